@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -33,9 +37,26 @@ type TickerData struct {
 
 	// The timestamps at which tickerValueHistory were updated
 	TickerTimestampHistory []int64
+
+	// The quotes of this ticker over time
+	// One-to-one with tickerTimestampHistory
+	TickerQuoteHistory []TickerQuote
+}
+
+type TickerQuote struct {
+	Bid     float64
+	Ask     float64
+	BidSize int64
+	AskSize int64
 }
 
 func main() {
+	serverAddr := flag.String("serverAddr", SERVER_ADDR, "The address of the server. Use an https:// address to connect over TLS.")
+	caFile := flag.String("caFile", "", "The PEM encoded CA certificate(s) to verify the server against, e.g. the self-signed certificate written by the server. If unset, the system roots are used.")
+	certFile := flag.String("certFile", "", "The PEM encoded client certificate file, for servers requiring mutual TLS.")
+	keyFile := flag.String("keyFile", "", "The PEM encoded private key file of the client certificate.")
+	flag.Parse()
+
 	slogHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		AddSource: true,
 		Level:     LOG_LEVEL,
@@ -53,10 +74,16 @@ func main() {
 	}
 	defer dataFile.Close()
 
+	httpClient, err := newHTTPClient(*caFile, *certFile, *keyFile)
+	if err != nil {
+		slog.Error("error when configuring http client", "err", err)
+		panic(err)
+	}
+
 	// Step One: Get all the ticker names for polling later.
 	client := tickerv1connect.NewTickerInfoServiceClient(
-		http.DefaultClient,
-		SERVER_ADDR,
+		httpClient,
+		*serverAddr,
 		// connect.WithGRPC(),
 	)
 	res, err := client.GetAllTickerNames(
@@ -80,6 +107,7 @@ func main() {
 			TickerName:             tickerName,
 			TickerValueHistory:     make([]float64, 0),
 			TickerTimestampHistory: make([]int64, 0),
+			TickerQuoteHistory:     make([]TickerQuote, 0),
 		})
 	}
 
@@ -91,8 +119,8 @@ func main() {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancelMap[tickerData.TickerName] = cancel
 		client := tickerv1connect.NewTickerInfoServiceClient(
-			http.DefaultClient,
-			SERVER_ADDR,
+			httpClient,
+			*serverAddr,
 			// connect.WithGRPC(),
 		)
 		serverPollingWaitgroup.Go(func() {
@@ -147,6 +175,12 @@ func pollTicker(ctx context.Context, client tickerv1connect.TickerInfoServiceCli
 
 			tickerData.TickerValueHistory = append(tickerData.TickerValueHistory, res.Msg.TickerValue)
 			tickerData.TickerTimestampHistory = append(tickerData.TickerTimestampHistory, res.Msg.LastUpdatedTimestamp)
+			tickerData.TickerQuoteHistory = append(tickerData.TickerQuoteHistory, TickerQuote{
+				Bid:     res.Msg.Quote.GetBid(),
+				Ask:     res.Msg.Quote.GetAsk(),
+				BidSize: res.Msg.Quote.GetBidSize(),
+				AskSize: res.Msg.Quote.GetAskSize(),
+			})
 			pollTotalDuration := time.Since(pollStartTime)
 			if pollTotalDuration > SERVER_POLLING_RATE {
 				slog.Warn("server polling function longer than SERVER_POLLING_RATE",
@@ -156,3 +190,45 @@ func pollTicker(ctx context.Context, client tickerv1connect.TickerInfoServiceCli
 		}
 	}
 }
+
+// Create the HTTP client used to connect to the server.
+// If no TLS options are given, the default client is used, which also connects to https:// addresses using the system roots.
+// Otherwise, the client trusts the given CA certificates, and presents the given client certificate (for mutual TLS).
+func newHTTPClient(caFile, certFile, keyFile string) (*http.Client, error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return http.DefaultClient, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no CA certificates found in %q", caFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if certFile != "" || keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	// Setting a TLS config disables HTTP/2 unless it is explicitly enabled.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+			Protocols:       protocols,
+		},
+	}, nil
+}
@@ -0,0 +1,184 @@
+// A minimal FIX 4.4 initiator, for testing the FIX market data gateway of the server.
+// Logs on, requests market data for the given symbols, and prints every message received until interrupted.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/fix"
+)
+
+const (
+	LOG_LEVEL          slog.Level    = slog.LevelInfo
+	SERVER_ADDR        string        = "localhost:9878"
+	SENDER_COMP_ID     string        = "CLIENT"
+	TARGET_COMP_ID     string        = "GENRON"
+	HEARTBEAT_INTERVAL time.Duration = 30 * time.Second
+	LOGOUT_TIMEOUT     time.Duration = 5 * time.Second
+)
+
+func main() {
+	serverAddr := flag.String("serverAddr", SERVER_ADDR, "The address of the FIX acceptor of the server.")
+	senderCompID := flag.String("senderCompID", SENDER_COMP_ID, "The CompID of this client.")
+	targetCompID := flag.String("targetCompID", TARGET_COMP_ID, "The CompID of the server.")
+	symbols := flag.String("symbols", "", "A comma separated list of the symbols (ticker names) to request market data for.")
+	entryTypes := flag.String("entryTypes", "0,1,2", "A comma separated list of the MDEntryTypes to request: 0 (bid), 1 (offer), and 2 (trade).")
+	updateType := flag.String("updateType", "incremental", "How updates are sent: incremental (MarketDataIncrementalRefresh), full (MarketDataSnapshotFullRefresh), or none (a single snapshot only).")
+	heartbeatInterval := flag.Duration("heartbeatInterval", HEARTBEAT_INTERVAL, "The heartbeat interval of the session, in whole seconds.")
+	flag.Parse()
+
+	slogHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: LOG_LEVEL,
+	})
+	slog.SetDefault(slog.New(slogHandler))
+
+	if *symbols == "" {
+		slog.Error("no symbols given, set -symbols")
+		os.Exit(1)
+	}
+	marketDataRequest := fix.NewMessage(fix.MsgTypeMarketDataRequest).
+		Add(fix.TagMDReqID, strconv.FormatInt(time.Now().UnixNano(), 10))
+	switch *updateType {
+	case "incremental":
+		marketDataRequest.Add(fix.TagSubscriptionRequestType, "1").Add(fix.TagMDUpdateType, "1")
+	case "full":
+		marketDataRequest.Add(fix.TagSubscriptionRequestType, "1").Add(fix.TagMDUpdateType, "0")
+	case "none":
+		marketDataRequest.Add(fix.TagSubscriptionRequestType, "0")
+	default:
+		slog.Error("unknown update type", "updateType", *updateType)
+		os.Exit(1)
+	}
+	entryTypeList := strings.Split(*entryTypes, ",")
+	marketDataRequest.
+		Add(fix.TagMarketDepth, "1").
+		AddInt(fix.TagNoMDEntryTypes, len(entryTypeList))
+	for _, entryType := range entryTypeList {
+		marketDataRequest.Add(fix.TagMDEntryType, entryType)
+	}
+	symbolList := strings.Split(*symbols, ",")
+	marketDataRequest.AddInt(fix.TagNoRelatedSym, len(symbolList))
+	for _, symbol := range symbolList {
+		marketDataRequest.Add(fix.TagSymbol, symbol)
+	}
+
+	conn, err := net.Dial("tcp", *serverAddr)
+	if err != nil {
+		slog.Error("error when connecting to server", "serverAddr", *serverAddr, "err", err)
+		panic(err)
+	}
+	defer conn.Close()
+
+	c := &client{
+		conn:         conn,
+		senderCompID: *senderCompID,
+		targetCompID: *targetCompID,
+		nextOutgoing: 1,
+	}
+	heartbeatSeconds := max(1, int(heartbeatInterval.Seconds()))
+	c.send(fix.NewMessage(fix.MsgTypeLogon).
+		Add(fix.TagEncryptMethod, "0").
+		AddInt(fix.TagHeartBtInt, heartbeatSeconds).
+		Add(fix.TagResetSeqNumFlag, "Y"))
+
+	incoming := make(chan *fix.Message)
+	go func() {
+		defer close(incoming)
+		reader := bufio.NewReader(conn)
+		for {
+			m, err := fix.ReadMessage(reader)
+			if errors.Is(err, fix.ErrorChecksum) {
+				slog.Warn("ignoring message with invalid checksum")
+				continue
+			} else if err != nil {
+				slog.Info("connection closed", "err", err)
+				return
+			}
+			incoming <- m
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	heartbeatTimer := time.NewTicker(time.Duration(heartbeatSeconds) * time.Second)
+	defer heartbeatTimer.Stop()
+
+	loggedOn := false
+	for {
+		select {
+		case <-ctx.Done():
+			c.send(fix.NewMessage(fix.MsgTypeLogout))
+			logoutTimeout := time.After(LOGOUT_TIMEOUT)
+			for {
+				select {
+				case m, ok := <-incoming:
+					if !ok || m.MsgType == fix.MsgTypeLogout {
+						return
+					}
+				case <-logoutTimeout:
+					slog.Warn("server did not confirm logout")
+					return
+				}
+			}
+		case <-heartbeatTimer.C:
+			c.send(fix.NewMessage(fix.MsgTypeHeartbeat))
+		case m, ok := <-incoming:
+			if !ok {
+				return
+			}
+			fmt.Println(m.String())
+			switch m.MsgType {
+			case fix.MsgTypeLogon:
+				if !loggedOn {
+					loggedOn = true
+					c.send(marketDataRequest)
+				}
+			case fix.MsgTypeTestRequest:
+				testRequestID, _ := m.Get(fix.TagTestReqID)
+				c.send(fix.NewMessage(fix.MsgTypeHeartbeat).Add(fix.TagTestReqID, testRequestID))
+			case fix.MsgTypeResendRequest:
+				// Nothing sent by this client is worth resending.
+				beginSeqNo, _ := m.GetInt(fix.TagBeginSeqNo)
+				c.write(fix.NewMessage(fix.MsgTypeSequenceReset).
+					Add(fix.TagPossDupFlag, "Y").
+					Add(fix.TagOrigSendingTime, time.Now().UTC().Format(fix.TimestampFormat)).
+					Add(fix.TagGapFillFlag, "Y").
+					AddInt(fix.TagNewSeqNo, c.nextOutgoing), beginSeqNo)
+			case fix.MsgTypeLogout:
+				c.send(fix.NewMessage(fix.MsgTypeLogout))
+				return
+			}
+		}
+	}
+}
+
+type client struct {
+	conn         net.Conn
+	senderCompID string
+	targetCompID string
+	nextOutgoing int
+}
+
+func (c *client) send(m *fix.Message) {
+	c.write(m, c.nextOutgoing)
+	c.nextOutgoing++
+}
+
+func (c *client) write(m *fix.Message, msgSeqNum int) {
+	if _, err := c.conn.Write(m.Encode(c.senderCompID, c.targetCompID, msgSeqNum, time.Now())); err != nil {
+		slog.Error("error when sending message", "msgType", m.MsgType, "err", err)
+		panic(err)
+	}
+}
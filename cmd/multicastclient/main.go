@@ -0,0 +1,208 @@
+// A minimal consumer of the multicast feed of the server, for testing.
+// Prints every message received, and recovers any gap in the sequence numbers from the retransmission service.
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/multicast"
+)
+
+const (
+	LOG_LEVEL       slog.Level = slog.LevelInfo
+	FEED_ADDR       string     = "239.192.0.1:30001"
+	RETRANSMIT_ADDR string     = "localhost:30002"
+	MAX_PACKET_SIZE int        = 65536
+)
+
+type consumer struct {
+	retransmitAddr string
+	retransmitConn net.Conn
+
+	session            string
+	nextSequenceNumber uint64
+	tickerNames        map[uint16]string
+}
+
+func main() {
+	feedAddr := flag.String("feedAddr", FEED_ADDR, "The address the feed is published to. Either a multicast group to join, or a unicast address to listen on.")
+	interfaceName := flag.String("interface", "", "The name of the network interface to join the multicast group on. If unset, the system default is used.")
+	retransmitAddr := flag.String("retransmitAddr", RETRANSMIT_ADDR, "The address of the retransmission service. If empty, gaps are reported but not recovered.")
+	dropRate := flag.Float64("dropRate", 0, "The fraction of packets to drop on purpose, to test gap recovery.")
+	flag.Parse()
+
+	slogHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: LOG_LEVEL,
+	})
+	slog.SetDefault(slog.New(slogHandler))
+
+	udpAddr, err := net.ResolveUDPAddr("udp", *feedAddr)
+	if err != nil {
+		slog.Error("invalid feed address", "feedAddr", *feedAddr, "err", err)
+		panic(err)
+	}
+	var conn *net.UDPConn
+	if udpAddr.IP.IsMulticast() {
+		var multicastInterface *net.Interface
+		if *interfaceName != "" {
+			multicastInterface, err = net.InterfaceByName(*interfaceName)
+			if err != nil {
+				slog.Error("invalid interface", "interface", *interfaceName, "err", err)
+				panic(err)
+			}
+		}
+		conn, err = net.ListenMulticastUDP("udp", multicastInterface, udpAddr)
+	} else {
+		conn, err = net.ListenUDP("udp", udpAddr)
+	}
+	if err != nil {
+		slog.Error("error when listening for feed", "feedAddr", *feedAddr, "err", err)
+		panic(err)
+	}
+	defer conn.Close()
+	slog.Info("listening for feed", "feedAddr", *feedAddr)
+
+	c := &consumer{
+		retransmitAddr: *retransmitAddr,
+		tickerNames:    make(map[uint16]string),
+	}
+	packet := make([]byte, MAX_PACKET_SIZE)
+	for {
+		n, err := conn.Read(packet)
+		if err != nil {
+			slog.Error("error when reading feed", "err", err)
+			panic(err)
+		}
+		if rand.Float64() < *dropRate {
+			continue
+		}
+		if ended := c.handlePacket(packet[:n]); ended {
+			return
+		}
+	}
+}
+
+// Handle a packet from the feed, recovering any gap before it. Returns whether the session has ended.
+func (c *consumer) handlePacket(packet []byte) bool {
+	header, messages, err := multicast.ParsePacket(packet)
+	if err != nil {
+		slog.Warn("invalid packet", "err", err)
+		return false
+	}
+	if header.Session != c.session {
+		slog.Info("new session", "session", header.Session, "sequenceNumber", header.SequenceNumber)
+		c.session = header.Session
+		c.nextSequenceNumber = header.SequenceNumber
+		if c.retransmitConn != nil {
+			c.retransmitConn.Close()
+			c.retransmitConn = nil
+		}
+	}
+
+	if header.SequenceNumber > c.nextSequenceNumber {
+		c.recoverGap(header.SequenceNumber)
+	}
+	if header.MessageCount == multicast.EndOfSessionMessageCount {
+		slog.Info("end of session", "session", header.Session)
+		return true
+	}
+	for i, message := range messages {
+		sequenceNumber := header.SequenceNumber + uint64(i)
+		if sequenceNumber < c.nextSequenceNumber {
+			continue
+		}
+		c.printMessage(sequenceNumber, message, false)
+		c.nextSequenceNumber = sequenceNumber + 1
+	}
+	return false
+}
+
+// Request the messages before the given sequence number from the retransmission service, until the gap is filled.
+func (c *consumer) recoverGap(untilSequenceNumber uint64) {
+	slog.Warn("gap detected", "from", c.nextSequenceNumber, "until", untilSequenceNumber)
+	for c.nextSequenceNumber < untilSequenceNumber {
+		if c.retransmitAddr == "" {
+			c.nextSequenceNumber = untilSequenceNumber
+			return
+		}
+		messageCount := min(untilSequenceNumber-c.nextSequenceNumber, multicast.EndOfSessionMessageCount-1)
+		header, messages, err := c.requestRetransmission(c.nextSequenceNumber, uint16(messageCount))
+		if err != nil {
+			slog.Error("error when requesting retransmission, skipping gap", "err", err)
+			c.nextSequenceNumber = untilSequenceNumber
+			return
+		}
+		if header.SequenceNumber > c.nextSequenceNumber {
+			slog.Warn("messages no longer available for retransmission, skipping", "from", c.nextSequenceNumber, "until", header.SequenceNumber)
+			c.nextSequenceNumber = min(header.SequenceNumber, untilSequenceNumber)
+			continue
+		}
+		if len(messages) == 0 {
+			slog.Error("retransmission returned no messages, skipping gap")
+			c.nextSequenceNumber = untilSequenceNumber
+			return
+		}
+		for i, message := range messages {
+			c.printMessage(header.SequenceNumber+uint64(i), message, true)
+		}
+		c.nextSequenceNumber = header.SequenceNumber + uint64(len(messages))
+	}
+}
+
+func (c *consumer) requestRetransmission(sequenceNumber uint64, messageCount uint16) (multicast.PacketHeader, [][]byte, error) {
+	if c.retransmitConn == nil {
+		conn, err := net.Dial("tcp", c.retransmitAddr)
+		if err != nil {
+			return multicast.PacketHeader{}, nil, err
+		}
+		c.retransmitConn = conn
+	}
+	request := multicast.AppendPacketHeader(nil, multicast.PacketHeader{Session: c.session, SequenceNumber: sequenceNumber, MessageCount: messageCount})
+	c.retransmitConn.SetDeadline(time.Now().Add(5 * time.Second))
+	if _, err := c.retransmitConn.Write(request); err != nil {
+		return multicast.PacketHeader{}, nil, err
+	}
+	var length [2]byte
+	if _, err := io.ReadFull(c.retransmitConn, length[:]); err != nil {
+		return multicast.PacketHeader{}, nil, err
+	}
+	packet := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(c.retransmitConn, packet); err != nil {
+		return multicast.PacketHeader{}, nil, err
+	}
+	return multicast.ParsePacket(packet)
+}
+
+func (c *consumer) printMessage(sequenceNumber uint64, message []byte, retransmitted bool) {
+	parsed, err := multicast.ParseMessage(message)
+	if err != nil {
+		slog.Warn("invalid message", "sequenceNumber", sequenceNumber, "err", err)
+		return
+	}
+	source := "feed"
+	if retransmitted {
+		source = "retransmit"
+	}
+	switch m := parsed.(type) {
+	case multicast.SystemEvent:
+		fmt.Printf("%d\t%s\tsystem event %q\t%s\n", sequenceNumber, source, m.EventCode, m.Timestamp.Format(time.RFC3339Nano))
+	case multicast.TickerDirectory:
+		c.tickerNames[m.Locate] = m.Name
+		fmt.Printf("%d\t%s\tdirectory %d=%s\n", sequenceNumber, source, m.Locate, m.Name)
+	case multicast.PriceUpdate:
+		// Consumers joining late only learn the ticker names once the directory is repeated.
+		tickerName, ok := c.tickerNames[m.Locate]
+		if !ok {
+			tickerName = fmt.Sprintf("locate %d", m.Locate)
+		}
+		fmt.Printf("%d\t%s\t%s\t%s\t%v\n", sequenceNumber, source, tickerName, m.Timestamp.Format(time.RFC3339Nano), m.Value())
+	}
+}
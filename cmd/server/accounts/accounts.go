@@ -0,0 +1,259 @@
+// Package accounts keeps the cash, positions, and profit and loss of every client of the order engine,
+// booked from the executions of their simulated orders and marked to market at the ticker values.
+package accounts
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/orders"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// The position of an account in a single ticker, marked to market at the ticker value.
+type Position struct {
+	TickerName string
+
+	// Positive for long positions, and negative for short positions.
+	Size int64
+
+	// The average price of the open position, or zero if the position is flat.
+	AveragePrice float64
+
+	MarketPrice   float64
+	MarketValue   float64
+	RealisedPnL   float64
+	UnrealisedPnL float64
+}
+
+// The cash, positions, profit and loss, and margin of the account of a client, at one point in time.
+type Portfolio struct {
+	ClientName string
+	Timestamp  time.Time
+	Cash       float64
+
+	// Every ticker the account has traded, sorted by ticker name.
+	Positions []Position
+
+	// The total market value of the positions. Short positions have a negative market value.
+	MarketValue float64
+
+	// The cash plus the market value of the positions.
+	Equity float64
+
+	RealisedPnL   float64
+	UnrealisedPnL float64
+
+	// The margin required for the positions, and the equity in excess of it.
+	Margin       float64
+	ExcessMargin float64
+}
+
+// The booked state of the account of a client.
+type account struct {
+	cash      float64
+	positions map[string]*position
+}
+
+type position struct {
+	size         int64
+	averagePrice float64
+	realisedPnL  float64
+}
+
+// Books the executions of the order engine to the account of each client.
+// Accounts are created with the initial cash at the first execution of a client.
+// Safe for concurrent use.
+type Book struct {
+	cfg     config.AccountsConfig
+	tickers map[string]ticker.Ticker
+
+	mu       sync.RWMutex
+	accounts map[string]*account
+}
+
+func NewBook(cfg config.AccountsConfig, tickers map[string]ticker.Ticker) *Book {
+	return &Book{
+		cfg:      cfg,
+		tickers:  tickers,
+		accounts: make(map[string]*account),
+	}
+}
+
+// Book every fill of the engine as it is executed, from within the engine, so that no fill is ever missed,
+// and the portfolio of a client includes every fill the client has been sent.
+// If margin is enforced, also check the margin of every order as it arrives at the engine.
+func (b *Book) Start(engine *orders.Engine) {
+	engine.OnExecution(func(execution orders.Execution) {
+		if execution.Type == orders.ExecutionTypeTrade {
+			b.book(execution)
+		}
+	})
+	if b.cfg.EnforceMargin {
+		engine.AddCheck(orders.RejectReasonMargin, b.checkMargin)
+	}
+}
+
+// Book a fill to the account of the owner of the order, using average cost accounting.
+// Fills that reduce a position realise the difference between the fill price and the average price of the position.
+func (b *Book) book(execution orders.Execution) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	owner := execution.Order.Owner
+	acc, ok := b.accounts[owner]
+	if !ok {
+		acc = &account{
+			cash:      b.cfg.InitialCash,
+			positions: make(map[string]*position),
+		}
+		b.accounts[owner] = acc
+	}
+	pos, ok := acc.positions[execution.Order.TickerName]
+	if !ok {
+		pos = &position{}
+		acc.positions[execution.Order.TickerName] = pos
+	}
+
+	fillSize := execution.FillSize
+	if execution.Order.Side == ticker.SideSell {
+		fillSize = -fillSize
+	}
+	acc.cash -= float64(fillSize) * execution.FillPrice
+
+	if pos.size == 0 || (pos.size > 0) == (fillSize > 0) {
+		// Opening or increasing the position.
+		pos.averagePrice = (pos.averagePrice*math.Abs(float64(pos.size)) + execution.FillPrice*math.Abs(float64(fillSize))) /
+			math.Abs(float64(pos.size+fillSize))
+		pos.size += fillSize
+		return
+	}
+
+	// Reducing, closing, or reversing the position.
+	closedSize := min(abs(fillSize), abs(pos.size))
+	pnlPerUnit := execution.FillPrice - pos.averagePrice
+	if pos.size < 0 {
+		pnlPerUnit = -pnlPerUnit
+	}
+	pos.realisedPnL += float64(closedSize) * pnlPerUnit
+	reversed := abs(fillSize) > abs(pos.size)
+	pos.size += fillSize
+	if pos.size == 0 {
+		pos.averagePrice = 0
+	} else if reversed {
+		pos.averagePrice = execution.FillPrice
+	}
+}
+
+// Check that the account of the owner of an arriving order would have the margin required if the order filled in full,
+// at its limit price (or the quote, for a market order), with every position marked to market at the current ticker values.
+// Returns a message for the client if the order is rejected, or an empty string if it is accepted.
+// Orders that do not increase the size of the position are always accepted, so an account short of margin can still trade out.
+//
+// Called by the engine with the engine mutex locked, see orders.Engine.AddCheck.
+func (b *Book) checkMargin(order orders.Order, info ticker.TickerInfo) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	fillSize := order.Size
+	fillPrice := info.Quote.Ask
+	hasPrice := info.Quote.HasAsk
+	if order.Side == ticker.SideSell {
+		fillSize = -fillSize
+		fillPrice, hasPrice = info.Quote.Bid, info.Quote.HasBid
+	}
+	if order.Type == orders.OrderTypeLimit {
+		fillPrice, hasPrice = order.LimitPrice, true
+	}
+	// A market order against a side with no price fills at a later quote, so is checked at the value.
+	if !hasPrice {
+		fillPrice = info.Value
+	}
+
+	cash := b.cfg.InitialCash
+	sizes := make(map[string]int64)
+	if acc, ok := b.accounts[order.Owner]; ok {
+		cash = acc.cash
+		for tickerName, pos := range acc.positions {
+			sizes[tickerName] = pos.size
+		}
+	}
+	if abs(sizes[order.TickerName]+fillSize) <= abs(sizes[order.TickerName]) {
+		return ""
+	}
+	cash -= float64(fillSize) * fillPrice
+	sizes[order.TickerName] += fillSize
+
+	equity, margin := cash, 0.0
+	for tickerName, size := range sizes {
+		marketPrice := info.Value
+		if tickerName != order.TickerName {
+			_, marketPrice, _, _ = b.tickers[tickerName].GetInfo()
+		}
+		marketValue := float64(size) * marketPrice
+		equity += marketValue
+		margin += b.cfg.MarginRate * math.Abs(marketValue)
+	}
+	if equity < margin {
+		return fmt.Sprintf("order requires margin of %g, but the account would have equity of %g", margin, equity)
+	}
+	return ""
+}
+
+// Get the portfolio of a client, marked to market at the current ticker values.
+// A client with no executions has the initial cash, and no positions.
+func (b *Book) Portfolio(clientName string) Portfolio {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	portfolio := Portfolio{
+		ClientName: clientName,
+		Timestamp:  time.Now(),
+		Cash:       b.cfg.InitialCash,
+	}
+	acc, ok := b.accounts[clientName]
+	if !ok {
+		portfolio.Equity = portfolio.Cash
+		portfolio.ExcessMargin = portfolio.Equity
+		return portfolio
+	}
+
+	portfolio.Cash = acc.cash
+	portfolio.Positions = make([]Position, 0, len(acc.positions))
+	for tickerName, pos := range acc.positions {
+		_, marketPrice, _, _ := b.tickers[tickerName].GetInfo()
+		marketValue := float64(pos.size) * marketPrice
+		unrealisedPnL := float64(pos.size) * (marketPrice - pos.averagePrice)
+		portfolio.Positions = append(portfolio.Positions, Position{
+			TickerName:    tickerName,
+			Size:          pos.size,
+			AveragePrice:  pos.averagePrice,
+			MarketPrice:   marketPrice,
+			MarketValue:   marketValue,
+			RealisedPnL:   pos.realisedPnL,
+			UnrealisedPnL: unrealisedPnL,
+		})
+		portfolio.MarketValue += marketValue
+		portfolio.RealisedPnL += pos.realisedPnL
+		portfolio.UnrealisedPnL += unrealisedPnL
+		portfolio.Margin += b.cfg.MarginRate * math.Abs(marketValue)
+	}
+	slices.SortFunc(portfolio.Positions, func(a, b Position) int {
+		return strings.Compare(a.TickerName, b.TickerName)
+	})
+	portfolio.Equity = portfolio.Cash + portfolio.MarketValue
+	portfolio.ExcessMargin = portfolio.Equity - portfolio.Margin
+	return portfolio
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
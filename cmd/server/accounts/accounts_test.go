@@ -0,0 +1,318 @@
+package accounts
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/calendar"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/orders"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// A ticker with a value set by the test, quoted a tenth either side of the value with no size limit.
+// Only the methods used by the book and the order engine are implemented.
+type fakeTicker struct {
+	ticker.Ticker
+	name  string
+	value float64
+}
+
+func (t *fakeTicker) GetInfo() (string, float64, time.Time, time.Duration) {
+	return t.name, t.value, time.Time{}, time.Second
+}
+
+func (t *fakeTicker) GetSnapshot() ticker.TickerInfo {
+	return ticker.TickerInfo{
+		Name:  t.name,
+		Value: t.value,
+		Quote: ticker.Quote{Bid: t.value - 0.1, Ask: t.value + 0.1, HasBid: true, HasAsk: true},
+	}
+}
+
+func (t *fakeTicker) GetMarketStatus(at time.Time) calendar.Status {
+	return calendar.Status{Open: true}
+}
+
+func (t *fakeTicker) RecordExecution(side ticker.Side, size int64) {}
+
+func checkClose(t *testing.T, name string, got float64, want float64) {
+	t.Helper()
+
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s is %g, expected %g", name, got, want)
+	}
+}
+
+// A fill of an order of the client "client" in the ticker "T".
+type testFill struct {
+	side  ticker.Side
+	size  int64
+	price float64
+}
+
+func TestBookAverageCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		fills       []testFill
+		marketPrice float64
+
+		wantSize          int64
+		wantAveragePrice  float64
+		wantRealisedPnL   float64
+		wantUnrealisedPnL float64
+	}{
+		{
+			name:              "opening a long position",
+			fills:             []testFill{{ticker.SideBuy, 100, 10}},
+			marketPrice:       12,
+			wantSize:          100,
+			wantAveragePrice:  10,
+			wantUnrealisedPnL: 200,
+		},
+		{
+			name:              "adding to a long position",
+			fills:             []testFill{{ticker.SideBuy, 100, 10}, {ticker.SideBuy, 300, 14}},
+			marketPrice:       12,
+			wantSize:          400,
+			wantAveragePrice:  13,
+			wantUnrealisedPnL: -400,
+		},
+		{
+			name:              "reducing a long position",
+			fills:             []testFill{{ticker.SideBuy, 100, 10}, {ticker.SideSell, 40, 15}},
+			marketPrice:       12,
+			wantSize:          60,
+			wantAveragePrice:  10,
+			wantRealisedPnL:   200,
+			wantUnrealisedPnL: 120,
+		},
+		{
+			name:             "closing a long position",
+			fills:            []testFill{{ticker.SideBuy, 100, 10}, {ticker.SideSell, 100, 8}},
+			marketPrice:      12,
+			wantSize:         0,
+			wantAveragePrice: 0,
+			wantRealisedPnL:  -200,
+		},
+		{
+			name:              "reversing a long position through flat",
+			fills:             []testFill{{ticker.SideBuy, 100, 10}, {ticker.SideSell, 150, 12}},
+			marketPrice:       11,
+			wantSize:          -50,
+			wantAveragePrice:  12,
+			wantRealisedPnL:   200,
+			wantUnrealisedPnL: 50,
+		},
+		{
+			name:              "adding to then reducing a short position",
+			fills:             []testFill{{ticker.SideSell, 100, 20}, {ticker.SideSell, 100, 22}, {ticker.SideBuy, 50, 19}},
+			marketPrice:       20,
+			wantSize:          -150,
+			wantAveragePrice:  21,
+			wantRealisedPnL:   100,
+			wantUnrealisedPnL: 150,
+		},
+		{
+			name:              "reversing a short position through flat",
+			fills:             []testFill{{ticker.SideSell, 100, 20}, {ticker.SideBuy, 300, 18}},
+			marketPrice:       17,
+			wantSize:          200,
+			wantAveragePrice:  18,
+			wantRealisedPnL:   200,
+			wantUnrealisedPnL: -200,
+		},
+		{
+			name:              "reopening after closing",
+			fills:             []testFill{{ticker.SideBuy, 100, 10}, {ticker.SideSell, 100, 11}, {ticker.SideSell, 50, 9}},
+			marketPrice:       10,
+			wantSize:          -50,
+			wantAveragePrice:  9,
+			wantRealisedPnL:   100,
+			wantUnrealisedPnL: -50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const initialCash = 1_000_000
+			b := NewBook(config.AccountsConfig{InitialCash: initialCash, MarginRate: 0.25}, map[string]ticker.Ticker{
+				"T": &fakeTicker{name: "T", value: tt.marketPrice},
+			})
+			wantCash := float64(initialCash)
+			for _, fill := range tt.fills {
+				b.book(orders.Execution{
+					Type: orders.ExecutionTypeTrade,
+					Order: orders.Order{OrderRequest: orders.OrderRequest{
+						Owner:      "client",
+						TickerName: "T",
+						Side:       fill.side,
+					}},
+					FillSize:  fill.size,
+					FillPrice: fill.price,
+				})
+				if fill.side == ticker.SideBuy {
+					wantCash -= float64(fill.size) * fill.price
+				} else {
+					wantCash += float64(fill.size) * fill.price
+				}
+			}
+
+			portfolio := b.Portfolio("client")
+			if len(portfolio.Positions) != 1 {
+				t.Fatalf("portfolio has %d positions, expected 1", len(portfolio.Positions))
+			}
+			pos := portfolio.Positions[0]
+			if pos.Size != tt.wantSize {
+				t.Errorf("size is %d, expected %d", pos.Size, tt.wantSize)
+			}
+			checkClose(t, "average price", pos.AveragePrice, tt.wantAveragePrice)
+			checkClose(t, "realised PnL", pos.RealisedPnL, tt.wantRealisedPnL)
+			checkClose(t, "unrealised PnL", pos.UnrealisedPnL, tt.wantUnrealisedPnL)
+			checkClose(t, "market value", pos.MarketValue, float64(tt.wantSize)*tt.marketPrice)
+			checkClose(t, "cash", portfolio.Cash, wantCash)
+
+			// Equity is always the initial cash plus the profit and loss.
+			checkClose(t, "equity", portfolio.Equity, initialCash+tt.wantRealisedPnL+tt.wantUnrealisedPnL)
+			checkClose(t, "margin", portfolio.Margin, 0.25*math.Abs(float64(tt.wantSize)*tt.marketPrice))
+			checkClose(t, "excess margin", portfolio.ExcessMargin, portfolio.Equity-portfolio.Margin)
+		})
+	}
+}
+
+func TestBookPortfolioWithoutExecutions(t *testing.T) {
+	b := NewBook(config.AccountsConfig{InitialCash: 1000, MarginRate: 0.25}, map[string]ticker.Ticker{})
+	portfolio := b.Portfolio("client")
+	if portfolio.Cash != 1000 || portfolio.Equity != 1000 || portfolio.ExcessMargin != 1000 || len(portfolio.Positions) != 0 {
+		t.Errorf("portfolio is %+v, expected only the initial cash", portfolio)
+	}
+}
+
+func TestBookMargin(t *testing.T) {
+	// An order of the client. A non-zero value first moves the value of the ticker of the order.
+	type testOrder struct {
+		tickerName   string
+		value        float64
+		side         ticker.Side
+		size         int64
+		limitPrice   float64
+		wantRejected bool
+	}
+
+	// With an initial cash of 1000, a margin rate of one half, and a value of 10 (quoted at 9.9 and 10.1),
+	// a position of about 200 either way uses up the margin of the account.
+	tests := []struct {
+		name          string
+		enforceMargin bool
+		orders        []testOrder
+	}{
+		{
+			name:          "market buy within margin",
+			enforceMargin: true,
+			orders:        []testOrder{{tickerName: "T", side: ticker.SideBuy, size: 100}},
+		},
+		{
+			name:          "market buy beyond margin",
+			enforceMargin: true,
+			orders:        []testOrder{{tickerName: "T", side: ticker.SideBuy, size: 200, wantRejected: true}},
+		},
+		{
+			name:          "market sell beyond margin",
+			enforceMargin: true,
+			orders:        []testOrder{{tickerName: "T", side: ticker.SideSell, size: 200, wantRejected: true}},
+		},
+		{
+			name:          "limit buy checked at its limit price",
+			enforceMargin: true,
+			orders: []testOrder{
+				{tickerName: "T", side: ticker.SideBuy, size: 190, limitPrice: 10.5, wantRejected: true},
+				{tickerName: "T", side: ticker.SideBuy, size: 190},
+			},
+		},
+		{
+			name:          "adding to a position beyond margin",
+			enforceMargin: true,
+			orders: []testOrder{
+				{tickerName: "T", side: ticker.SideBuy, size: 100},
+				{tickerName: "T", side: ticker.SideBuy, size: 100, wantRejected: true},
+			},
+		},
+		{
+			name:          "positions in other tickers use margin",
+			enforceMargin: true,
+			orders: []testOrder{
+				{tickerName: "U", side: ticker.SideBuy, size: 50},
+				{tickerName: "T", side: ticker.SideBuy, size: 150, wantRejected: true},
+				{tickerName: "T", side: ticker.SideBuy, size: 100},
+			},
+		},
+		{
+			name:          "reducing a position short of margin is accepted",
+			enforceMargin: true,
+			orders: []testOrder{
+				{tickerName: "T", side: ticker.SideBuy, size: 150},
+				{tickerName: "T", value: 5, side: ticker.SideBuy, size: 1, wantRejected: true},
+				{tickerName: "T", side: ticker.SideSell, size: 50},
+			},
+		},
+		{
+			name:          "reversing a position is checked",
+			enforceMargin: true,
+			orders: []testOrder{
+				{tickerName: "T", side: ticker.SideBuy, size: 100},
+				{tickerName: "T", side: ticker.SideSell, size: 400, wantRejected: true},
+				{tickerName: "T", side: ticker.SideSell, size: 200},
+			},
+		},
+		{
+			name:          "margin not enforced",
+			enforceMargin: false,
+			orders:        []testOrder{{tickerName: "T", side: ticker.SideBuy, size: 1000}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickers := map[string]ticker.Ticker{
+				"T": &fakeTicker{name: "T", value: 10},
+				"U": &fakeTicker{name: "U", value: 10},
+			}
+			seed := int64(1)
+			engine := orders.NewEngine(config.OrdersConfig{RandomSeed: &seed}, tickers)
+			b := NewBook(config.AccountsConfig{InitialCash: 1000, MarginRate: 0.5, EnforceMargin: tt.enforceMargin}, tickers)
+			b.Start(engine)
+
+			var rejected map[uint64]string
+			engine.OnExecution(func(execution orders.Execution) {
+				if execution.Type == orders.ExecutionTypeRejected {
+					rejected[execution.Order.ID] = execution.Order.RejectReason
+				}
+			})
+			for i, o := range tt.orders {
+				if o.value != 0 {
+					tickers[o.tickerName].(*fakeTicker).value = o.value
+				}
+				req := orders.OrderRequest{
+					Owner:       "client",
+					TickerName:  o.tickerName,
+					Side:        o.side,
+					Type:        orders.OrderTypeMarket,
+					TimeInForce: orders.TimeInForceIOC,
+					Size:        o.size,
+				}
+				if o.limitPrice != 0 {
+					req.Type = orders.OrderTypeLimit
+					req.LimitPrice = o.limitPrice
+				}
+
+				rejected = make(map[uint64]string)
+				order, err := engine.Submit(req)
+				if err != nil {
+					t.Fatalf("error submitting order %d: %v", i, err)
+				}
+				if reason, ok := rejected[order.ID]; ok != o.wantRejected {
+					t.Errorf("order %d rejected is %v (%q), expected %v", i, ok, reason, o.wantRejected)
+				}
+			}
+		})
+	}
+}
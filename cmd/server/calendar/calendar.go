@@ -0,0 +1,266 @@
+// Package calendar models the trading sessions of exchanges: the time zone, the daily open and close times,
+// the trading days of the week, holidays, and half-days with an early close.
+// Tickers assigned to a calendar only update during its sessions.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	// Embed the time zone database, so calendars work in minimal containers without one.
+	_ "time/tzdata"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/spf13/viper"
+)
+
+var (
+	ErrorUnknownCalendar = errors.New("no calendar exists with requested name")
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+
+	// The furthest a search for the next session looks, in days.
+	// Long enough for any real calendar, but bounded for calendars with no sessions at all.
+	maxSearchDays = 366
+)
+
+type CalendarConfig struct {
+	// Set from the calendar key by ParseCalendars, rather than in the calendar config itself.
+	Name string `mapstructure:"name" schema:"-"`
+
+	Timezone    string   `mapstructure:"timezone" default:"UTC" description:"The IANA time zone of the open and close times, and of the dates, e.g. America/New_York."`
+	Open        string   `mapstructure:"open" default:"09:30" description:"The time of the open of each session, as HH:MM in the time zone."`
+	Close       string   `mapstructure:"close" default:"16:00" description:"The time of the close of each session, as HH:MM in the time zone. Must be after the open, so sessions cannot span midnight."`
+	EarlyClose  string   `mapstructure:"earlyclose" default:"13:00" description:"The time of the close of half-day sessions, as HH:MM in the time zone. Must be after the open, if there are any half-days."`
+	TradingDays []string `mapstructure:"tradingdays" description:"The days of the week with a session, e.g. [\"mon\", \"tue\"]. If empty, Monday to Friday."`
+	Holidays    []string `mapstructure:"holidays" description:"The dates with no session, as YYYY-MM-DD."`
+	HalfDays    []string `mapstructure:"halfdays" description:"The dates whose session closes early, at the early close, as YYYY-MM-DD."`
+}
+
+// The trading sessions of an exchange. Immutable once parsed, so safe for concurrent use.
+type Calendar struct {
+	name       string
+	location   *time.Location
+	open       time.Duration
+	close      time.Duration
+	earlyClose time.Duration
+
+	tradingDays map[time.Weekday]struct{}
+	holidays    map[string]struct{}
+	halfDays    map[string]struct{}
+}
+
+// The status of the market of a calendar at one point in time.
+type Status struct {
+	// The name of the calendar, or empty for a ticker with no calendar (which is always open).
+	Calendar string
+
+	Open bool
+
+	// The open of the current session, if the market is open.
+	SessionOpen time.Time
+
+	// The next open after the given time, and the next close at or after it.
+	// Zero if there is none (e.g. for a ticker with no calendar).
+	NextOpen  time.Time
+	NextClose time.Time
+}
+
+// Create a calendar from its (already decoded and validated) config.
+func NewCalendar(cfg CalendarConfig) (*Calendar, error) {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("calendar %q has invalid time zone: %w", cfg.Name, err)
+	}
+	c := &Calendar{
+		name:        cfg.Name,
+		location:    location,
+		tradingDays: make(map[time.Weekday]struct{}),
+		holidays:    make(map[string]struct{}, len(cfg.Holidays)),
+		halfDays:    make(map[string]struct{}, len(cfg.HalfDays)),
+	}
+
+	for _, clock := range []struct {
+		key   string
+		value string
+		field *time.Duration
+	}{
+		{"open", cfg.Open, &c.open},
+		{"close", cfg.Close, &c.close},
+		{"earlyclose", cfg.EarlyClose, &c.earlyClose},
+	} {
+		parsed, err := time.Parse(clockLayout, clock.value)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %q has invalid %s time %q, expected HH:MM", cfg.Name, clock.key, clock.value)
+		}
+		*clock.field = time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+	}
+	if c.close <= c.open {
+		return nil, fmt.Errorf("calendar %q must close after it opens", cfg.Name)
+	}
+	// The early close is only used on half-days, so the default is not checked against a late open.
+	if len(cfg.HalfDays) > 0 && c.earlyClose <= c.open {
+		return nil, fmt.Errorf("calendar %q must close early after it opens", cfg.Name)
+	}
+
+	tradingDays := cfg.TradingDays
+	if len(tradingDays) == 0 {
+		tradingDays = []string{"mon", "tue", "wed", "thu", "fri"}
+	}
+	for _, day := range tradingDays {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return nil, fmt.Errorf("calendar %q has invalid trading day %q", cfg.Name, day)
+		}
+		c.tradingDays[weekday] = struct{}{}
+	}
+
+	for _, dates := range []struct {
+		key   string
+		dates []string
+		set   map[string]struct{}
+	}{
+		{"holiday", cfg.Holidays, c.holidays},
+		{"half-day", cfg.HalfDays, c.halfDays},
+	} {
+		for _, date := range dates.dates {
+			if _, err := time.Parse(dateLayout, date); err != nil {
+				return nil, fmt.Errorf("calendar %q has invalid %s %q, expected YYYY-MM-DD", cfg.Name, dates.key, date)
+			}
+			dates.set[date] = struct{}{}
+		}
+	}
+	return c, nil
+}
+
+// Parse a day of the week from its name, or the first three letters of its name, in any case.
+func parseWeekday(day string) (time.Weekday, bool) {
+	day = strings.ToLower(day)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if day == name || day == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Calendar) String() string {
+	return c.name
+}
+
+// Get the time zone of the calendar.
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// Get the open and close of the session on the date of the given time (in the calendar time zone).
+// Returns false if there is no session on the date, i.e. a holiday, or a day of the week without trading.
+func (c *Calendar) session(date time.Time) (time.Time, time.Time, bool) {
+	date = date.In(c.location)
+	if _, ok := c.tradingDays[date.Weekday()]; !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	if _, ok := c.holidays[date.Format(dateLayout)]; ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	closeTime := c.close
+	if _, ok := c.halfDays[date.Format(dateLayout)]; ok {
+		closeTime = c.earlyClose
+	}
+	year, month, day := date.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, c.location)
+	return clockTime(midnight, c.open), clockTime(midnight, closeTime), true
+}
+
+// Get the given time of day on the date of the given midnight.
+// The hours and minutes are set directly, rather than added, so that the time of day is kept across a daylight saving change.
+func clockTime(midnight time.Time, timeOfDay time.Duration) time.Time {
+	year, month, day := midnight.Date()
+	return time.Date(year, month, day, int(timeOfDay/time.Hour), int(timeOfDay%time.Hour/time.Minute), 0, 0, midnight.Location())
+}
+
+// Get the date a number of days after the date of the given time, in the calendar time zone.
+func (c *Calendar) addDays(t time.Time, days int) time.Time {
+	year, month, day := t.In(c.location).Date()
+	return time.Date(year, month, day+days, 12, 0, 0, 0, c.location)
+}
+
+// Get the status of the market at the given time.
+func (c *Calendar) Status(t time.Time) Status {
+	status := Status{Calendar: c.name}
+	if open, close, ok := c.session(t); ok && !t.Before(open) && t.Before(close) {
+		status.Open = true
+		status.SessionOpen = open
+		status.NextClose = close
+	}
+
+	for i := range maxSearchDays {
+		open, close, ok := c.session(c.addDays(t, i))
+		if ok && open.After(t) {
+			status.NextOpen = open
+			if !status.Open {
+				status.NextClose = close
+			}
+			break
+		}
+	}
+	return status
+}
+
+// --------------------------------------------------------------------------------
+
+var (
+	calendarsMutex sync.RWMutex
+	calendars      = make(map[string]*Calendar)
+)
+
+// Parse calendars from the viper config, looking into the `calendars` map to find definitions.
+// Calendars must be parsed before the tickers, so that tickers can be assigned to them by name.
+func ParseCalendars() error {
+	calendarsMutex.Lock()
+	defer calendarsMutex.Unlock()
+
+	for calendarName := range viper.GetStringMap("calendars") {
+		calendarConfig := viper.Sub("calendars." + calendarName)
+		calendarConfig.Set("name", calendarName) // Add the calendar name to the config as a way to easily pass this along to NewCalendar
+
+		var cfg CalendarConfig
+		if err := config.Decode(calendarConfig, &cfg); err != nil {
+			return fmt.Errorf("error parsing calendar %q: %w", calendarName, err)
+		}
+		c, err := NewCalendar(cfg)
+		if err != nil {
+			return err
+		}
+		calendars[calendarName] = c
+		slog.Debug("parsed new calendar", "calendarName", calendarName, "timezone", cfg.Timezone)
+	}
+	return nil
+}
+
+// Get the parsed calendar with the given name.
+func GetCalendar(name string) (*Calendar, bool) {
+	calendarsMutex.RLock()
+	defer calendarsMutex.RUnlock()
+
+	c, ok := calendars[name]
+	return c, ok
+}
+
+// Get the JSON Schema of the `calendars` map of the config file.
+func CalendarsSchema() *config.JSONSchema {
+	return &config.JSONSchema{
+		Type:                 "object",
+		Description:          "The trading calendars of the tickers, keyed by calendar name.",
+		AdditionalProperties: config.Schema(CalendarConfig{}),
+	}
+}
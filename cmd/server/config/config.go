@@ -4,15 +4,156 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
-func LoadConfig(configFilePath string) {
+// The top level server config.
+// The config is still read through viper lookups, but this struct is the source of truth
+// for the defaults, validation, and schema of every top level key.
+type ServerConfig struct {
+	LogLevel        string          `mapstructure:"loglevel" default:"info" validate:"oneof=none error warn info debug" description:"The level at which logs are recorded. None disables logging."`
+	LogFile         string          `mapstructure:"logfile" default:"" description:"The filepath to write logs to. If left unset or empty, logs are sent to stdout. The file is truncated before logging begins."`
+	Port            int             `mapstructure:"port" default:"8080" validate:"min=0,max=65535" description:"The port to bind the HTTP server to."`
+	ShutdownTimeout time.Duration   `mapstructure:"shutdowntimeout" default:"10s" description:"The maximum time to spend shutting down gracefully after receiving SIGINT or SIGTERM."`
+	Snapshot        SnapshotConfig  `mapstructure:"snapshot"`
+	Replay          ReplayConfig    `mapstructure:"replay"`
+	AccessLog       AccessLogConfig `mapstructure:"accesslog"`
+	Auth            AuthConfig      `mapstructure:"auth"`
+	RateLimit       RateLimitConfig `mapstructure:"ratelimit"`
+	TLS             TLSConfig       `mapstructure:"tls"`
+	History         HistoryConfig   `mapstructure:"history"`
+	Feed            FeedConfig      `mapstructure:"feed"`
+	FIX             FIXConfig       `mapstructure:"fix"`
+	Multicast       MulticastConfig `mapstructure:"multicast"`
+	Stream          StreamConfig    `mapstructure:"stream"`
+	Orders          OrdersConfig    `mapstructure:"orders"`
+	Accounts        AccountsConfig  `mapstructure:"accounts"`
+
+	// Each calendar, ticker, and sink is decoded separately into its typed config,
+	// so these are left undecoded here.
+	Calendars map[string]any `mapstructure:"calendars" description:"The trading calendars of the tickers, keyed by calendar name."`
+	Tickers   map[string]any `mapstructure:"tickers" description:"The tickers to create and manage, keyed by ticker name."`
+	Sinks     map[string]any `mapstructure:"sinks" description:"The sinks to write ticker updates to, keyed by sink name."`
+}
+
+type SnapshotConfig struct {
+	File           string `mapstructure:"file" default:"" description:"The file path to save snapshots to, and restore snapshots from. If left unset or empty, snapshots are disabled."`
+	RestoreOnStart bool   `mapstructure:"restoreonstart" default:"false" description:"Restore the snapshot file (if it exists) when the server starts. Not allowed while orders are enabled."`
+	SaveOnShutdown bool   `mapstructure:"saveonshutdown" default:"false" description:"Save a snapshot once all tickers have stopped during a graceful shutdown."`
+}
+
+type ReplayConfig struct {
+	Dir                string  `mapstructure:"dir" default:"" description:"The journal directory to replay. If set, the server runs in replay mode, serving the tickers of the journal instead of the tickers in the config."`
+	Speed              float64 `mapstructure:"speed" default:"1" validate:"min=0" description:"The replay speed, relative to the original session. For example, 2 replays twice as fast. Zero replays as fast as possible."`
+	Loop               bool    `mapstructure:"loop" default:"false" description:"Restart the replay from the beginning once the journal is finished."`
+	OriginalTimestamps bool    `mapstructure:"originaltimestamps" default:"false" description:"Serve the original timestamps of the journal, rather than the time each update is replayed."`
+}
+
+// The config of the interceptors wrapping every RPC handler. See servers.NewInterceptors.
+type AccessLogConfig struct {
+	Enabled    bool    `mapstructure:"enabled" default:"true" description:"Log a structured access log line for RPCs."`
+	SampleRate float64 `mapstructure:"samplerate" default:"0.01" validate:"min=0,max=1" description:"The fraction of successful RPCs to log. Failed RPCs are always logged."`
+	Level      string  `mapstructure:"level" default:"info" validate:"oneof=debug info" description:"The level at which successful RPCs are logged. Failed RPCs are logged at warn."`
+}
+
+type AuthConfig struct {
+	Mode   string            `mapstructure:"mode" default:"none" validate:"oneof=none apikey bearer" description:"How clients authenticate. One of none, apikey (a key in the API key header), or bearer (a token in the Authorization header)."`
+	Header string            `mapstructure:"header" default:"X-API-Key" description:"The header holding the API key, when the mode is apikey."`
+	Keys   map[string]string `mapstructure:"keys" description:"The accepted API keys or bearer tokens, keyed by client name. The client name is used in logs and for rate limiting."`
+}
+
+type RateLimitConfig struct {
+	Rate        float64       `mapstructure:"rate" default:"0" validate:"min=0" description:"The sustained number of RPCs per second allowed from each client. Zero disables rate limiting."`
+	Burst       int           `mapstructure:"burst" default:"100" validate:"gt=0" description:"The number of RPCs a client may make at once, above the sustained rate."`
+	IdleTimeout time.Duration `mapstructure:"idletimeout" default:"10m" validate:"gt=0" description:"How long a client is remembered after its last RPC. A client returning after this starts with a full bucket."`
+}
+
+type TLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled" default:"false" description:"Serve over TLS (HTTP/1.1 and HTTP/2) rather than plaintext (HTTP/1.1 and h2c)."`
+	CertFile           string `mapstructure:"certfile" default:"" description:"The PEM encoded certificate (chain) file of the server. Required when TLS is enabled, unless using a self-signed certificate."`
+	KeyFile            string `mapstructure:"keyfile" default:"" description:"The PEM encoded private key file of the server certificate. Required when TLS is enabled, unless using a self-signed certificate."`
+	ClientCAFile       string `mapstructure:"clientcafile" default:"" description:"The PEM encoded CA certificate(s) to verify client certificates against. If set, clients must present a certificate signed by one of these CAs (mutual TLS)."`
+	MinVersion         string `mapstructure:"minversion" default:"1.2" validate:"oneof=1.2 1.3" description:"The minimum TLS version accepted."`
+	SelfSigned         bool   `mapstructure:"selfsigned" default:"false" description:"Generate a self-signed certificate for localhost when the server starts, instead of using the certificate and key files. For development only."`
+	SelfSignedCertFile string `mapstructure:"selfsignedcertfile" default:"" description:"The file to write the generated self-signed certificate to, so clients can be configured to trust it. If left unset or empty, the certificate is not written."`
+}
+
+type HistoryConfig struct {
+	MaxPoints        int           `mapstructure:"maxpoints" default:"100000" validate:"min=0" description:"The number of recent updates of each ticker kept in memory for the history and candles endpoints. Zero disables the history."`
+	SamplingInterval time.Duration `mapstructure:"samplinginterval" default:"0s" validate:"min=0" description:"The minimum time between recorded updates of each ticker. Zero records every update."`
+	BufferSize       int           `mapstructure:"buffersize" default:"65536" validate:"gt=0" description:"The number of updates buffered while waiting to be recorded. Updates are dropped while the buffer is full."`
+}
+
+type FeedConfig struct {
+	DefaultThrottle time.Duration `mapstructure:"defaultthrottle" default:"100ms" validate:"min=0" description:"The time between pushed updates of each ticker on a feed connection, unless the client asks for another throttle. Only the latest update of each ticker in each interval is pushed."`
+	MinThrottle     time.Duration `mapstructure:"minthrottle" default:"10ms" validate:"min=0" description:"The shortest throttle a client may ask for. Zero allows clients to receive every update."`
+	BufferSize      int           `mapstructure:"buffersize" default:"4096" validate:"gt=0" description:"The number of updates buffered for each feed connection. Updates are dropped while the buffer is full."`
+	WriteTimeout    time.Duration `mapstructure:"writetimeout" default:"10s" validate:"gt=0" description:"The time allowed to write a message to a feed connection before the connection is closed."`
+	AllowedOrigins  []string      `mapstructure:"allowedorigins" description:"The origin hosts (e.g. \"localhost:3000\", or patterns such as \"*.example.com\") of the browser pages allowed to connect to the feeds, besides the origin of the server itself."`
+}
+
+// The config of the streaming RPCs, such as StreamTrades.
+type StreamConfig struct {
+	BufferSize int `mapstructure:"buffersize" default:"4096" validate:"gt=0" description:"The number of updates buffered for each streaming RPC. Updates are dropped while the buffer is full."`
+}
+
+// The config of the simulated order entry of the OrderService.
+type OrdersConfig struct {
+	Enabled           bool          `mapstructure:"enabled" default:"false" description:"Accept simulated orders through the OrderService, filling them against the quotes of the tickers."`
+	Latency           time.Duration `mapstructure:"latency" default:"0s" validate:"min=0" description:"The time for each order (and cancel) to arrive at the venue after it is submitted. The quote may move in the meantime."`
+	LatencyJitter     time.Duration `mapstructure:"latencyjitter" default:"0s" validate:"min=0" description:"The largest random delay added to the latency of each order (and cancel). Delays are uniformly distributed."`
+	PartialFills      bool          `mapstructure:"partialfills" default:"true" description:"Fill at most the quoted size at each update, so large orders fill over several updates. Otherwise every fill is for the full remaining size of the order."`
+	RejectProbability float64       `mapstructure:"rejectprobability" default:"0" validate:"min=0,max=1" description:"The probability that the venue rejects each order at random, to exercise reject handling."`
+	RandomSeed        *int64        `mapstructure:"randomseed" description:"The random seed of the generator of latency jitter and random rejects. If left unset, a random seed is generated and logged."`
+	MaxOrderSize      int64         `mapstructure:"maxordersize" default:"0" validate:"min=0" description:"The largest size of an order. Larger orders are rejected. Zero allows any size."`
+	MaxOpenOrders     int           `mapstructure:"maxopenorders" default:"1000" validate:"min=0" description:"The most working orders each client may have. Further orders are rejected. Zero allows any number."`
+	PriceCollar       float64       `mapstructure:"pricecollar" default:"0" validate:"min=0" description:"The largest distance of a limit price from the ticker value, as a fraction of the value. Limit orders priced further away are rejected. Zero disables the collar."`
+	BufferSize        int           `mapstructure:"buffersize" default:"65536" validate:"gt=0" description:"The number of ticker updates buffered while waiting to fill working orders. Updates are dropped while the buffer is full."`
+}
+
+// The config of the accounts of the clients of the OrderService.
+type AccountsConfig struct {
+	InitialCash    float64       `mapstructure:"initialcash" default:"1000000" validate:"min=0" description:"The cash of each account before its first execution."`
+	MarginRate     float64       `mapstructure:"marginrate" default:"0.25" validate:"min=0" description:"The margin required for each position, as a fraction of the absolute market value of the position."`
+	EnforceMargin  bool          `mapstructure:"enforcemargin" default:"true" description:"Reject orders that would leave the account with less equity than the margin required. Orders that reduce a position are always accepted."`
+	StreamInterval time.Duration `mapstructure:"streaminterval" default:"1s" validate:"gt=0" description:"The time between portfolios sent by StreamPortfolio."`
+}
+
+type FIXConfig struct {
+	Enabled       bool          `mapstructure:"enabled" default:"false" description:"Accept FIX 4.4 sessions, serving market data from the ticker updates."`
+	Port          int           `mapstructure:"port" default:"9878" validate:"min=0,max=65535" description:"The port to accept FIX sessions on."`
+	SenderCompID  string        `mapstructure:"sendercompid" default:"GENRON" validate:"required" description:"The CompID of the server. Counterparties must send this as their TargetCompID."`
+	TargetCompIDs []string      `mapstructure:"targetcompids" description:"The CompIDs of the counterparties allowed to log on. If empty, any counterparty may log on."`
+	ResetOnLogon  bool          `mapstructure:"resetonlogon" default:"true" description:"Reset sequence numbers to 1 on every logon. Otherwise sequence numbers continue from the last session of the counterparty (until the server restarts), unless the Logon sets ResetSeqNumFlag."`
+	LogonTimeout  time.Duration `mapstructure:"logontimeout" default:"10s" validate:"gt=0" description:"The time allowed for a counterparty to send its Logon after connecting."`
+	WriteTimeout  time.Duration `mapstructure:"writetimeout" default:"10s" validate:"gt=0" description:"The time allowed to write a message to a session before the session is disconnected."`
+	BufferSize    int           `mapstructure:"buffersize" default:"4096" validate:"gt=0" description:"The number of updates buffered for each session. Updates are dropped while the buffer is full."`
+	LogMessages   bool          `mapstructure:"logmessages" default:"false" description:"Log every FIX message sent and received, at debug level."`
+}
+
+type MulticastConfig struct {
+	Enabled              bool          `mapstructure:"enabled" default:"false" description:"Publish ticker updates as binary messages over UDP."`
+	Address              string        `mapstructure:"address" default:"239.192.0.1:30001" description:"The address to publish to, as host:port. Either a multicast group, or a unicast address (e.g. 127.0.0.1:30001) for tests."`
+	Interface            string        `mapstructure:"interface" default:"" description:"The name of the network interface to publish multicast from. If left unset or empty, the system default is used."`
+	TTL                  int           `mapstructure:"ttl" default:"1" validate:"min=0,max=255" description:"The time to live of multicast packets. One keeps packets on the local network."`
+	Loopback             bool          `mapstructure:"loopback" default:"true" description:"Deliver multicast packets to listeners on the publishing host too."`
+	Session              string        `mapstructure:"session" default:"" description:"The session name (at most 10 characters) of the feed. If left unset or empty, a session name is generated from the start time, so consumers can detect restarts."`
+	MaxPacketSize        int           `mapstructure:"maxpacketsize" default:"1400" validate:"min=64,max=65507" description:"The largest packet sent, in bytes. Updates are packed into packets up to this size. Keep below the path MTU to avoid fragmentation."`
+	HeartbeatInterval    time.Duration `mapstructure:"heartbeatinterval" default:"1s" validate:"gt=0" description:"The time without updates after which a heartbeat packet is sent."`
+	DirectoryInterval    time.Duration `mapstructure:"directoryinterval" default:"10s" validate:"min=0" description:"The time between repeats of the ticker directory, for consumers joining late. Zero sends the directory only at the start of the session."`
+	RetransmitPort       int           `mapstructure:"retransmitport" default:"30002" validate:"min=0,max=65535" description:"The TCP port of the retransmission service. Zero disables retransmission."`
+	RetransmitBufferSize int           `mapstructure:"retransmitbuffersize" default:"1000000" validate:"gt=0" description:"The number of recent messages kept for retransmission."`
+	BufferSize           int           `mapstructure:"buffersize" default:"65536" validate:"gt=0" description:"The number of updates buffered while waiting to be published. Updates are dropped while the buffer is full."`
+}
+
+// Load the config file at the given path into viper, and validate it.
+// Returns the decoded config. Note that parts of the program still read the config through viper lookups.
+func LoadConfig(configFilePath string) ServerConfig {
 	slog.Debug("loading config")
 
-	viper.SetDefault("loglevel", "info")
-	viper.SetDefault("logfile", "")
+	SetDefaults(viper.GetViper(), ServerConfig{})
 
 	// Read explicitly from the config file give.
 	// This may ignore other config paths (e.g. environment variables), worth testing.
@@ -29,6 +170,13 @@ func LoadConfig(configFilePath string) {
 			panic(err)
 		}
 	}
+
+	var serverConfig ServerConfig
+	if err := Decode(viper.GetViper(), &serverConfig); err != nil {
+		slog.Error("error during config validation", "err", err)
+		panic(err)
+	}
+	return serverConfig
 }
 
 // Configure the slog logger using config values in viper.
@@ -78,7 +226,8 @@ func ConfigureLogger() *os.File {
 		logFilePointer = nil
 		slogHandler = slog.NewTextHandler(os.Stdout, &slogHandlerOptions)
 	} else {
-		logFilePointer, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		var err error
+		logFilePointer, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 		if err != nil {
 			slog.Error("error while creating log file", "logfile", logFile, "err", err)
 			panic(err)
@@ -0,0 +1,335 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+// Typed config structs are decoded from viper using mapstructure, and are annotated with the struct tags:
+//   - `mapstructure:"key"`: The config key of the field. Embedded structs are flattened with `mapstructure:",squash"`.
+//   - `default:"value"`: The default value of the field, used if the key is not set in the config.
+//   - `validate:"rule,rule,..."`: The validation rules of the field. See validateField for the valid rules.
+//   - `description:"text"`: A human readable description of the field, used in the generated JSON Schema.
+//   - `schema:"-"`: Exclude the field from the generated JSON Schema (e.g. for fields set by the program, not the user).
+//
+// Keeping the defaults, validation, and documentation of the config in the struct tags
+// means the generated schema cannot drift from the code.
+
+// A description of a single field of a typed config struct.
+type Field struct {
+	// The full config key of the field, with nested keys joined by ".".
+	Key string
+
+	// The datatype of the field, e.g. "float64".
+	Datatype string
+
+	// The default value of the field, as written in the default tag.
+	Default string
+
+	// If true, the field must be set in the config.
+	Required bool
+
+	Description string
+
+	// The validation rules of the field, as written in the validate tag.
+	Rules []string
+
+	// If true, the field is set by the program rather than the user, and is excluded from the schema.
+	Hidden bool
+
+	structField reflect.StructField
+	index       []int
+}
+
+// Get the fields of a typed config struct (or pointer to a struct), flattening nested and embedded structs.
+// Fields of map types (such as the tickers of the server config) are returned but not descended into.
+func Fields(cfg any) []Field {
+	configType := reflect.TypeOf(cfg)
+	for configType.Kind() == reflect.Pointer {
+		configType = configType.Elem()
+	}
+	return appendFields(nil, configType, "", nil)
+}
+
+func appendFields(fields []Field, structType reflect.Type, prefix string, index []int) []Field {
+	for i := range structType.NumField() {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		key, options, _ := strings.Cut(structField.Tag.Get("mapstructure"), ",")
+		if options == "squash" {
+			fields = appendFields(fields, structField.Type, prefix, fieldIndex)
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(structField.Name)
+		}
+		key = prefix + key
+
+		if structField.Type.Kind() == reflect.Struct && structField.Type != reflect.TypeFor[time.Time]() {
+			fields = appendFields(fields, structField.Type, key+".", fieldIndex)
+			continue
+		}
+
+		var rules []string
+		if validateTag := structField.Tag.Get("validate"); validateTag != "" {
+			rules = strings.Split(validateTag, ",")
+		}
+		required := false
+		for _, rule := range rules {
+			if rule == "required" {
+				required = true
+			}
+		}
+
+		fields = append(fields, Field{
+			Key:         key,
+			Datatype:    datatypeName(structField.Type),
+			Default:     structField.Tag.Get("default"),
+			Required:    required,
+			Description: structField.Tag.Get("description"),
+			Rules:       rules,
+			Hidden:      structField.Tag.Get("schema") == "-",
+			structField: structField,
+			index:       fieldIndex,
+		})
+	}
+	return fields
+}
+
+func datatypeName(fieldType reflect.Type) string {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType == reflect.TypeFor[time.Duration]() {
+		return "Duration"
+	}
+	return fieldType.String()
+}
+
+// Set the defaults of every field of the typed config struct in the given viper instance.
+func SetDefaults(v *viper.Viper, cfg any) {
+	for _, field := range Fields(cfg) {
+		if _, ok := field.structField.Tag.Lookup("default"); ok {
+			v.SetDefault(field.Key, field.Default)
+		}
+	}
+}
+
+// Decode the given viper config into the typed config struct pointed to by cfg.
+// Defaults are applied from the struct tags, and then all fields are validated.
+//
+// Returns an error if a required field is not set, if the config contains a key that does not
+// exist in the struct (most likely a typo), or if any field fails validation.
+func Decode(v *viper.Viper, cfg any) error {
+	SetDefaults(v, cfg)
+
+	fields := Fields(cfg)
+	for _, field := range fields {
+		if field.Required && !v.IsSet(field.Key) {
+			return fmt.Errorf("config field %q is required but not set", field.Key)
+		}
+	}
+
+	if err := v.Unmarshal(cfg, func(decoderConfig *mapstructure.DecoderConfig) {
+		decoderConfig.ErrorUnused = true
+	}); err != nil {
+		return err
+	}
+
+	configValue := reflect.ValueOf(cfg).Elem()
+	for _, field := range fields {
+		if err := validateField(field, configValue.FieldByIndex(field.index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check the value of a field against the rules in its validate tag. The valid rules are:
+//   - required: The field must be set. Checked before decoding, since zero values may be valid.
+//   - min=x, max=x: The (numeric) field must be at least or at most x.
+//   - gt=x, lt=x: The (numeric) field must be strictly greater or less than x.
+//   - oneof=a b c: The (string) field must be one of the space separated values.
+//
+// Unset pointer fields are not validated.
+func validateField(field Field, fieldValue reflect.Value) error {
+	for fieldValue.Kind() == reflect.Pointer {
+		if fieldValue.IsNil() {
+			return nil
+		}
+		fieldValue = fieldValue.Elem()
+	}
+
+	for _, rule := range field.Rules {
+		ruleName, ruleArgument, _ := strings.Cut(rule, "=")
+		switch ruleName {
+		case "required":
+			continue
+		case "oneof":
+			options := strings.Fields(ruleArgument)
+			value := fmt.Sprint(fieldValue.Interface())
+			valid := false
+			for _, option := range options {
+				if value == option {
+					valid = true
+				}
+			}
+			if !valid {
+				return fmt.Errorf("config field %q must be one of %v, got %q", field.Key, options, value)
+			}
+		case "min", "max", "gt", "lt":
+			bound, err := strconv.ParseFloat(ruleArgument, 64)
+			if err != nil {
+				panic(fmt.Sprintf("invalid validate rule %q on config field %q", rule, field.Key))
+			}
+			var value float64
+			switch {
+			case fieldValue.CanFloat():
+				value = fieldValue.Float()
+			case fieldValue.CanInt():
+				value = float64(fieldValue.Int())
+			case fieldValue.CanUint():
+				value = float64(fieldValue.Uint())
+			default:
+				panic(fmt.Sprintf("numeric validate rule %q on non-numeric config field %q", rule, field.Key))
+			}
+			if (ruleName == "min" && value < bound) ||
+				(ruleName == "max" && value > bound) ||
+				(ruleName == "gt" && value <= bound) ||
+				(ruleName == "lt" && value >= bound) {
+				return fmt.Errorf("config field %q fails validation rule %q, got %v", field.Key, rule, value)
+			}
+		default:
+			panic(fmt.Sprintf("unknown validate rule %q on config field %q", rule, field.Key))
+		}
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------------
+
+// A (subset of a) JSON Schema document, see https://json-schema.org/.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+// Generate the JSON Schema of a typed config struct, from the struct tags of each field.
+func Schema(cfg any) *JSONSchema {
+	schema := &JSONSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: false,
+	}
+
+	for _, field := range Fields(cfg) {
+		if field.Hidden {
+			continue
+		}
+
+		// Walk down (and create as needed) the nested object schemas of the field key.
+		parentSchema := schema
+		keyParts := strings.Split(field.Key, ".")
+		for _, keyPart := range keyParts[:len(keyParts)-1] {
+			childSchema, ok := parentSchema.Properties[keyPart]
+			if !ok {
+				childSchema = &JSONSchema{
+					Type:                 "object",
+					Properties:           make(map[string]*JSONSchema),
+					AdditionalProperties: false,
+				}
+				parentSchema.Properties[keyPart] = childSchema
+			}
+			parentSchema = childSchema
+		}
+
+		key := keyParts[len(keyParts)-1]
+		parentSchema.Properties[key] = fieldSchema(field)
+		if field.Required {
+			parentSchema.Required = append(parentSchema.Required, key)
+		}
+	}
+	return schema
+}
+
+func fieldSchema(field Field) *JSONSchema {
+	schema := &JSONSchema{
+		Description: field.Description,
+	}
+
+	fieldType := field.structField.Type
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	switch {
+	case fieldType == reflect.TypeFor[time.Duration]():
+		// Durations are given either as integer nanoseconds, or as strings such as "10s".
+		schema.Type = []string{"integer", "string"}
+	case fieldType.Kind() == reflect.Bool:
+		schema.Type = "boolean"
+	case fieldType.Kind() == reflect.String:
+		schema.Type = "string"
+	case fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64:
+		schema.Type = "number"
+	case fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Uint64:
+		schema.Type = "integer"
+	case fieldType.Kind() == reflect.Slice:
+		schema.Type = "array"
+	case fieldType.Kind() == reflect.Map:
+		schema.Type = "object"
+	}
+
+	if field.Default != "" {
+		schema.Default = field.Default
+		var typedDefault any
+		if err := json.Unmarshal([]byte(field.Default), &typedDefault); err == nil && schema.Type != "string" {
+			schema.Default = typedDefault
+		}
+	}
+
+	for _, rule := range field.Rules {
+		ruleName, ruleArgument, _ := strings.Cut(rule, "=")
+		bound, _ := strconv.ParseFloat(ruleArgument, 64)
+		switch ruleName {
+		case "min":
+			schema.Minimum = &bound
+		case "max":
+			schema.Maximum = &bound
+		case "gt":
+			schema.ExclusiveMinimum = &bound
+		case "lt":
+			schema.ExclusiveMaximum = &bound
+		case "oneof":
+			for _, option := range strings.Fields(ruleArgument) {
+				schema.Enum = append(schema.Enum, option)
+			}
+		}
+	}
+	return schema
+}
@@ -0,0 +1,206 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// Accepts FIX sessions from counterparties, serving market data from the ticker updates.
+//
+// Each counterparty (identified by its SenderCompID) may have a single session logged on at a time.
+// The sequence numbers of each counterparty are kept in memory between sessions, unless reset on logon.
+type Acceptor struct {
+	cfg     config.FIXConfig
+	tickers map[string]ticker.Ticker
+	updates *ticker.UpdateBroadcaster
+
+	listener  net.Listener
+	waitGroup sync.WaitGroup
+
+	mu sync.Mutex
+	// The sequence numbers of each counterparty, by CompID.
+	sequenceNumbers map[string]*sequenceNumbers
+	// The counterparties with a session currently logged on.
+	loggedOn map[string]struct{}
+}
+
+// The sequence numbers of a counterparty, which continue across sessions unless reset.
+type sequenceNumbers struct {
+	nextIncoming int
+	nextOutgoing int
+}
+
+func NewAcceptor(cfg config.FIXConfig, tickers map[string]ticker.Ticker, updates *ticker.UpdateBroadcaster) *Acceptor {
+	return &Acceptor{
+		cfg:             cfg,
+		tickers:         tickers,
+		updates:         updates,
+		sequenceNumbers: make(map[string]*sequenceNumbers),
+		loggedOn:        make(map[string]struct{}),
+	}
+}
+
+// Bind the FIX port. Call before Serve, so that errors binding the port are reported at startup.
+func (a *Acceptor) Listen() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", a.cfg.Port))
+	if err != nil {
+		return err
+	}
+	a.listener = listener
+	return nil
+}
+
+// Accept sessions until the context is cancelled, at which point every session is logged out.
+// Returns once every session has ended.
+func (a *Acceptor) Serve(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		a.listener.Close()
+	}()
+
+	slog.Info("accepting fix sessions", "addr", a.listener.Addr().String(), "senderCompID", a.cfg.SenderCompID)
+	for {
+		conn, err := a.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				slog.Error("error when accepting fix connection", "err", err)
+			}
+			break
+		}
+		a.waitGroup.Go(func() {
+			defer conn.Close()
+			a.handleConnection(ctx, conn)
+		})
+	}
+	a.waitGroup.Wait()
+}
+
+func (a *Acceptor) handleConnection(ctx context.Context, conn net.Conn) {
+	remoteAddr := conn.RemoteAddr().String()
+	reader := bufio.NewReader(conn)
+
+	// The first message must be a Logon, sent within the logon timeout.
+	conn.SetReadDeadline(time.Now().Add(a.cfg.LogonTimeout))
+	logon, err := ReadMessage(reader)
+	if err != nil {
+		slog.Warn("fix connection closed before logon", "remoteAddr", remoteAddr, "err", err)
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+	if logon.MsgType != MsgTypeLogon {
+		slog.Warn("fix connection did not start with a logon", "remoteAddr", remoteAddr, "msgType", logon.MsgType)
+		return
+	}
+
+	s, err := a.logon(conn, reader, logon)
+	if err != nil {
+		slog.Warn("fix logon rejected", "remoteAddr", remoteAddr, "err", err)
+		// Only tell the counterparty why if it is who it claims to be talking to.
+		if targetCompID, _ := logon.Get(TagTargetCompID); targetCompID == a.cfg.SenderCompID {
+			senderCompID, _ := logon.Get(TagSenderCompID)
+			out := NewMessage(MsgTypeLogout).Add(TagText, err.Error())
+			conn.SetWriteDeadline(time.Now().Add(a.cfg.WriteTimeout))
+			conn.Write(out.Encode(a.cfg.SenderCompID, senderCompID, 1, time.Now()))
+		}
+		return
+	}
+	defer a.logout(s)
+
+	s.logger.Info("fix session logged on", "remoteAddr", remoteAddr, "heartbeatInterval", s.heartbeatInterval)
+	if err := s.run(ctx); err != nil {
+		s.logger.Warn("fix session ended with error", "err", err)
+		return
+	}
+	s.logger.Info("fix session logged out")
+}
+
+// Validate a Logon, and create the session of the counterparty.
+func (a *Acceptor) logon(conn net.Conn, reader *bufio.Reader, logon *Message) (*session, error) {
+	senderCompID, _ := logon.Get(TagSenderCompID)
+	targetCompID, _ := logon.Get(TagTargetCompID)
+	if targetCompID != a.cfg.SenderCompID {
+		return nil, fmt.Errorf("unknown TargetCompID %q", targetCompID)
+	}
+	if senderCompID == "" || (len(a.cfg.TargetCompIDs) > 0 && !slices.Contains(a.cfg.TargetCompIDs, senderCompID)) {
+		return nil, fmt.Errorf("SenderCompID %q is not allowed to log on", senderCompID)
+	}
+	if encryptMethod, _ := logon.Get(TagEncryptMethod); encryptMethod != "0" {
+		return nil, fmt.Errorf("unsupported EncryptMethod %q", encryptMethod)
+	}
+	heartbeatSeconds, err := logon.GetInt(TagHeartBtInt)
+	if err != nil || heartbeatSeconds <= 0 {
+		return nil, errors.New("HeartBtInt must be a positive number of seconds")
+	}
+
+	msgSeqNum, err := logon.GetInt(TagMsgSeqNum)
+	if err != nil {
+		return nil, errors.New("missing MsgSeqNum")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.loggedOn[senderCompID]; ok {
+		return nil, fmt.Errorf("a session of %q is already logged on", senderCompID)
+	}
+	seq, ok := a.sequenceNumbers[senderCompID]
+	resetRequested := logon.GetBool(TagResetSeqNumFlag)
+	if !ok || a.cfg.ResetOnLogon || resetRequested {
+		seq = &sequenceNumbers{nextIncoming: 1, nextOutgoing: 1}
+		a.sequenceNumbers[senderCompID] = seq
+	}
+	if msgSeqNum < seq.nextIncoming {
+		return nil, fmt.Errorf("MsgSeqNum too low, expecting %d but received %d", seq.nextIncoming, msgSeqNum)
+	}
+
+	a.loggedOn[senderCompID] = struct{}{}
+	metricSessions.Inc()
+	s := newSession(a, conn, reader, senderCompID, seq, time.Duration(heartbeatSeconds)*time.Second)
+	s.logMessage("received", logon)
+
+	// A Logon beyond a gap in the sequence numbers is accepted, but only counted once the gap is filled.
+	if msgSeqNum == seq.nextIncoming {
+		seq.nextIncoming++
+	} else {
+		s.queuedLogonSeqNum = msgSeqNum
+	}
+
+	response := NewMessage(MsgTypeLogon).
+		Add(TagEncryptMethod, "0").
+		AddInt(TagHeartBtInt, heartbeatSeconds)
+	if resetRequested {
+		response.Add(TagResetSeqNumFlag, "Y")
+	}
+	if err := s.send(response); err != nil {
+		a.releaseLocked(s)
+		return nil, err
+	}
+	if s.queuedLogonSeqNum > 0 {
+		if err := s.requestResend(msgSeqNum); err != nil {
+			a.releaseLocked(s)
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Release the counterparty of the session, so it may log on again.
+func (a *Acceptor) logout(s *session) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.releaseLocked(s)
+}
+
+func (a *Acceptor) releaseLocked(s *session) {
+	delete(a.loggedOn, s.targetCompID)
+	metricSessions.Dec()
+}
@@ -0,0 +1,247 @@
+package fix
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// The MDEntryTypes (269) served. The value of each ticker is served as the price of its last trade,
+// and its quote as the bid and offer.
+const (
+	mdEntryTypeBid   = "0"
+	mdEntryTypeOffer = "1"
+	mdEntryTypeTrade = "2"
+)
+
+var supportedMDEntryTypes = []string{mdEntryTypeBid, mdEntryTypeOffer, mdEntryTypeTrade}
+
+// The SubscriptionRequestTypes (263) of a MarketDataRequest.
+const (
+	subscriptionRequestTypeSnapshot           = "0"
+	subscriptionRequestTypeSnapshotAndUpdates = "1"
+	subscriptionRequestTypeUnsubscribe        = "2"
+)
+
+// The MDUpdateTypes (265) of a MarketDataRequest.
+const (
+	mdUpdateTypeFullRefresh = "0"
+	mdUpdateTypeIncremental = "1"
+)
+
+// The MDReqRejReasons (281) of each MarketDataRequestReject sent by the gateway.
+const (
+	mdReqRejReasonUnknownSymbol                      = "0"
+	mdReqRejReasonDuplicateMDReqID                   = "1"
+	mdReqRejReasonUnsupportedSubscriptionRequestType = "4"
+	mdReqRejReasonUnsupportedMDUpdateType            = "6"
+	mdReqRejReasonUnsupportedMDEntryType             = "8"
+)
+
+// The MDUpdateActions (279) of incremental refresh entries. Each update is a new trade, and changes the bid and offer,
+// or deletes a side of the quote with no liquidity.
+const (
+	mdUpdateActionNew    = "0"
+	mdUpdateActionChange = "1"
+	mdUpdateActionDelete = "2"
+)
+
+// A market data subscription of a session, created by a MarketDataRequest for snapshots and updates.
+type marketDataSubscription struct {
+	mdReqID    string
+	symbols    map[string]struct{}
+	entryTypes []string
+
+	// Send each update as an incremental refresh, rather than a full refresh.
+	incremental bool
+}
+
+// Handle a MarketDataRequest, sending a snapshot of every requested symbol,
+// and subscribing to updates if requested.
+func (s *session) handleMarketDataRequest(m *Message) error {
+	mdReqID, ok := m.Get(TagMDReqID)
+	if !ok {
+		return s.reject(m, TagMDReqID, sessionRejectReasonRequiredTagMissing, "missing MDReqID")
+	}
+	subscriptionRequestType, ok := m.Get(TagSubscriptionRequestType)
+	if !ok {
+		return s.reject(m, TagSubscriptionRequestType, sessionRejectReasonRequiredTagMissing, "missing SubscriptionRequestType")
+	}
+
+	switch subscriptionRequestType {
+	case subscriptionRequestTypeUnsubscribe:
+		delete(s.subscriptions, mdReqID)
+		return nil
+	case subscriptionRequestTypeSnapshot, subscriptionRequestTypeSnapshotAndUpdates:
+	default:
+		return s.rejectMarketDataRequest(mdReqID, mdReqRejReasonUnsupportedSubscriptionRequestType, fmt.Sprintf("unsupported SubscriptionRequestType %q", subscriptionRequestType))
+	}
+	if _, ok := s.subscriptions[mdReqID]; ok {
+		return s.rejectMarketDataRequest(mdReqID, mdReqRejReasonDuplicateMDReqID, fmt.Sprintf("MDReqID %q is already subscribed", mdReqID))
+	}
+
+	// Updates are incremental unless a full refresh is requested.
+	incremental := true
+	if mdUpdateType, ok := m.Get(TagMDUpdateType); ok {
+		switch mdUpdateType {
+		case mdUpdateTypeFullRefresh:
+			incremental = false
+		case mdUpdateTypeIncremental:
+		default:
+			return s.rejectMarketDataRequest(mdReqID, mdReqRejReasonUnsupportedMDUpdateType, fmt.Sprintf("unsupported MDUpdateType %q", mdUpdateType))
+		}
+	}
+
+	var entryTypes []string
+	for _, entryType := range m.GetAll(TagMDEntryType) {
+		if slices.Contains(supportedMDEntryTypes, entryType) && !slices.Contains(entryTypes, entryType) {
+			entryTypes = append(entryTypes, entryType)
+		}
+	}
+	if len(entryTypes) == 0 {
+		return s.rejectMarketDataRequest(mdReqID, mdReqRejReasonUnsupportedMDEntryType, fmt.Sprintf("no supported MDEntryType requested, supported types are %v", supportedMDEntryTypes))
+	}
+
+	symbols := m.GetAll(TagSymbol)
+	if len(symbols) == 0 {
+		return s.reject(m, TagNoRelatedSym, sessionRejectReasonRequiredTagMissing, "no symbols requested")
+	}
+	for _, symbol := range symbols {
+		if _, ok := s.acceptor.tickers[symbol]; !ok {
+			return s.rejectMarketDataRequest(mdReqID, mdReqRejReasonUnknownSymbol, fmt.Sprintf("unknown symbol %q", symbol))
+		}
+	}
+
+	for _, symbol := range symbols {
+		info := s.acceptor.tickers[symbol].GetSnapshot()
+		update := ticker.TickerUpdate{
+			Name:      info.Name,
+			Timestamp: info.LastUpdatedTimestamp,
+			Value:     info.Value,
+			Quote:     info.Quote,
+		}
+		if err := s.send(newFullRefresh(mdReqID, entryTypes, update)); err != nil {
+			return err
+		}
+	}
+
+	if subscriptionRequestType == subscriptionRequestTypeSnapshotAndUpdates {
+		subscription := &marketDataSubscription{
+			mdReqID:     mdReqID,
+			symbols:     make(map[string]struct{}, len(symbols)),
+			entryTypes:  entryTypes,
+			incremental: incremental,
+		}
+		for _, symbol := range symbols {
+			subscription.symbols[symbol] = struct{}{}
+		}
+		s.subscriptions[mdReqID] = subscription
+	}
+	return nil
+}
+
+func (s *session) rejectMarketDataRequest(mdReqID string, reason string, text string) error {
+	return s.send(NewMessage(MsgTypeMarketDataRequestReject).
+		Add(TagMDReqID, mdReqID).
+		Add(TagMDReqRejReason, reason).
+		Add(TagText, text))
+}
+
+// Send a ticker update to every subscription of the ticker.
+func (s *session) publish(update ticker.TickerUpdate) error {
+	for _, subscription := range s.subscriptions {
+		if _, ok := subscription.symbols[update.Name]; !ok {
+			continue
+		}
+		var m *Message
+		if subscription.incremental {
+			m = newIncrementalRefresh(subscription.mdReqID, subscription.entryTypes, update)
+		} else {
+			m = newFullRefresh(subscription.mdReqID, subscription.entryTypes, update)
+		}
+		if err := s.send(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFullRefresh(mdReqID string, entryTypes []string, update ticker.TickerUpdate) *Message {
+	// A side of the quote with no liquidity has no entry.
+	presentEntryTypes := make([]string, 0, len(entryTypes))
+	for _, entryType := range entryTypes {
+		if hasMarketDataEntry(entryType, update.Quote) {
+			presentEntryTypes = append(presentEntryTypes, entryType)
+		}
+	}
+
+	m := NewMessage(MsgTypeMarketDataSnapshotFullRefresh).
+		Add(TagMDReqID, mdReqID).
+		Add(TagSymbol, update.Name).
+		AddInt(TagNoMDEntries, len(presentEntryTypes))
+	for _, entryType := range presentEntryTypes {
+		m.Add(TagMDEntryType, entryType)
+		addMarketDataEntry(m, entryType, update)
+	}
+	return m
+}
+
+func newIncrementalRefresh(mdReqID string, entryTypes []string, update ticker.TickerUpdate) *Message {
+	m := NewMessage(MsgTypeMarketDataIncrementalRefresh).
+		Add(TagMDReqID, mdReqID).
+		AddInt(TagNoMDEntries, len(entryTypes))
+	for _, entryType := range entryTypes {
+		updateAction := mdUpdateActionChange
+		if entryType == mdEntryTypeTrade {
+			updateAction = mdUpdateActionNew
+		}
+		// A side of the quote with no liquidity is deleted, and has no price or size.
+		if !hasMarketDataEntry(entryType, update.Quote) {
+			m.Add(TagMDUpdateAction, mdUpdateActionDelete).
+				Add(TagMDEntryType, entryType).
+				Add(TagSymbol, update.Name)
+			continue
+		}
+		m.Add(TagMDUpdateAction, updateAction).
+			Add(TagMDEntryType, entryType).
+			Add(TagSymbol, update.Name)
+		addMarketDataEntry(m, entryType, update)
+	}
+	return m
+}
+
+// Whether the update has a market data entry of the given type. A side of the quote with no liquidity has none.
+func hasMarketDataEntry(entryType string, quote ticker.Quote) bool {
+	switch entryType {
+	case mdEntryTypeBid:
+		return quote.HasBid
+	case mdEntryTypeOffer:
+		return quote.HasAsk
+	default:
+		return true
+	}
+}
+
+// Add the price, size, and time of a market data entry.
+// Trades have no size, since the value of a ticker is not the result of any real trade.
+func addMarketDataEntry(m *Message, entryType string, update ticker.TickerUpdate) {
+	switch entryType {
+	case mdEntryTypeBid:
+		m.Add(TagMDEntryPx, formatPrice(update.Quote.Bid)).
+			AddInt(TagMDEntrySize, int(update.Quote.BidSize))
+	case mdEntryTypeOffer:
+		m.Add(TagMDEntryPx, formatPrice(update.Quote.Ask)).
+			AddInt(TagMDEntrySize, int(update.Quote.AskSize))
+	default:
+		m.Add(TagMDEntryPx, formatPrice(update.Value))
+	}
+	timestamp := update.Timestamp.UTC()
+	m.Add(TagMDEntryDate, timestamp.Format(DateFormat)).
+		Add(TagMDEntryTime, timestamp.Format(TimeFormat))
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
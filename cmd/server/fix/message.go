@@ -0,0 +1,303 @@
+// Package fix implements a minimal FIX 4.4 acceptor, serving market data built from the ticker updates,
+// so that FIX-only systems (e.g. an order management system) can use the server as a market data source.
+//
+// Only the session layer (logon, heartbeats, test requests, sequence numbers, resend requests, and logout)
+// and the market data messages are implemented:
+//
+//	MarketDataRequest (V)                 client to server
+//	MarketDataSnapshotFullRefresh (W)     server to client
+//	MarketDataIncrementalRefresh (X)      server to client
+//	MarketDataRequestReject (Y)           server to client
+//
+// Market data is not stored, so a resend request is always answered with a gap fill.
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	BeginString = "FIX.4.4"
+
+	// The field delimiter.
+	SOH = '\x01'
+
+	// The largest message body accepted, to bound the memory used by a garbled BodyLength.
+	maxBodyLength = 1 << 20
+
+	// The format of UTCTimestamp fields (e.g. SendingTime), with milliseconds.
+	TimestampFormat = "20060102-15:04:05.000"
+
+	// The formats of the UTCDateOnly and UTCTimeOnly fields (e.g. MDEntryDate and MDEntryTime).
+	DateFormat = "20060102"
+	TimeFormat = "15:04:05.000"
+)
+
+// The tags of every field used by the gateway.
+const (
+	TagBeginSeqNo              = 7
+	TagBeginString             = 8
+	TagBodyLength              = 9
+	TagCheckSum                = 10
+	TagEndSeqNo                = 16
+	TagMsgSeqNum               = 34
+	TagMsgType                 = 35
+	TagNewSeqNo                = 36
+	TagPossDupFlag             = 43
+	TagRefSeqNum               = 45
+	TagSenderCompID            = 49
+	TagSendingTime             = 52
+	TagSymbol                  = 55
+	TagTargetCompID            = 56
+	TagText                    = 58
+	TagEncryptMethod           = 98
+	TagHeartBtInt              = 108
+	TagTestReqID               = 112
+	TagOrigSendingTime         = 122
+	TagGapFillFlag             = 123
+	TagResetSeqNumFlag         = 141
+	TagNoRelatedSym            = 146
+	TagMDReqID                 = 262
+	TagSubscriptionRequestType = 263
+	TagMarketDepth             = 264
+	TagMDUpdateType            = 265
+	TagNoMDEntryTypes          = 267
+	TagNoMDEntries             = 268
+	TagMDEntryType             = 269
+	TagMDEntryPx               = 270
+	TagMDEntrySize             = 271
+	TagMDEntryDate             = 272
+	TagMDEntryTime             = 273
+	TagMDUpdateAction          = 279
+	TagMDReqRejReason          = 281
+	TagRefTagID                = 371
+	TagRefMsgType              = 372
+	TagSessionRejectReason     = 373
+	TagBusinessRejectReason    = 380
+)
+
+// The message types used by the gateway.
+const (
+	MsgTypeHeartbeat                     = "0"
+	MsgTypeTestRequest                   = "1"
+	MsgTypeResendRequest                 = "2"
+	MsgTypeReject                        = "3"
+	MsgTypeSequenceReset                 = "4"
+	MsgTypeLogout                        = "5"
+	MsgTypeLogon                         = "A"
+	MsgTypeMarketDataRequest             = "V"
+	MsgTypeMarketDataSnapshotFullRefresh = "W"
+	MsgTypeMarketDataIncrementalRefresh  = "X"
+	MsgTypeMarketDataRequestReject       = "Y"
+	MsgTypeBusinessMessageReject         = "j"
+)
+
+var (
+	// The stream is not a sequence of FIX 4.4 messages. The connection cannot recover, and must be closed.
+	ErrorGarbled = errors.New("garbled fix message")
+
+	// A message failed its checksum. The message has been skipped, so the next message can still be read.
+	ErrorChecksum = errors.New("fix message failed checksum")
+)
+
+// A single tag=value field.
+type Field struct {
+	Tag   int
+	Value string
+}
+
+// A FIX message, as its type and the ordered list of its other fields.
+// BeginString, BodyLength, MsgType, and CheckSum are not included in the fields, as they are added by Encode.
+// Repeating groups are kept in order, as the count field followed by the fields of each entry.
+type Message struct {
+	MsgType string
+	Fields  []Field
+}
+
+func NewMessage(msgType string) *Message {
+	return &Message{MsgType: msgType}
+}
+
+// Append a field to the message, returning the message for chaining.
+func (m *Message) Add(tag int, value string) *Message {
+	m.Fields = append(m.Fields, Field{Tag: tag, Value: value})
+	return m
+}
+
+func (m *Message) AddInt(tag int, value int) *Message {
+	return m.Add(tag, strconv.Itoa(value))
+}
+
+// Get the value of the first field with the given tag.
+func (m *Message) Get(tag int) (string, bool) {
+	for _, field := range m.Fields {
+		if field.Tag == tag {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// Get the value of the first field with the given tag as an integer.
+func (m *Message) GetInt(tag int) (int, error) {
+	value, ok := m.Get(tag)
+	if !ok {
+		return 0, fmt.Errorf("missing tag %d", tag)
+	}
+	return strconv.Atoi(value)
+}
+
+// Get the values of every field with the given tag, e.g. each Symbol of the NoRelatedSym group.
+func (m *Message) GetAll(tag int) []string {
+	var values []string
+	for _, field := range m.Fields {
+		if field.Tag == tag {
+			values = append(values, field.Value)
+		}
+	}
+	return values
+}
+
+// Whether the boolean field with the given tag is set to Y.
+func (m *Message) GetBool(tag int) bool {
+	value, _ := m.Get(tag)
+	return value == "Y"
+}
+
+// Encode the message, with the standard header and trailer.
+// Any header fields beyond those given here (e.g. PossDupFlag) must be the first fields of the message.
+func (m *Message) Encode(senderCompID, targetCompID string, msgSeqNum int, sendingTime time.Time) []byte {
+	var body bytes.Buffer
+	writeField(&body, TagMsgType, m.MsgType)
+	writeField(&body, TagSenderCompID, senderCompID)
+	writeField(&body, TagTargetCompID, targetCompID)
+	writeField(&body, TagMsgSeqNum, strconv.Itoa(msgSeqNum))
+	writeField(&body, TagSendingTime, sendingTime.UTC().Format(TimestampFormat))
+	for _, field := range m.Fields {
+		writeField(&body, field.Tag, field.Value)
+	}
+
+	var encoded bytes.Buffer
+	writeField(&encoded, TagBeginString, BeginString)
+	writeField(&encoded, TagBodyLength, strconv.Itoa(body.Len()))
+	encoded.Write(body.Bytes())
+	writeField(&encoded, TagCheckSum, fmt.Sprintf("%03d", checksum(encoded.Bytes())))
+	return encoded.Bytes()
+}
+
+func writeField(buf *bytes.Buffer, tag int, value string) {
+	buf.WriteString(strconv.Itoa(tag))
+	buf.WriteByte('=')
+	buf.WriteString(value)
+	buf.WriteByte(SOH)
+}
+
+func checksum(data []byte) int {
+	sum := 0
+	for _, b := range data {
+		sum += int(b)
+	}
+	return sum % 256
+}
+
+// Format the message for logs, with "|" in place of SOH.
+func (m *Message) String() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%d=%s|", TagMsgType, m.MsgType)
+	for _, field := range m.Fields {
+		fmt.Fprintf(&s, "%d=%s|", field.Tag, field.Value)
+	}
+	return s.String()
+}
+
+// --------------------------------------------------------------------------------
+
+// Read the next message from the reader.
+//
+// Returns ErrorChecksum if the message failed its checksum, in which case the message has been consumed
+// and reading may continue. Any other error (including ErrorGarbled) is fatal to the connection.
+func ReadMessage(r *bufio.Reader) (*Message, error) {
+	var raw bytes.Buffer
+
+	beginString, err := readField(r, &raw, TagBeginString)
+	if err != nil {
+		return nil, err
+	}
+	if beginString != BeginString {
+		return nil, fmt.Errorf("%w: unsupported BeginString %q", ErrorGarbled, beginString)
+	}
+	bodyLengthString, err := readField(r, &raw, TagBodyLength)
+	if err != nil {
+		return nil, err
+	}
+	bodyLength, err := strconv.Atoi(bodyLengthString)
+	if err != nil || bodyLength <= 0 || bodyLength > maxBodyLength {
+		return nil, fmt.Errorf("%w: invalid BodyLength %q", ErrorGarbled, bodyLengthString)
+	}
+
+	body := make([]byte, bodyLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	raw.Write(body)
+	expectedChecksum := checksum(raw.Bytes())
+
+	checksumString, err := readField(r, nil, TagCheckSum)
+	if err != nil {
+		return nil, err
+	}
+	if receivedChecksum, err := strconv.Atoi(checksumString); err != nil || receivedChecksum != expectedChecksum {
+		return nil, ErrorChecksum
+	}
+
+	if len(body) == 0 || body[len(body)-1] != SOH {
+		return nil, fmt.Errorf("%w: body does not end with a delimiter", ErrorGarbled)
+	}
+	m := &Message{}
+	for i, rawField := range bytes.Split(body[:len(body)-1], []byte{SOH}) {
+		tagString, value, ok := bytes.Cut(rawField, []byte{'='})
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q is not tag=value", ErrorGarbled, rawField)
+		}
+		tag, err := strconv.Atoi(string(tagString))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid tag %q", ErrorGarbled, tagString)
+		}
+		if i == 0 {
+			if tag != TagMsgType {
+				return nil, fmt.Errorf("%w: MsgType is not the first field of the body", ErrorGarbled)
+			}
+			m.MsgType = string(value)
+			continue
+		}
+		m.Fields = append(m.Fields, Field{Tag: tag, Value: string(value)})
+	}
+	return m, nil
+}
+
+// Read a single field, which must have the given tag, returning the value.
+// The raw bytes of the field are appended to raw, if not nil, for the checksum.
+func readField(r *bufio.Reader, raw *bytes.Buffer, expectedTag int) (string, error) {
+	rawField, err := r.ReadSlice(SOH)
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", fmt.Errorf("%w: field too long", ErrorGarbled)
+	} else if err != nil {
+		return "", err
+	}
+	if raw != nil {
+		raw.Write(rawField)
+	}
+	prefix := strconv.Itoa(expectedTag) + "="
+	value, ok := strings.CutPrefix(string(rawField[:len(rawField)-1]), prefix)
+	if !ok {
+		return "", fmt.Errorf("%w: expected tag %d", ErrorGarbled, expectedTag)
+	}
+	return value, nil
+}
@@ -0,0 +1,23 @@
+package fix
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics of the FIX acceptor, registered with the default registry.
+var (
+	metricSessions = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "genron",
+		Subsystem: "fix",
+		Name:      "sessions",
+		Help:      "The number of currently logged on FIX sessions.",
+	})
+
+	metricMessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "fix",
+		Name:      "messages_total",
+		Help:      "The number of FIX messages sent and received, by direction (sent or received) and message type.",
+	}, []string{"direction", "msgtype"})
+)
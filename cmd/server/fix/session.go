@@ -0,0 +1,357 @@
+package fix
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// The time to wait for the counterparty to confirm a logout initiated by the server.
+	logoutTimeout = 2 * time.Second
+
+	// How often to check whether a heartbeat or test request is due.
+	heartbeatCheckInterval = 100 * time.Millisecond
+)
+
+// The SessionRejectReason (373) of each session level Reject sent by the gateway.
+const (
+	sessionRejectReasonRequiredTagMissing = 1
+	sessionRejectReasonValueIsIncorrect   = 5
+	sessionRejectReasonCompIDProblem      = 9
+)
+
+// The BusinessRejectReason (380) of each BusinessMessageReject sent by the gateway.
+const (
+	businessRejectReasonUnsupportedMessageType = 3
+)
+
+// A logged on FIX session with a single counterparty.
+// Every method is called only from the goroutine running the session.
+type session struct {
+	acceptor     *Acceptor
+	conn         net.Conn
+	reader       *bufio.Reader
+	targetCompID string
+	seq          *sequenceNumbers
+	logger       *slog.Logger
+
+	heartbeatInterval time.Duration
+	lastSent          time.Time
+	lastReceived      time.Time
+	// The TestReqID of the unanswered TestRequest, if any.
+	testRequestID string
+
+	// While a ResendRequest is outstanding, the highest sequence number received beyond the gap. Otherwise zero.
+	resendTarget int
+	// The sequence number of a Logon received beyond a gap, counted once the gap is filled. Otherwise zero.
+	queuedLogonSeqNum int
+
+	// The active market data subscriptions, by MDReqID.
+	subscriptions map[string]*marketDataSubscription
+}
+
+func newSession(acceptor *Acceptor, conn net.Conn, reader *bufio.Reader, targetCompID string, seq *sequenceNumbers, heartbeatInterval time.Duration) *session {
+	now := time.Now()
+	return &session{
+		acceptor:          acceptor,
+		conn:              conn,
+		reader:            reader,
+		targetCompID:      targetCompID,
+		seq:               seq,
+		logger:            slog.With("fixTargetCompID", targetCompID),
+		heartbeatInterval: heartbeatInterval,
+		lastSent:          now,
+		lastReceived:      now,
+		subscriptions:     make(map[string]*marketDataSubscription),
+	}
+}
+
+// The result of reading a single message from the connection.
+type readResult struct {
+	message *Message
+	err     error
+}
+
+// Run the session until it is logged out, by either side, or fails.
+// Returns nil only if the session was logged out cleanly.
+func (s *session) run(ctx context.Context) error {
+	// Read messages in a separate goroutine, as reads block until a message arrives.
+	// The reader ends once the connection is closed, after the session ends.
+	incoming := make(chan readResult)
+	sessionDone := make(chan struct{})
+	defer close(sessionDone)
+	go func() {
+		for {
+			message, err := ReadMessage(s.reader)
+			if errors.Is(err, ErrorChecksum) {
+				s.logger.Warn("ignoring fix message with invalid checksum")
+				continue
+			}
+			select {
+			case incoming <- readResult{message: message, err: err}:
+			case <-sessionDone:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	subscription := s.acceptor.updates.Subscribe(s.acceptor.cfg.BufferSize)
+	defer subscription.Unsubscribe()
+	heartbeatTimer := time.NewTicker(heartbeatCheckInterval)
+	defer heartbeatTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return s.initiateLogout("server is shutting down", incoming)
+		case result := <-incoming:
+			if errors.Is(result.err, io.EOF) {
+				return errors.New("connection closed without logout")
+			} else if result.err != nil {
+				return result.err
+			}
+			s.lastReceived = time.Now()
+			s.testRequestID = ""
+			loggedOut, err := s.handle(result.message)
+			if err != nil {
+				// Errors handling a message are fatal to the session, so tell the counterparty why before disconnecting.
+				s.send(NewMessage(MsgTypeLogout).Add(TagText, err.Error()))
+				return err
+			}
+			if loggedOut {
+				return nil
+			}
+		case update, ok := <-subscription.C:
+			if !ok {
+				return s.initiateLogout("server is shutting down", incoming)
+			}
+			if err := s.publish(update); err != nil {
+				return err
+			}
+		case now := <-heartbeatTimer.C:
+			if now.Sub(s.lastSent) >= s.heartbeatInterval {
+				if err := s.send(NewMessage(MsgTypeHeartbeat)); err != nil {
+					return err
+				}
+			}
+			// Allow some leeway for transmission time before testing the counterparty, and before giving up on it.
+			sinceReceived := now.Sub(s.lastReceived)
+			leeway := s.heartbeatInterval / 5
+			if s.testRequestID == "" && sinceReceived >= s.heartbeatInterval+leeway {
+				s.testRequestID = strconv.FormatInt(now.UnixNano(), 10)
+				if err := s.send(NewMessage(MsgTypeTestRequest).Add(TagTestReqID, s.testRequestID)); err != nil {
+					return err
+				}
+			} else if s.testRequestID != "" && sinceReceived >= 2*s.heartbeatInterval+leeway {
+				s.send(NewMessage(MsgTypeLogout).Add(TagText, "no response to test request"))
+				return errors.New("counterparty did not respond to test request")
+			}
+		}
+	}
+}
+
+// Handle a single received message. Returns whether the session has been logged out.
+// Any error is fatal to the session.
+func (s *session) handle(m *Message) (bool, error) {
+	s.logMessage("received", m)
+
+	senderCompID, _ := m.Get(TagSenderCompID)
+	targetCompID, _ := m.Get(TagTargetCompID)
+	if senderCompID != s.targetCompID || targetCompID != s.acceptor.cfg.SenderCompID {
+		s.reject(m, TagSenderCompID, sessionRejectReasonCompIDProblem, "CompID problem")
+		return false, errors.New("CompID problem")
+	}
+	msgSeqNum, err := m.GetInt(TagMsgSeqNum)
+	if err != nil {
+		return false, errors.New("missing MsgSeqNum")
+	}
+
+	// A SequenceReset in reset mode (rather than gap fill mode) applies regardless of its sequence number.
+	if m.MsgType == MsgTypeSequenceReset && !m.GetBool(TagGapFillFlag) {
+		return false, s.handleSequenceReset(m)
+	}
+
+	switch {
+	case msgSeqNum > s.seq.nextIncoming:
+		// Messages beyond a gap are dropped, to be resent once the gap is filled,
+		// except for resend requests and logouts, which are handled immediately.
+		if err := s.requestResend(msgSeqNum); err != nil {
+			return false, err
+		}
+		switch m.MsgType {
+		case MsgTypeResendRequest:
+			return false, s.handleResendRequest(m)
+		case MsgTypeLogout:
+			return true, s.send(NewMessage(MsgTypeLogout))
+		}
+		return false, nil
+	case msgSeqNum < s.seq.nextIncoming:
+		if m.GetBool(TagPossDupFlag) {
+			// A duplicate of an already handled message.
+			return false, nil
+		}
+		return false, fmt.Errorf("MsgSeqNum too low, expecting %d but received %d", s.seq.nextIncoming, msgSeqNum)
+	}
+	s.advanceIncoming(msgSeqNum + 1)
+
+	switch m.MsgType {
+	case MsgTypeHeartbeat:
+	case MsgTypeTestRequest:
+		testRequestID, _ := m.Get(TagTestReqID)
+		return false, s.send(NewMessage(MsgTypeHeartbeat).Add(TagTestReqID, testRequestID))
+	case MsgTypeResendRequest:
+		return false, s.handleResendRequest(m)
+	case MsgTypeReject:
+		text, _ := m.Get(TagText)
+		refSeqNum, _ := m.Get(TagRefSeqNum)
+		s.logger.Warn("fix message rejected by counterparty", "refSeqNum", refSeqNum, "text", text)
+	case MsgTypeSequenceReset:
+		return false, s.handleSequenceReset(m)
+	case MsgTypeLogout:
+		return true, s.send(NewMessage(MsgTypeLogout))
+	case MsgTypeLogon:
+		return false, errors.New("received Logon while already logged on")
+	case MsgTypeMarketDataRequest:
+		return false, s.handleMarketDataRequest(m)
+	default:
+		return false, s.businessReject(m, businessRejectReasonUnsupportedMessageType, fmt.Sprintf("unsupported MsgType %q", m.MsgType))
+	}
+	return false, nil
+}
+
+// Set the next expected incoming sequence number, counting any queued Logon and ending any filled resend request.
+func (s *session) advanceIncoming(nextIncoming int) {
+	s.seq.nextIncoming = nextIncoming
+	if s.queuedLogonSeqNum > 0 && s.seq.nextIncoming >= s.queuedLogonSeqNum {
+		s.seq.nextIncoming = max(s.seq.nextIncoming, s.queuedLogonSeqNum+1)
+		s.queuedLogonSeqNum = 0
+	}
+	if s.resendTarget > 0 && s.seq.nextIncoming > s.resendTarget {
+		s.resendTarget = 0
+	}
+}
+
+// Request the counterparty resend everything from the next expected sequence number,
+// having received the given sequence number beyond it. Only a single resend request is outstanding at a time.
+func (s *session) requestResend(receivedSeqNum int) error {
+	defer func() {
+		s.resendTarget = max(s.resendTarget, receivedSeqNum)
+	}()
+	if s.resendTarget > 0 {
+		return nil
+	}
+	s.logger.Info("fix sequence gap detected, requesting resend", "expected", s.seq.nextIncoming, "received", receivedSeqNum)
+	return s.send(NewMessage(MsgTypeResendRequest).
+		AddInt(TagBeginSeqNo, s.seq.nextIncoming).
+		AddInt(TagEndSeqNo, 0))
+}
+
+// Market data is not stored, and stale market data is useless, so every resend request is answered with a gap fill.
+func (s *session) handleResendRequest(m *Message) error {
+	beginSeqNo, err := m.GetInt(TagBeginSeqNo)
+	if err != nil || beginSeqNo < 1 {
+		return s.reject(m, TagBeginSeqNo, sessionRejectReasonValueIsIncorrect, "invalid BeginSeqNo")
+	}
+	if beginSeqNo >= s.seq.nextOutgoing {
+		return nil
+	}
+	// The gap fill takes the sequence number of the first message to resend, and must mark itself as a possible duplicate.
+	gapFill := NewMessage(MsgTypeSequenceReset).
+		Add(TagPossDupFlag, "Y").
+		Add(TagOrigSendingTime, time.Now().UTC().Format(TimestampFormat)).
+		Add(TagGapFillFlag, "Y").
+		AddInt(TagNewSeqNo, s.seq.nextOutgoing)
+	return s.write(gapFill, beginSeqNo)
+}
+
+func (s *session) handleSequenceReset(m *Message) error {
+	newSeqNo, err := m.GetInt(TagNewSeqNo)
+	if err != nil {
+		return s.reject(m, TagNewSeqNo, sessionRejectReasonRequiredTagMissing, "missing NewSeqNo")
+	}
+	if newSeqNo < s.seq.nextIncoming {
+		return s.reject(m, TagNewSeqNo, sessionRejectReasonValueIsIncorrect, fmt.Sprintf("NewSeqNo %d is lower than the expected MsgSeqNum %d", newSeqNo, s.seq.nextIncoming))
+	}
+	s.advanceIncoming(newSeqNo)
+	return nil
+}
+
+// Send a Logout, and wait (briefly) for the counterparty to confirm it.
+func (s *session) initiateLogout(text string, incoming <-chan readResult) error {
+	if err := s.send(NewMessage(MsgTypeLogout).Add(TagText, text)); err != nil {
+		return err
+	}
+	timeout := time.NewTimer(logoutTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case result := <-incoming:
+			if result.err != nil || result.message.MsgType == MsgTypeLogout {
+				return nil
+			}
+		case <-timeout.C:
+			return errors.New("counterparty did not confirm logout")
+		}
+	}
+}
+
+// Send a session level Reject of the given message.
+func (s *session) reject(m *Message, refTagID int, reason int, text string) error {
+	reject := NewMessage(MsgTypeReject)
+	if refSeqNum, ok := m.Get(TagMsgSeqNum); ok {
+		reject.Add(TagRefSeqNum, refSeqNum)
+	}
+	return s.send(reject.
+		AddInt(TagRefTagID, refTagID).
+		Add(TagRefMsgType, m.MsgType).
+		AddInt(TagSessionRejectReason, reason).
+		Add(TagText, text))
+}
+
+// Send a BusinessMessageReject of the given message.
+func (s *session) businessReject(m *Message, reason int, text string) error {
+	refSeqNum, _ := m.Get(TagMsgSeqNum)
+	return s.send(NewMessage(MsgTypeBusinessMessageReject).
+		Add(TagRefSeqNum, refSeqNum).
+		Add(TagRefMsgType, m.MsgType).
+		AddInt(TagBusinessRejectReason, reason).
+		Add(TagText, text))
+}
+
+// Send a message with the next outgoing sequence number.
+func (s *session) send(m *Message) error {
+	if err := s.write(m, s.seq.nextOutgoing); err != nil {
+		return err
+	}
+	s.seq.nextOutgoing++
+	return nil
+}
+
+// Write a message with the given sequence number, without advancing the outgoing sequence number.
+func (s *session) write(m *Message, msgSeqNum int) error {
+	now := time.Now()
+	s.conn.SetWriteDeadline(now.Add(s.acceptor.cfg.WriteTimeout))
+	if _, err := s.conn.Write(m.Encode(s.acceptor.cfg.SenderCompID, s.targetCompID, msgSeqNum, now)); err != nil {
+		return err
+	}
+	s.lastSent = now
+	s.logMessage("sent", m)
+	return nil
+}
+
+func (s *session) logMessage(direction string, m *Message) {
+	metricMessagesTotal.WithLabelValues(direction, m.MsgType).Inc()
+	if s.acceptor.cfg.LogMessages {
+		s.logger.Debug("fix message", "direction", direction, "message", m.String())
+	}
+}
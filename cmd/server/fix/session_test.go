@@ -0,0 +1,505 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+const (
+	testSenderCompID = "GENRON"
+	testTargetCompID = "CLIENT"
+
+	// How long to wait for a message the session should send.
+	testReceiveTimeout = 3 * time.Second
+
+	// How long to wait before deciding the session sent nothing.
+	testSilenceTimeout = 50 * time.Millisecond
+)
+
+// The counterparty of a session under test, at the other end of a net.Pipe.
+type testCounterparty struct {
+	t        *testing.T
+	conn     net.Conn
+	received chan *Message
+
+	mu      sync.Mutex
+	nextSeq int
+}
+
+// Create a session logged on with a counterparty over a net.Pipe.
+// Every message the session sends is read by the counterparty, since writes to a net.Pipe block until read.
+func newTestSession(t *testing.T, nextIncoming, nextOutgoing int, heartbeatInterval time.Duration) (*session, *testCounterparty) {
+	t.Helper()
+	cfg := config.FIXConfig{
+		SenderCompID: testSenderCompID,
+		WriteTimeout: time.Second,
+		BufferSize:   16,
+	}
+	acceptor := NewAcceptor(cfg, map[string]ticker.Ticker{}, ticker.NewUpdateBroadcaster())
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() {
+		serverConn.Close()
+		clientConn.Close()
+	})
+
+	seq := &sequenceNumbers{nextIncoming: nextIncoming, nextOutgoing: nextOutgoing}
+	s := newSession(acceptor, serverConn, bufio.NewReader(serverConn), testTargetCompID, seq, heartbeatInterval)
+	cp := &testCounterparty{
+		t:        t,
+		conn:     clientConn,
+		received: make(chan *Message, 64),
+		nextSeq:  1,
+	}
+	go func() {
+		reader := bufio.NewReader(clientConn)
+		for {
+			m, err := ReadMessage(reader)
+			if err != nil {
+				close(cp.received)
+				return
+			}
+			cp.received <- m
+		}
+	}()
+	return s, cp
+}
+
+// Build a message as received from the counterparty, with the given sequence number, by encoding and reading it back.
+func testMessage(t *testing.T, msgType string, msgSeqNum int, fields ...Field) *Message {
+	t.Helper()
+	m := &Message{MsgType: msgType, Fields: fields}
+	decoded, err := ReadMessage(bufio.NewReader(bytes.NewReader(m.Encode(testTargetCompID, testSenderCompID, msgSeqNum, time.Now()))))
+	if err != nil {
+		t.Fatalf("error reading back test message: %v", err)
+	}
+	return decoded
+}
+
+// Send a message to the session with the next sequence number of the counterparty.
+func (cp *testCounterparty) send(m *Message) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.conn.SetWriteDeadline(time.Now().Add(testReceiveTimeout))
+	if _, err := cp.conn.Write(m.Encode(testTargetCompID, testSenderCompID, cp.nextSeq, time.Now())); err != nil {
+		cp.t.Errorf("error sending %s: %v", m, err)
+		return
+	}
+	cp.nextSeq++
+}
+
+// Wait for the next message sent by the session.
+func (cp *testCounterparty) receive() *Message {
+	cp.t.Helper()
+	select {
+	case m, ok := <-cp.received:
+		if !ok {
+			cp.t.Fatal("connection closed while waiting for a message")
+		}
+		return m
+	case <-time.After(testReceiveTimeout):
+		cp.t.Fatal("timed out waiting for a message")
+		return nil
+	}
+}
+
+// Check that the session sends nothing further.
+func (cp *testCounterparty) expectSilence() {
+	cp.t.Helper()
+	select {
+	case m, ok := <-cp.received:
+		if ok {
+			cp.t.Errorf("unexpected message sent: %s", m)
+		}
+	case <-time.After(testSilenceTimeout):
+	}
+}
+
+// Check a message sent by the session has the wanted type and fields (including header fields, e.g. MsgSeqNum).
+func checkMessage(t *testing.T, got *Message, want *Message) {
+	t.Helper()
+	if got.MsgType != want.MsgType {
+		t.Errorf("sent MsgType %q, expected %q: %s", got.MsgType, want.MsgType, got)
+		return
+	}
+	for _, field := range want.Fields {
+		if value, _ := got.Get(field.Tag); value != field.Value {
+			t.Errorf("sent tag %d = %q, expected %q: %s", field.Tag, value, field.Value, got)
+		}
+	}
+}
+
+func TestSessionHandle(t *testing.T) {
+	type step struct {
+		message       *Message
+		wantSent      []*Message
+		wantErr       bool
+		wantLoggedOut bool
+	}
+	tests := []struct {
+		name              string
+		nextIncoming      int
+		nextOutgoing      int
+		queuedLogonSeqNum int
+		steps             func(t *testing.T) []step
+
+		wantNextIncoming int
+		wantNextOutgoing int
+		wantResendTarget int
+	}{
+		{
+			name:         "in sequence heartbeat",
+			nextIncoming: 1,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{message: testMessage(t, MsgTypeHeartbeat, 1)},
+				}
+			},
+			wantNextIncoming: 2,
+			wantNextOutgoing: 1,
+		},
+		{
+			name:         "test request answered with heartbeat",
+			nextIncoming: 1,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{
+						message: testMessage(t, MsgTypeTestRequest, 1, Field{TagTestReqID, "ping"}),
+						wantSent: []*Message{
+							NewMessage(MsgTypeHeartbeat).Add(TagTestReqID, "ping").AddInt(TagMsgSeqNum, 1),
+						},
+					},
+				}
+			},
+			wantNextIncoming: 2,
+			wantNextOutgoing: 2,
+		},
+		{
+			name:         "gap sends a single resend request",
+			nextIncoming: 1,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{
+						message: testMessage(t, MsgTypeHeartbeat, 5),
+						wantSent: []*Message{
+							NewMessage(MsgTypeResendRequest).AddInt(TagBeginSeqNo, 1).AddInt(TagEndSeqNo, 0),
+						},
+					},
+					// A second message beyond the gap extends the outstanding request, rather than sending another.
+					{message: testMessage(t, MsgTypeHeartbeat, 6)},
+					{message: testMessage(t, MsgTypeHeartbeat, 7)},
+				}
+			},
+			wantNextIncoming: 1,
+			wantNextOutgoing: 2,
+			wantResendTarget: 7,
+		},
+		{
+			name:         "gap filled by resent messages ends the resend request",
+			nextIncoming: 1,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{
+						message:  testMessage(t, MsgTypeHeartbeat, 3),
+						wantSent: []*Message{NewMessage(MsgTypeResendRequest).AddInt(TagBeginSeqNo, 1)},
+					},
+					{message: testMessage(t, MsgTypeSequenceReset, 1, Field{TagPossDupFlag, "Y"}, Field{TagGapFillFlag, "Y"}, Field{TagNewSeqNo, "3"})},
+					{message: testMessage(t, MsgTypeHeartbeat, 3, Field{TagPossDupFlag, "Y"})},
+					// Once the gap is filled, a new gap is requested again.
+					{
+						message:  testMessage(t, MsgTypeHeartbeat, 6),
+						wantSent: []*Message{NewMessage(MsgTypeResendRequest).AddInt(TagBeginSeqNo, 4)},
+					},
+				}
+			},
+			wantNextIncoming: 4,
+			wantNextOutgoing: 3,
+			wantResendTarget: 6,
+		},
+		{
+			name:         "logout beyond a gap is handled immediately",
+			nextIncoming: 1,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{
+						message: testMessage(t, MsgTypeLogout, 4),
+						wantSent: []*Message{
+							NewMessage(MsgTypeResendRequest).AddInt(TagBeginSeqNo, 1),
+							NewMessage(MsgTypeLogout),
+						},
+						wantLoggedOut: true,
+					},
+				}
+			},
+			wantNextIncoming: 1,
+			wantNextOutgoing: 3,
+			wantResendTarget: 4,
+		},
+		{
+			name:         "resend request answered with gap fill",
+			nextIncoming: 1,
+			nextOutgoing: 10,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{
+						message: testMessage(t, MsgTypeResendRequest, 1, Field{TagBeginSeqNo, "3"}, Field{TagEndSeqNo, "0"}),
+						wantSent: []*Message{
+							NewMessage(MsgTypeSequenceReset).
+								AddInt(TagMsgSeqNum, 3).
+								Add(TagPossDupFlag, "Y").
+								Add(TagGapFillFlag, "Y").
+								AddInt(TagNewSeqNo, 10),
+						},
+					},
+				}
+			},
+			wantNextIncoming: 2,
+			wantNextOutgoing: 10,
+		},
+		{
+			name:         "resend request of nothing sent is ignored",
+			nextIncoming: 1,
+			nextOutgoing: 10,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{message: testMessage(t, MsgTypeResendRequest, 1, Field{TagBeginSeqNo, "10"}, Field{TagEndSeqNo, "0"})},
+				}
+			},
+			wantNextIncoming: 2,
+			wantNextOutgoing: 10,
+		},
+		{
+			name:         "MsgSeqNum too low without PossDup ends the session",
+			nextIncoming: 5,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{message: testMessage(t, MsgTypeHeartbeat, 3), wantErr: true},
+				}
+			},
+			wantNextIncoming: 5,
+			wantNextOutgoing: 1,
+		},
+		{
+			name:         "MsgSeqNum too low with PossDup is ignored",
+			nextIncoming: 5,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{message: testMessage(t, MsgTypeHeartbeat, 3, Field{TagPossDupFlag, "Y"})},
+				}
+			},
+			wantNextIncoming: 5,
+			wantNextOutgoing: 1,
+		},
+		{
+			name:         "sequence reset applies regardless of MsgSeqNum",
+			nextIncoming: 5,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{message: testMessage(t, MsgTypeSequenceReset, 1, Field{TagNewSeqNo, "20"})},
+				}
+			},
+			wantNextIncoming: 20,
+			wantNextOutgoing: 1,
+		},
+		{
+			name:         "sequence reset lowering MsgSeqNum is rejected",
+			nextIncoming: 5,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{
+						message: testMessage(t, MsgTypeSequenceReset, 5, Field{TagNewSeqNo, "2"}),
+						wantSent: []*Message{
+							NewMessage(MsgTypeReject).
+								AddInt(TagRefTagID, TagNewSeqNo).
+								AddInt(TagSessionRejectReason, sessionRejectReasonValueIsIncorrect),
+						},
+					},
+				}
+			},
+			wantNextIncoming: 5,
+			wantNextOutgoing: 2,
+		},
+		{
+			name:              "queued logon is counted once the gap is filled",
+			nextIncoming:      1,
+			nextOutgoing:      2,
+			queuedLogonSeqNum: 3,
+			steps: func(t *testing.T) []step {
+				return []step{
+					{message: testMessage(t, MsgTypeSequenceReset, 1, Field{TagPossDupFlag, "Y"}, Field{TagGapFillFlag, "Y"}, Field{TagNewSeqNo, "3"})},
+				}
+			},
+			wantNextIncoming: 4,
+			wantNextOutgoing: 2,
+		},
+		{
+			name:         "wrong CompID ends the session",
+			nextIncoming: 1,
+			nextOutgoing: 1,
+			steps: func(t *testing.T) []step {
+				m := testMessage(t, MsgTypeHeartbeat, 1)
+				for i, field := range m.Fields {
+					if field.Tag == TagSenderCompID {
+						m.Fields[i].Value = "OTHER"
+					}
+				}
+				return []step{
+					{
+						message:  m,
+						wantSent: []*Message{NewMessage(MsgTypeReject).AddInt(TagSessionRejectReason, sessionRejectReasonCompIDProblem)},
+						wantErr:  true,
+					},
+				}
+			},
+			wantNextIncoming: 1,
+			wantNextOutgoing: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, cp := newTestSession(t, tt.nextIncoming, tt.nextOutgoing, 30*time.Second)
+			s.queuedLogonSeqNum = tt.queuedLogonSeqNum
+
+			for i, step := range tt.steps(t) {
+				loggedOut, err := s.handle(step.message)
+				if (err != nil) != step.wantErr {
+					t.Fatalf("step %d: handle returned error %v, expected error %v", i, err, step.wantErr)
+				}
+				if loggedOut != step.wantLoggedOut {
+					t.Errorf("step %d: handle returned logged out %v, expected %v", i, loggedOut, step.wantLoggedOut)
+				}
+				for _, want := range step.wantSent {
+					checkMessage(t, cp.receive(), want)
+				}
+				cp.expectSilence()
+			}
+
+			if s.seq.nextIncoming != tt.wantNextIncoming {
+				t.Errorf("next incoming MsgSeqNum is %d, expected %d", s.seq.nextIncoming, tt.wantNextIncoming)
+			}
+			if s.seq.nextOutgoing != tt.wantNextOutgoing {
+				t.Errorf("next outgoing MsgSeqNum is %d, expected %d", s.seq.nextOutgoing, tt.wantNextOutgoing)
+			}
+			if s.resendTarget != tt.wantResendTarget {
+				t.Errorf("resend target is %d, expected %d", s.resendTarget, tt.wantResendTarget)
+			}
+		})
+	}
+}
+
+func TestSessionRunTimeouts(t *testing.T) {
+	const heartbeatInterval = 200 * time.Millisecond
+
+	tests := []struct {
+		name string
+		// Send a heartbeat every half heartbeat interval, so the session never needs to test the counterparty.
+		sendHeartbeats bool
+		// Answer every TestRequest with a Heartbeat.
+		answerTestRequests bool
+
+		// The MsgTypes the session must send, in order (other messages may be sent in between).
+		wantSent []string
+		// A MsgType the session must not send before the end of the test.
+		unwantedSent string
+		// Whether the session ends on its own with an error, rather than being logged out by the test.
+		wantErr bool
+	}{
+		{
+			name:           "heartbeat sent while idle",
+			sendHeartbeats: true,
+			wantSent:       []string{MsgTypeHeartbeat, MsgTypeHeartbeat},
+			unwantedSent:   MsgTypeTestRequest,
+		},
+		{
+			name:               "answered test request keeps the session",
+			answerTestRequests: true,
+			wantSent:           []string{MsgTypeTestRequest, MsgTypeTestRequest},
+			unwantedSent:       MsgTypeLogout,
+		},
+		{
+			name:     "unanswered test request ends the session",
+			wantSent: []string{MsgTypeHeartbeat, MsgTypeTestRequest, MsgTypeLogout},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, cp := newTestSession(t, 1, 1, heartbeatInterval)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			runErr := make(chan error, 1)
+			go func() {
+				runErr <- s.run(ctx)
+			}()
+
+			stopHeartbeats := make(chan struct{})
+			var heartbeatsDone sync.WaitGroup
+			if tt.sendHeartbeats {
+				heartbeatsDone.Go(func() {
+					heartbeatTicker := time.NewTicker(heartbeatInterval / 2)
+					defer heartbeatTicker.Stop()
+					for {
+						select {
+						case <-stopHeartbeats:
+							return
+						case <-heartbeatTicker.C:
+							cp.send(NewMessage(MsgTypeHeartbeat))
+						}
+					}
+				})
+			}
+
+			sawLogout := false
+			for wanted := 0; wanted < len(tt.wantSent); {
+				m := cp.receive()
+				if m.MsgType == tt.unwantedSent {
+					t.Errorf("unexpected message sent: %s", m)
+				}
+				if m.MsgType == MsgTypeTestRequest && tt.answerTestRequests {
+					testRequestID, _ := m.Get(TagTestReqID)
+					cp.send(NewMessage(MsgTypeHeartbeat).Add(TagTestReqID, testRequestID))
+				}
+				sawLogout = sawLogout || m.MsgType == MsgTypeLogout
+				if m.MsgType == tt.wantSent[wanted] {
+					wanted++
+				}
+			}
+			close(stopHeartbeats)
+			heartbeatsDone.Wait()
+
+			// Log out (as the server does on shutdown), confirming the Logout of the session.
+			if !sawLogout {
+				cancel()
+				for m := cp.receive(); m.MsgType != MsgTypeLogout; m = cp.receive() {
+				}
+				cp.send(NewMessage(MsgTypeLogout))
+			}
+
+			select {
+			case err := <-runErr:
+				if (err != nil) != tt.wantErr {
+					t.Errorf("session ended with error %v, expected error %v", err, tt.wantErr)
+				}
+			case <-time.After(testReceiveTimeout):
+				t.Fatal("timed out waiting for the session to end")
+			}
+		})
+	}
+}
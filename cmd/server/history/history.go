@@ -0,0 +1,205 @@
+// Package history keeps a bounded, in-memory history of the recent updates of every ticker,
+// fed by the update broadcaster, so that recent history and candles can be served without a database.
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// A single recorded value of a ticker.
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+
+	// The total size of the trades of the update, and of any updates skipped by the sampling interval before it.
+	Volume int64
+}
+
+// The open, high, low, and close values of a ticker over an interval, aligned to the unix epoch.
+type Candle struct {
+	StartTimestamp time.Time
+	Open           float64
+	High           float64
+	Low            float64
+	Close          float64
+
+	// The number of updates in the interval.
+	Count int
+
+	// The total size of the trades in the interval.
+	Volume int64
+}
+
+// Records the most recent updates of every ticker, up to a maximum number of points per ticker.
+// Safe for concurrent use.
+type Store struct {
+	cfg config.HistoryConfig
+
+	mu     sync.RWMutex
+	series map[string]*series
+}
+
+// A ring buffer of the points of a single ticker, in time order.
+type series struct {
+	points []Point
+	start  int
+	length int
+
+	// The volume of the updates since the last recorded point.
+	pendingVolume int64
+}
+
+func NewStore(cfg config.HistoryConfig) *Store {
+	return &Store{
+		cfg:    cfg,
+		series: make(map[string]*series),
+	}
+}
+
+// Subscribe to the broadcaster and record every update, in a new goroutine, until the broadcaster is closed.
+// Does nothing if the history is disabled (a maximum of zero points).
+func (s *Store) Start(updates *ticker.UpdateBroadcaster) {
+	if s.cfg.MaxPoints == 0 {
+		return
+	}
+
+	subscription := updates.Subscribe(s.cfg.BufferSize)
+	go func() {
+		for update := range subscription.C {
+			s.record(update)
+		}
+	}()
+}
+
+// Whether any history is kept.
+func (s *Store) Enabled() bool {
+	return s.cfg.MaxPoints > 0
+}
+
+func (s *Store) record(update ticker.TickerUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tickerSeries, ok := s.series[update.Name]
+	if !ok {
+		tickerSeries = &series{
+			points: make([]Point, s.cfg.MaxPoints),
+		}
+		s.series[update.Name] = tickerSeries
+	}
+
+	var volume int64
+	for _, trade := range update.Trades {
+		volume += trade.Size
+	}
+
+	// The volume of updates skipped by the sampling interval is carried to the next recorded point, so no volume is lost.
+	tickerSeries.pendingVolume += volume
+	if tickerSeries.length > 0 && s.cfg.SamplingInterval > 0 {
+		lastPoint := tickerSeries.at(tickerSeries.length - 1)
+		if update.Timestamp.Sub(lastPoint.Timestamp) < s.cfg.SamplingInterval {
+			return
+		}
+	}
+	tickerSeries.append(Point{Timestamp: update.Timestamp, Value: update.Value, Volume: tickerSeries.pendingVolume})
+	tickerSeries.pendingVolume = 0
+}
+
+func (ts *series) at(i int) Point {
+	return ts.points[(ts.start+i)%len(ts.points)]
+}
+
+func (ts *series) append(point Point) {
+	if ts.length < len(ts.points) {
+		ts.points[(ts.start+ts.length)%len(ts.points)] = point
+		ts.length++
+		return
+	}
+	// Full, so overwrite the oldest point.
+	ts.points[ts.start] = point
+	ts.start = (ts.start + 1) % len(ts.points)
+}
+
+// Find the indices [first, last) of the points with timestamps in [from, to).
+// A zero from or to leaves that end of the range open.
+func (ts *series) indexRange(from, to time.Time) (int, int) {
+	first := 0
+	if !from.IsZero() {
+		first = sort.Search(ts.length, func(i int) bool { return !ts.at(i).Timestamp.Before(from) })
+	}
+	last := ts.length
+	if !to.IsZero() {
+		last = sort.Search(ts.length, func(i int) bool { return !ts.at(i).Timestamp.Before(to) })
+	}
+	return first, max(first, last)
+}
+
+// Get the recorded points of the named ticker with timestamps in [from, to), oldest first.
+// If there are more than limit points, only the most recent limit points are returned.
+// A zero from or to leaves that end of the range open.
+//
+// Returns false if no updates of the ticker have been recorded.
+func (s *Store) Points(tickerName string, from, to time.Time, limit int) ([]Point, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tickerSeries, ok := s.series[tickerName]
+	if !ok {
+		return nil, false
+	}
+
+	first, last := tickerSeries.indexRange(from, to)
+	first = max(first, last-limit)
+	points := make([]Point, 0, last-first)
+	for i := first; i < last; i++ {
+		points = append(points, tickerSeries.at(i))
+	}
+	return points, true
+}
+
+// Aggregate the recorded points of the named ticker with timestamps in [from, to) into candles of the given interval,
+// oldest first. Intervals with no updates have no candle.
+// If there are more than limit candles, only the most recent limit candles are returned.
+// A zero from or to leaves that end of the range open.
+//
+// Returns false if no updates of the ticker have been recorded.
+func (s *Store) Candles(tickerName string, interval time.Duration, from, to time.Time, limit int) ([]Candle, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tickerSeries, ok := s.series[tickerName]
+	if !ok {
+		return nil, false
+	}
+
+	first, last := tickerSeries.indexRange(from, to)
+	candles := make([]Candle, 0)
+	for i := first; i < last; i++ {
+		point := tickerSeries.at(i)
+		startTimestamp := point.Timestamp.Truncate(interval)
+		if len(candles) == 0 || !candles[len(candles)-1].StartTimestamp.Equal(startTimestamp) {
+			candles = append(candles, Candle{
+				StartTimestamp: startTimestamp,
+				Open:           point.Value,
+				High:           point.Value,
+				Low:            point.Value,
+			})
+		}
+		candle := &candles[len(candles)-1]
+		candle.High = max(candle.High, point.Value)
+		candle.Low = min(candle.Low, point.Value)
+		candle.Close = point.Value
+		candle.Count++
+		candle.Volume += point.Volume
+	}
+
+	if len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+	return candles, true
+}
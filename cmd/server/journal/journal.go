@@ -0,0 +1,390 @@
+// Package journal implements a compact, checksummed, append-only binary journal of ticker updates,
+// split into segment files, and the replay of a journal through the normal server API.
+//
+// A journal is a directory of segment files named journal-00000001.gnj, journal-00000002.gnj, and so on.
+// Segments are never modified once closed. Each segment starts with an 8 byte magic,
+// followed by any number of records. All integers are little-endian, and all floats are IEEE 754 bits.
+// The magic is "GENRONJ2", and each record is laid out as:
+//
+//	uint32  payload length (bytes)
+//	uint32  CRC-32C (Castagnoli) of the payload
+//	payload:
+//	  int64    timestamp (unix nanoseconds)
+//	  float64  value
+//	  float64  bid
+//	  float64  ask
+//	  int64    bid size
+//	  int64    ask size
+//	  uint8    quote flags (bit 0: has bid, bit 1: has ask)
+//	  uint16   number of trades
+//	  uint16   name length (bytes)
+//	  []byte   name (UTF-8)
+//	  trades, each:
+//	    int64    timestamp (unix nanoseconds)
+//	    float64  price
+//	    int64    size
+//	    uint8    aggressor (0: buy, 1: sell)
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+const (
+	segmentMagic         = "GENRONJ2"
+	segmentFilePattern   = "journal-*.gnj"
+	segmentFileFormat    = "journal-%08d.gnj"
+	recordHeaderSize     = 8
+	payloadFixedSize     = 8 + 8 + 8 + 8 + 8 + 8 + 1 + 2 + 2
+	tradeSize            = 8 + 8 + 8 + 1
+	maxRecordPayloadSize = payloadFixedSize + math.MaxUint16 + math.MaxUint16*tradeSize
+)
+
+// The bits of the quote flags of a record.
+const (
+	quoteFlagHasBid = 1 << iota
+	quoteFlagHasAsk
+)
+
+// The aggressor of a trade of a record.
+const (
+	aggressorBuy  = 0
+	aggressorSell = 1
+)
+
+var (
+	ErrorNotJournalSegment = errors.New("file is not a journal segment")
+	ErrorCorruptRecord     = errors.New("journal record failed checksum")
+	ErrorEmptyJournal      = errors.New("journal contains no segments")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// List the segment files of the journal in the given directory, in order.
+func listSegments(journalDir string) ([]string, error) {
+	segmentFilePaths, err := filepath.Glob(filepath.Join(journalDir, segmentFilePattern))
+	if err != nil {
+		return nil, err
+	}
+	// Segment indices are zero padded, so lexical order is numeric order.
+	slices.Sort(segmentFilePaths)
+	return segmentFilePaths, nil
+}
+
+// --------------------------------------------------------------------------------
+
+// Appends ticker updates to a journal, rotating to a new segment once the current segment reaches the maximum size.
+// Not safe for concurrent use.
+type Writer struct {
+	journalDir     string
+	maxSegmentSize int64
+	syncOnFlush    bool
+
+	segmentIndex  int
+	segmentFile   *os.File
+	segmentWriter *bufio.Writer
+	segmentSize   int64
+	recordBuffer  []byte
+}
+
+// Open the journal in the given directory for appending, creating the directory if needed.
+// Writing always starts a new segment, after any existing segments, so existing segments are never modified.
+func NewWriter(journalDir string, maxSegmentSize int64, syncOnFlush bool) (*Writer, error) {
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		return nil, err
+	}
+	segmentFilePaths, err := listSegments(journalDir)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		journalDir:     journalDir,
+		maxSegmentSize: maxSegmentSize,
+		syncOnFlush:    syncOnFlush,
+		recordBuffer:   make([]byte, 0, recordHeaderSize+payloadFixedSize+64),
+	}
+	if len(segmentFilePaths) > 0 {
+		lastSegmentName := filepath.Base(segmentFilePaths[len(segmentFilePaths)-1])
+		if _, err := fmt.Sscanf(lastSegmentName, segmentFileFormat, &w.segmentIndex); err != nil {
+			return nil, fmt.Errorf("error parsing journal segment name %q: %w", lastSegmentName, err)
+		}
+	}
+	if err := w.rotate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Close the current segment (if any) and start the next.
+func (w *Writer) rotate() error {
+	if w.segmentFile != nil {
+		if err := w.closeSegment(); err != nil {
+			return err
+		}
+	}
+
+	w.segmentIndex++
+	segmentFilePath := filepath.Join(w.journalDir, fmt.Sprintf(segmentFileFormat, w.segmentIndex))
+	segmentFile, err := os.OpenFile(segmentFilePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	w.segmentFile = segmentFile
+	w.segmentWriter = bufio.NewWriter(segmentFile)
+	if _, err := w.segmentWriter.WriteString(segmentMagic); err != nil {
+		return err
+	}
+	w.segmentSize = int64(len(segmentMagic))
+	return nil
+}
+
+func (w *Writer) closeSegment() error {
+	if err := w.segmentWriter.Flush(); err != nil {
+		return err
+	}
+	if err := w.segmentFile.Sync(); err != nil {
+		return err
+	}
+	return w.segmentFile.Close()
+}
+
+// Append a single update to the journal, rotating segments if needed.
+// Updates are buffered until Flush is called.
+func (w *Writer) Append(update ticker.TickerUpdate) error {
+	name := update.Name
+	if len(name) > math.MaxUint16 {
+		return fmt.Errorf("ticker name of length %d too long for journal", len(name))
+	}
+	if len(update.Trades) > math.MaxUint16 {
+		return fmt.Errorf("update with %d trades has too many trades for journal", len(update.Trades))
+	}
+	payloadSize := payloadFixedSize + len(name) + len(update.Trades)*tradeSize
+
+	var quoteFlags uint8
+	if update.Quote.HasBid {
+		quoteFlags |= quoteFlagHasBid
+	}
+	if update.Quote.HasAsk {
+		quoteFlags |= quoteFlagHasAsk
+	}
+
+	record := w.recordBuffer[:recordHeaderSize]
+	record = binary.LittleEndian.AppendUint64(record, uint64(update.Timestamp.UnixNano()))
+	record = binary.LittleEndian.AppendUint64(record, math.Float64bits(update.Value))
+	record = binary.LittleEndian.AppendUint64(record, math.Float64bits(update.Quote.Bid))
+	record = binary.LittleEndian.AppendUint64(record, math.Float64bits(update.Quote.Ask))
+	record = binary.LittleEndian.AppendUint64(record, uint64(update.Quote.BidSize))
+	record = binary.LittleEndian.AppendUint64(record, uint64(update.Quote.AskSize))
+	record = append(record, quoteFlags)
+	record = binary.LittleEndian.AppendUint16(record, uint16(len(update.Trades)))
+	record = binary.LittleEndian.AppendUint16(record, uint16(len(name)))
+	record = append(record, name...)
+	for _, trade := range update.Trades {
+		aggressor := uint8(aggressorBuy)
+		if trade.Aggressor == ticker.SideSell {
+			aggressor = aggressorSell
+		}
+		record = binary.LittleEndian.AppendUint64(record, uint64(trade.Timestamp.UnixNano()))
+		record = binary.LittleEndian.AppendUint64(record, math.Float64bits(trade.Price))
+		record = binary.LittleEndian.AppendUint64(record, uint64(trade.Size))
+		record = append(record, aggressor)
+	}
+	binary.LittleEndian.PutUint32(record[0:4], uint32(payloadSize))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(record[recordHeaderSize:], crcTable))
+	w.recordBuffer = record
+
+	if w.segmentSize+int64(len(record)) > w.maxSegmentSize && w.segmentSize > int64(len(segmentMagic)) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.segmentWriter.Write(record); err != nil {
+		return err
+	}
+	w.segmentSize += int64(len(record))
+	return nil
+}
+
+// Write buffered updates to the current segment, and optionally sync the segment to disk.
+func (w *Writer) Flush() error {
+	if err := w.segmentWriter.Flush(); err != nil {
+		return err
+	}
+	if w.syncOnFlush {
+		return w.segmentFile.Sync()
+	}
+	return nil
+}
+
+// Flush and close the current segment.
+func (w *Writer) Close() error {
+	return w.closeSegment()
+}
+
+// --------------------------------------------------------------------------------
+
+// Reads the updates of a journal in order, across all segments.
+// Not safe for concurrent use.
+type Reader struct {
+	segmentFilePaths []string
+	nextSegment      int
+
+	segmentFile   *os.File
+	segmentReader *bufio.Reader
+	payloadBuffer []byte
+}
+
+// Open the journal in the given directory for reading.
+// Returns ErrorEmptyJournal if there are no segments.
+func NewReader(journalDir string) (*Reader, error) {
+	segmentFilePaths, err := listSegments(journalDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segmentFilePaths) == 0 {
+		return nil, fmt.Errorf("error reading journal %q: %w", journalDir, ErrorEmptyJournal)
+	}
+	return &Reader{
+		segmentFilePaths: segmentFilePaths,
+		payloadBuffer:    make([]byte, 0, payloadFixedSize+64),
+	}, nil
+}
+
+func (r *Reader) openNextSegment() error {
+	if r.segmentFile != nil {
+		r.segmentFile.Close()
+		r.segmentFile = nil
+	}
+	if r.nextSegment >= len(r.segmentFilePaths) {
+		return io.EOF
+	}
+
+	segmentFilePath := r.segmentFilePaths[r.nextSegment]
+	r.nextSegment++
+	segmentFile, err := os.Open(segmentFilePath)
+	if err != nil {
+		return err
+	}
+	r.segmentFile = segmentFile
+	r.segmentReader = bufio.NewReader(segmentFile)
+
+	magic := make([]byte, len(segmentMagic))
+	if _, err := io.ReadFull(r.segmentReader, magic); err != nil {
+		return fmt.Errorf("error reading journal segment %q: %w", segmentFilePath, ErrorNotJournalSegment)
+	}
+	if string(magic) != segmentMagic {
+		return fmt.Errorf("error reading journal segment %q: %w", segmentFilePath, ErrorNotJournalSegment)
+	}
+	return nil
+}
+
+// Read the next update of the journal.
+// Returns io.EOF once every segment has been read, and ErrorCorruptRecord if a record fails its checksum.
+//
+// A record cut short at the end of a segment (e.g. from a crash while writing) is treated as the end of that segment.
+func (r *Reader) Next() (ticker.TickerUpdate, error) {
+	for {
+		if r.segmentFile == nil {
+			if err := r.openNextSegment(); err != nil {
+				return ticker.TickerUpdate{}, err
+			}
+		}
+
+		update, err := r.readRecord()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			if err := r.openNextSegment(); err != nil {
+				return ticker.TickerUpdate{}, err
+			}
+			continue
+		}
+		return update, err
+	}
+}
+
+func (r *Reader) readRecord() (ticker.TickerUpdate, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r.segmentReader, header[:]); err != nil {
+		return ticker.TickerUpdate{}, err
+	}
+	payloadSize := binary.LittleEndian.Uint32(header[0:4])
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	if payloadSize < payloadFixedSize || payloadSize > maxRecordPayloadSize {
+		return ticker.TickerUpdate{}, ErrorCorruptRecord
+	}
+
+	payload := slices.Grow(r.payloadBuffer[:0], int(payloadSize))[:payloadSize]
+	r.payloadBuffer = payload
+	if _, err := io.ReadFull(r.segmentReader, payload); err != nil {
+		return ticker.TickerUpdate{}, err
+	}
+	if crc32.Checksum(payload, crcTable) != checksum {
+		return ticker.TickerUpdate{}, ErrorCorruptRecord
+	}
+
+	return decodePayload(payload)
+}
+
+// Decode the payload of a record.
+func decodePayload(payload []byte) (ticker.TickerUpdate, error) {
+	if len(payload) < payloadFixedSize {
+		return ticker.TickerUpdate{}, ErrorCorruptRecord
+	}
+	quoteFlags := payload[48]
+	numTrades := int(binary.LittleEndian.Uint16(payload[49:51]))
+	nameLength := int(binary.LittleEndian.Uint16(payload[51:53]))
+	if payloadFixedSize+nameLength+numTrades*tradeSize != len(payload) {
+		return ticker.TickerUpdate{}, ErrorCorruptRecord
+	}
+
+	update := ticker.TickerUpdate{
+		Name:      string(payload[payloadFixedSize : payloadFixedSize+nameLength]),
+		Timestamp: time.Unix(0, int64(binary.LittleEndian.Uint64(payload[0:8]))),
+		Value:     math.Float64frombits(binary.LittleEndian.Uint64(payload[8:16])),
+		Quote: ticker.Quote{
+			Bid:     math.Float64frombits(binary.LittleEndian.Uint64(payload[16:24])),
+			Ask:     math.Float64frombits(binary.LittleEndian.Uint64(payload[24:32])),
+			BidSize: int64(binary.LittleEndian.Uint64(payload[32:40])),
+			AskSize: int64(binary.LittleEndian.Uint64(payload[40:48])),
+			HasBid:  quoteFlags&quoteFlagHasBid != 0,
+			HasAsk:  quoteFlags&quoteFlagHasAsk != 0,
+		},
+	}
+	if numTrades > 0 {
+		update.Trades = make([]ticker.Trade, numTrades)
+	}
+	tradesPayload := payload[payloadFixedSize+nameLength:]
+	for i := range update.Trades {
+		tradePayload := tradesPayload[i*tradeSize : (i+1)*tradeSize]
+		aggressor := ticker.SideBuy
+		if tradePayload[24] == aggressorSell {
+			aggressor = ticker.SideSell
+		}
+		update.Trades[i] = ticker.Trade{
+			Timestamp: time.Unix(0, int64(binary.LittleEndian.Uint64(tradePayload[0:8]))),
+			Price:     math.Float64frombits(binary.LittleEndian.Uint64(tradePayload[8:16])),
+			Size:      int64(binary.LittleEndian.Uint64(tradePayload[16:24])),
+			Aggressor: aggressor,
+		}
+	}
+	return update, nil
+}
+
+func (r *Reader) Close() error {
+	if r.segmentFile == nil {
+		return nil
+	}
+	return r.segmentFile.Close()
+}
@@ -0,0 +1,298 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// Updates covering every field of a record: one and two-sided quotes, trades of each aggressor, and no trades.
+func testUpdates() []ticker.TickerUpdate {
+	start := time.Unix(1_700_000_000, 0)
+	return []ticker.TickerUpdate{
+		{
+			Name:      "ticker01",
+			Timestamp: start,
+			Value:     100.25,
+			Quote:     ticker.Quote{Bid: 100.2, Ask: 100.3, BidSize: 500, AskSize: 700, HasBid: true, HasAsk: true},
+		},
+		{
+			Name:      "ticker02",
+			Timestamp: start.Add(10 * time.Millisecond),
+			Value:     42,
+			Quote:     ticker.Quote{Bid: 41.9, BidSize: 100, HasBid: true},
+			Trades: []ticker.Trade{
+				{Timestamp: start.Add(5 * time.Millisecond), Price: 41.9, Size: 10, Aggressor: ticker.SideSell},
+				{Timestamp: start.Add(10 * time.Millisecond), Price: 42.1, Size: 25, Aggressor: ticker.SideBuy},
+			},
+		},
+		{
+			Name:      "ticker01",
+			Timestamp: start.Add(20 * time.Millisecond),
+			Value:     math.SmallestNonzeroFloat64,
+			Quote:     ticker.Quote{Ask: 0.01, AskSize: 1, HasAsk: true},
+		},
+		{
+			Name:      "",
+			Timestamp: start.Add(30 * time.Millisecond),
+			Value:     0,
+		},
+	}
+}
+
+func checkUpdatesEqual(t *testing.T, got []ticker.TickerUpdate, want []ticker.TickerUpdate) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d updates, expected %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || !got[i].Timestamp.Equal(want[i].Timestamp) || got[i].Value != want[i].Value || got[i].Quote != want[i].Quote {
+			t.Errorf("update %d is %+v, expected %+v", i, got[i], want[i])
+		}
+		if !slices.EqualFunc(got[i].Trades, want[i].Trades, func(a, b ticker.Trade) bool {
+			return a.Timestamp.Equal(b.Timestamp) && a.Price == b.Price && a.Size == b.Size && a.Aggressor == b.Aggressor
+		}) {
+			t.Errorf("update %d has trades %+v, expected %+v", i, got[i].Trades, want[i].Trades)
+		}
+	}
+}
+
+func writeJournal(t *testing.T, journalDir string, maxSegmentSize int64, updates []ticker.TickerUpdate) {
+	t.Helper()
+
+	w, err := NewWriter(journalDir, maxSegmentSize, false)
+	if err != nil {
+		t.Fatalf("error opening journal writer: %v", err)
+	}
+	for _, update := range updates {
+		if err := w.Append(update); err != nil {
+			t.Fatalf("error appending update: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing journal writer: %v", err)
+	}
+}
+
+// Read every update of the journal, returning the updates read before the first error (other than io.EOF).
+func readJournal(t *testing.T, journalDir string) ([]ticker.TickerUpdate, error) {
+	t.Helper()
+
+	r, err := NewReader(journalDir)
+	if err != nil {
+		t.Fatalf("error opening journal reader: %v", err)
+	}
+	defer r.Close()
+
+	var updates []ticker.TickerUpdate
+	for {
+		update, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return updates, nil
+		} else if err != nil {
+			return updates, err
+		}
+		updates = append(updates, update)
+	}
+}
+
+// The size of the record of the given update, including its header.
+func recordSize(update ticker.TickerUpdate) int64 {
+	return int64(recordHeaderSize + payloadFixedSize + len(update.Name) + len(update.Trades)*tradeSize)
+}
+
+func TestJournalRoundTrip(t *testing.T) {
+	journalDir := t.TempDir()
+	want := testUpdates()
+	writeJournal(t, journalDir, 1<<20, want)
+
+	got, err := readJournal(t, journalDir)
+	if err != nil {
+		t.Fatalf("error reading journal: %v", err)
+	}
+	checkUpdatesEqual(t, got, want)
+}
+
+func TestJournalWriterStartsNewSegment(t *testing.T) {
+	journalDir := t.TempDir()
+	updates := testUpdates()
+	writeJournal(t, journalDir, 1<<20, updates[:2])
+	writeJournal(t, journalDir, 1<<20, updates[2:])
+
+	segmentFilePaths, err := listSegments(journalDir)
+	if err != nil {
+		t.Fatalf("error listing segments: %v", err)
+	}
+	if len(segmentFilePaths) != 2 {
+		t.Fatalf("got %d segments, expected 2", len(segmentFilePaths))
+	}
+	got, err := readJournal(t, journalDir)
+	if err != nil {
+		t.Fatalf("error reading journal: %v", err)
+	}
+	checkUpdatesEqual(t, got, updates)
+}
+
+func TestJournalRotation(t *testing.T) {
+	update := ticker.TickerUpdate{Name: "ticker01", Value: 100}
+	large := ticker.TickerUpdate{Name: "ticker02", Value: 200, Trades: make([]ticker.Trade, 8)}
+	for i := range large.Trades {
+		large.Trades[i] = ticker.Trade{Timestamp: time.Unix(0, int64(i)), Price: 200, Size: int64(i + 1), Aggressor: ticker.SideBuy}
+	}
+	magicSize := int64(len(segmentMagic))
+
+	tests := []struct {
+		name           string
+		maxSegmentSize int64
+		updates        []ticker.TickerUpdate
+		// The number of records written to each segment.
+		wantRecords []int
+	}{
+		{
+			name:           "exactly full",
+			maxSegmentSize: magicSize + 2*recordSize(update),
+			updates:        []ticker.TickerUpdate{update, update, update, update, update},
+			wantRecords:    []int{2, 2, 1},
+		},
+		{
+			name:           "one byte short",
+			maxSegmentSize: magicSize + 2*recordSize(update) - 1,
+			updates:        []ticker.TickerUpdate{update, update, update},
+			wantRecords:    []int{1, 1, 1},
+		},
+		{
+			name:           "record larger than the limit",
+			maxSegmentSize: magicSize + 2*recordSize(update),
+			updates:        []ticker.TickerUpdate{update, large, update, update},
+			wantRecords:    []int{1, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			journalDir := t.TempDir()
+			for i := range tt.updates {
+				tt.updates[i].Timestamp = time.Unix(0, int64(i))
+			}
+			writeJournal(t, journalDir, tt.maxSegmentSize, tt.updates)
+
+			segmentFilePaths, err := listSegments(journalDir)
+			if err != nil {
+				t.Fatalf("error listing segments: %v", err)
+			}
+			if len(segmentFilePaths) != len(tt.wantRecords) {
+				t.Fatalf("got %d segments, expected %d", len(segmentFilePaths), len(tt.wantRecords))
+			}
+			written := 0
+			for i, segmentFilePath := range segmentFilePaths {
+				if want := fmt.Sprintf(segmentFileFormat, i+1); filepath.Base(segmentFilePath) != want {
+					t.Errorf("segment %d is named %q, expected %q", i, filepath.Base(segmentFilePath), want)
+				}
+				info, err := os.Stat(segmentFilePath)
+				if err != nil {
+					t.Fatalf("error reading segment size: %v", err)
+				}
+				wantSize := magicSize
+				for _, update := range tt.updates[written : written+tt.wantRecords[i]] {
+					wantSize += recordSize(update)
+				}
+				written += tt.wantRecords[i]
+				if info.Size() != wantSize {
+					t.Errorf("segment %d is %d bytes, expected %d", i, info.Size(), wantSize)
+				}
+			}
+
+			got, err := readJournal(t, journalDir)
+			if err != nil {
+				t.Fatalf("error reading journal: %v", err)
+			}
+			checkUpdatesEqual(t, got, tt.updates)
+		})
+	}
+}
+
+func TestJournalReaderCorruptRecord(t *testing.T) {
+	journalDir := t.TempDir()
+	updates := testUpdates()
+	writeJournal(t, journalDir, 1<<20, updates)
+
+	// Flip a bit in the value of the second record.
+	segmentFilePath := filepath.Join(journalDir, fmt.Sprintf(segmentFileFormat, 1))
+	segment, err := os.ReadFile(segmentFilePath)
+	if err != nil {
+		t.Fatalf("error reading segment: %v", err)
+	}
+	secondRecordOffset := int64(len(segmentMagic)) + recordSize(updates[0])
+	segment[secondRecordOffset+recordHeaderSize+8] ^= 1
+	if err := os.WriteFile(segmentFilePath, segment, 0644); err != nil {
+		t.Fatalf("error writing segment: %v", err)
+	}
+
+	got, err := readJournal(t, journalDir)
+	if !errors.Is(err, ErrorCorruptRecord) {
+		t.Fatalf("error reading journal is %v, expected %v", err, ErrorCorruptRecord)
+	}
+	checkUpdatesEqual(t, got, updates[:1])
+}
+
+func TestJournalReaderTruncatedRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		// The number of bytes cut from the end of the first segment.
+		truncate int64
+	}{
+		{name: "truncated header", truncate: recordSize(testUpdates()[3]) - 4},
+		{name: "truncated payload", truncate: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			journalDir := t.TempDir()
+			updates := testUpdates()
+			writeJournal(t, journalDir, 1<<20, updates)
+
+			segmentFilePath := filepath.Join(journalDir, fmt.Sprintf(segmentFileFormat, 1))
+			info, err := os.Stat(segmentFilePath)
+			if err != nil {
+				t.Fatalf("error reading segment size: %v", err)
+			}
+			if err := os.Truncate(segmentFilePath, info.Size()-tt.truncate); err != nil {
+				t.Fatalf("error truncating segment: %v", err)
+			}
+
+			// The truncated record ends the first segment, and reading continues with the next segment.
+			next := ticker.TickerUpdate{Name: "ticker03", Timestamp: time.Unix(1_800_000_000, 0), Value: 7}
+			writeJournal(t, journalDir, 1<<20, []ticker.TickerUpdate{next})
+
+			got, err := readJournal(t, journalDir)
+			if err != nil {
+				t.Fatalf("error reading journal: %v", err)
+			}
+			checkUpdatesEqual(t, got, append(updates[:3:3], next))
+		})
+	}
+}
+
+func TestJournalReaderNotJournalSegment(t *testing.T) {
+	journalDir := t.TempDir()
+	segmentFilePath := filepath.Join(journalDir, fmt.Sprintf(segmentFileFormat, 1))
+	if err := os.WriteFile(segmentFilePath, []byte("GENRONJ1"), 0644); err != nil {
+		t.Fatalf("error writing segment: %v", err)
+	}
+
+	_, err := readJournal(t, journalDir)
+	if !errors.Is(err, ErrorNotJournalSegment) {
+		t.Fatalf("error reading journal is %v, expected %v", err, ErrorNotJournalSegment)
+	}
+
+	if _, err := NewReader(t.TempDir()); !errors.Is(err, ErrorEmptyJournal) {
+		t.Fatalf("error opening empty journal is %v, expected %v", err, ErrorEmptyJournal)
+	}
+}
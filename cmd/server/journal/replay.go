@@ -0,0 +1,124 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// Scan the journal in the given directory, and create a replay ticker for every ticker name found.
+// The update period of each ticker is estimated as the time between its first two updates.
+// Each ticker starts at the value of its first update, so the tickers are valid before replay begins.
+func LoadReplayTickers(journalDir string) (map[string]*ticker.ReplayTicker, error) {
+	reader, err := NewReader(journalDir)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	firstUpdates := make(map[string]ticker.TickerUpdate)
+	updatePeriods := make(map[string]time.Duration)
+	for {
+		update, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		firstUpdate, ok := firstUpdates[update.Name]
+		if !ok {
+			firstUpdates[update.Name] = update
+		} else if _, ok := updatePeriods[update.Name]; !ok {
+			updatePeriods[update.Name] = update.Timestamp.Sub(firstUpdate.Timestamp)
+		}
+	}
+
+	replayTickers := make(map[string]*ticker.ReplayTicker, len(firstUpdates))
+	for tickerName, firstUpdate := range firstUpdates {
+		replayTicker := ticker.NewReplayTicker(tickerName, updatePeriods[tickerName])
+		replayTicker.Replay(firstUpdate)
+		replayTickers[tickerName] = replayTicker
+	}
+	return replayTickers, nil
+}
+
+// Replay the journal in the given directory through the replay tickers, publishing every update to the broadcaster
+// just as StartTicker would. Updates are replayed with their original spacing, scaled by the replay speed.
+// This function blocks until the journal is finished (never, if looping) or the context is cancelled,
+// so call inside a goroutine.
+//
+// Unless original timestamps are requested, replayed updates are timestamped with the time they are replayed,
+// so clients see the same values as the recorded session, but with fresh timestamps.
+// The recorded trades of each update are shifted by the same offset, keeping their spacing from the update.
+func Replay(ctx context.Context, replayConfig config.ReplayConfig, replayTickers map[string]*ticker.ReplayTicker, updates *ticker.UpdateBroadcaster) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		if err := replayOnce(ctx, replayConfig, replayTickers, updates, timer); err != nil {
+			return err
+		}
+		if !replayConfig.Loop || ctx.Err() != nil {
+			slog.Info("journal replay finished", "journalDir", replayConfig.Dir)
+			return nil
+		}
+		slog.Debug("journal replay looping", "journalDir", replayConfig.Dir)
+	}
+}
+
+func replayOnce(ctx context.Context, replayConfig config.ReplayConfig, replayTickers map[string]*ticker.ReplayTicker, updates *ticker.UpdateBroadcaster, timer *time.Timer) error {
+	reader, err := NewReader(replayConfig.Dir)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	replayStartTime := time.Now()
+	var journalStartTimestamp time.Time
+	for {
+		update, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if journalStartTimestamp.IsZero() {
+			journalStartTimestamp = update.Timestamp
+		}
+
+		replayTimestamp := time.Now()
+		if replayConfig.Speed > 0 {
+			offset := time.Duration(float64(update.Timestamp.Sub(journalStartTimestamp)) / replayConfig.Speed)
+			replayTimestamp = replayStartTime.Add(offset)
+			timer.Reset(time.Until(replayTimestamp))
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			return nil
+		}
+		if replayConfig.OriginalTimestamps {
+			replayTimestamp = update.Timestamp
+		}
+
+		replayTicker, ok := replayTickers[update.Name]
+		if !ok {
+			continue
+		}
+		offset := replayTimestamp.Sub(update.Timestamp)
+		update.Timestamp = replayTimestamp
+		for i := range update.Trades {
+			update.Trades[i].Timestamp = update.Trades[i].Timestamp.Add(offset)
+		}
+		replayTicker.Replay(update)
+		updates.Publish(update)
+	}
+}
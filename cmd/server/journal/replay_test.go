@@ -0,0 +1,144 @@
+package journal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// Replay the journal in the given directory as fast as possible, returning every published update.
+func replayJournal(t *testing.T, replayConfig config.ReplayConfig) ([]ticker.TickerUpdate, map[string]*ticker.ReplayTicker) {
+	t.Helper()
+
+	replayTickers, err := LoadReplayTickers(replayConfig.Dir)
+	if err != nil {
+		t.Fatalf("error loading replay tickers: %v", err)
+	}
+	updates := ticker.NewUpdateBroadcaster()
+	subscription := updates.Subscribe(64)
+	if err := Replay(context.Background(), replayConfig, replayTickers, updates); err != nil {
+		t.Fatalf("error replaying journal: %v", err)
+	}
+	updates.Close()
+
+	var replayed []ticker.TickerUpdate
+	for update := range subscription.C {
+		replayed = append(replayed, update)
+	}
+	return replayed, replayTickers
+}
+
+func TestLoadReplayTickers(t *testing.T) {
+	journalDir := t.TempDir()
+	recorded := testUpdates()
+	writeJournal(t, journalDir, 1<<20, recorded)
+
+	replayTickers, err := LoadReplayTickers(journalDir)
+	if err != nil {
+		t.Fatalf("error loading replay tickers: %v", err)
+	}
+	if len(replayTickers) != 3 {
+		t.Fatalf("got %d replay tickers, expected 3", len(replayTickers))
+	}
+
+	// Each ticker starts at its first update, with the update period between its first two updates.
+	info := replayTickers["ticker01"].GetSnapshot()
+	if info.Value != recorded[0].Value || info.Quote != recorded[0].Quote || !info.LastUpdatedTimestamp.Equal(recorded[0].Timestamp) {
+		t.Errorf("ticker01 starts at %+v, expected the first update %+v", info, recorded[0])
+	}
+	if want := recorded[2].Timestamp.Sub(recorded[0].Timestamp); info.UpdatePeriod != want {
+		t.Errorf("ticker01 has update period %v, expected %v", info.UpdatePeriod, want)
+	}
+	if info := replayTickers["ticker02"].GetSnapshot(); info.UpdatePeriod != 0 {
+		t.Errorf("ticker02 has update period %v, expected 0 with a single update", info.UpdatePeriod)
+	}
+}
+
+func TestReplayOriginalTimestamps(t *testing.T) {
+	journalDir := t.TempDir()
+	recorded := testUpdates()
+	writeJournal(t, journalDir, 1<<20, recorded)
+
+	replayed, replayTickers := replayJournal(t, config.ReplayConfig{Dir: journalDir, Speed: 0, OriginalTimestamps: true})
+	checkUpdatesEqual(t, replayed, recorded)
+
+	// Each ticker is left at its last recorded update.
+	info := replayTickers["ticker01"].GetSnapshot()
+	if info.Value != recorded[2].Value || info.Quote != recorded[2].Quote || !info.LastUpdatedTimestamp.Equal(recorded[2].Timestamp) {
+		t.Errorf("ticker01 ends at %+v, expected the last update %+v", info, recorded[2])
+	}
+}
+
+func TestReplayTimestamps(t *testing.T) {
+	journalDir := t.TempDir()
+	recorded := testUpdates()
+	writeJournal(t, journalDir, 1<<20, recorded)
+
+	replayStart := time.Now()
+	replayed, _ := replayJournal(t, config.ReplayConfig{Dir: journalDir, Speed: 0})
+	if len(replayed) != len(recorded) {
+		t.Fatalf("got %d updates, expected %d", len(replayed), len(recorded))
+	}
+
+	// Updates are timestamped with the time they are replayed, and trades keep their spacing from their update.
+	for i, update := range replayed {
+		if update.Timestamp.Before(replayStart) {
+			t.Errorf("update %d is timestamped %v, before the replay started at %v", i, update.Timestamp, replayStart)
+		}
+		want := recorded[i]
+		if update.Name != want.Name || update.Value != want.Value || update.Quote != want.Quote {
+			t.Errorf("update %d is %+v, expected %+v", i, update, want)
+		}
+		if len(update.Trades) != len(want.Trades) {
+			t.Fatalf("update %d has %d trades, expected %d", i, len(update.Trades), len(want.Trades))
+		}
+		for j, trade := range update.Trades {
+			if got, want := update.Timestamp.Sub(trade.Timestamp), want.Timestamp.Sub(want.Trades[j].Timestamp); got != want {
+				t.Errorf("trade %d of update %d is %v before its update, expected %v", j, i, got, want)
+			}
+		}
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	journalDir := t.TempDir()
+	recorded := testUpdates()
+	writeJournal(t, journalDir, 1<<20, recorded)
+
+	// The recorded updates span 30ms, so at half speed the replay takes at least 60ms.
+	start := time.Now()
+	replayed, _ := replayJournal(t, config.ReplayConfig{Dir: journalDir, Speed: 0.5, OriginalTimestamps: true})
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("replay took %v, expected at least %v", elapsed, 60*time.Millisecond)
+	}
+	checkUpdatesEqual(t, replayed, recorded)
+}
+
+func TestReplayCancelled(t *testing.T) {
+	journalDir := t.TempDir()
+	writeJournal(t, journalDir, 1<<20, testUpdates())
+
+	replayTickers, err := LoadReplayTickers(journalDir)
+	if err != nil {
+		t.Fatalf("error loading replay tickers: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A looping replay only returns once cancelled.
+	done := make(chan error, 1)
+	go func() {
+		done <- Replay(ctx, config.ReplayConfig{Dir: journalDir, Speed: 0, Loop: true}, replayTickers, ticker.NewUpdateBroadcaster())
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("error replaying journal: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("replay did not return after the context was cancelled")
+	}
+}
@@ -1,58 +1,418 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"github.com/hmcalister/genron/cmd/server/accounts"
+	"github.com/hmcalister/genron/cmd/server/calendar"
 	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/fix"
+	"github.com/hmcalister/genron/cmd/server/history"
+	"github.com/hmcalister/genron/cmd/server/journal"
+	"github.com/hmcalister/genron/cmd/server/multicast"
+	"github.com/hmcalister/genron/cmd/server/orders"
 	"github.com/hmcalister/genron/cmd/server/servers"
+	"github.com/hmcalister/genron/cmd/server/sinks"
 	"github.com/hmcalister/genron/cmd/server/ticker"
+	healthv1 "github.com/hmcalister/genron/gen/api/grpc/health/v1"
+	"github.com/hmcalister/genron/gen/api/grpc/health/v1/healthv1connect"
 	"github.com/hmcalister/genron/gen/api/ticker/v1/tickerv1connect"
-	"golang.org/x/net/http2"
-	"golang.org/x/net/http2/h2c"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
 	configFilePath := flag.String("configFilePath", "config.yaml", "Set the file path to the config file. Accepts JSON, YAML, TOML, and envfiles. See README for config specifications.")
+	listTickerTypes := flag.Bool("listTickerTypes", false, "Print the available ticker types and their parameters, then exit.")
+	exportOpenAPI := flag.String("exportOpenAPI", "", "Write the OpenAPI document of the REST gateway to the given file path (or \"-\" for stdout), then exit.")
+	exportConfigSchema := flag.String("exportConfigSchema", "", "Write the JSON Schema of the config file to the given file path (or \"-\" for stdout), then exit.")
 	flag.Parse()
 
-	config.LoadConfig(*configFilePath)
+	if *listTickerTypes {
+		if err := ticker.PrintTickerTypes(os.Stdout); err != nil {
+			panic(err)
+		}
+		return
+	}
+	if *exportConfigSchema != "" {
+		if err := writeConfigSchema(*exportConfigSchema); err != nil {
+			panic(err)
+		}
+		return
+	}
+	if *exportOpenAPI != "" {
+		if err := writeJSONFile(*exportOpenAPI, servers.OpenAPIDocument()); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	serverConfig := config.LoadConfig(*configFilePath)
 	logFilePointer := config.ConfigureLogger()
 	if logFilePointer != nil {
 		defer logFilePointer.Close()
 	}
 	slog.Debug("logger configured")
 
-	tickers := ticker.ParseTickers()
+	// Cancelled on the first SIGINT or SIGTERM, which begins the graceful shutdown.
+	// A second signal restores the default behavior, and kills the process immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// In replay mode the tickers come from the journal, rather than the tickers config.
+	replayMode := serverConfig.Replay.Dir != ""
+	var tickers map[string]ticker.Ticker
+	var replayTickers map[string]*ticker.ReplayTicker
+	if replayMode {
+		var err error
+		replayTickers, err = journal.LoadReplayTickers(serverConfig.Replay.Dir)
+		if err != nil {
+			slog.Error("error when loading journal for replay", "journalDir", serverConfig.Replay.Dir, "err", err)
+			panic(err)
+		}
+		tickers = make(map[string]ticker.Ticker, len(replayTickers))
+		for n, t := range replayTickers {
+			tickers[n] = t
+		}
+		slog.Info("replay mode, serving tickers from journal", "journalDir", serverConfig.Replay.Dir, "numTickers", len(tickers))
+	} else {
+		// Tickers are assigned to calendars by name, so calendars must be parsed first.
+		if err := calendar.ParseCalendars(); err != nil {
+			slog.Error("error when parsing calendars", "err", err)
+			panic(err)
+		}
+		tickers = ticker.ParseTickers()
+	}
 	slog.Debug("parsed tickers", "tickers", tickers)
 
-	for n, t := range tickers {
-		go func() {
-			slog.Debug("starting ticker", "tickerName", n)
-			ticker.StartTicker(t)
-		}()
+	snapshotFilePath := serverConfig.Snapshot.File
+	if snapshotFilePath != "" && serverConfig.Snapshot.RestoreOnStart && replayMode {
+		slog.Info("replay mode, not restoring snapshot", "snapshotFilePath", snapshotFilePath)
+	} else if snapshotFilePath != "" && serverConfig.Snapshot.RestoreOnStart && serverConfig.Orders.Enabled {
+		slog.Error("error when restoring snapshot", "snapshotFilePath", snapshotFilePath, "err", servers.ErrorSnapshotOrdersEnabled)
+		panic(servers.ErrorSnapshotOrdersEnabled)
+	} else if snapshotFilePath != "" && serverConfig.Snapshot.RestoreOnStart {
+		snapshot, err := ticker.LoadSnapshot(snapshotFilePath)
+		if errors.Is(err, os.ErrNotExist) {
+			slog.Info("no snapshot to restore, starting from config", "snapshotFilePath", snapshotFilePath)
+		} else if err != nil {
+			slog.Error("error when loading snapshot", "snapshotFilePath", snapshotFilePath, "err", err)
+			panic(err)
+		} else if _, err := ticker.RestoreSnapshot(snapshot, tickers); err != nil {
+			slog.Error("error when restoring snapshot", "snapshotFilePath", snapshotFilePath, "err", err)
+			panic(err)
+		}
+	}
+
+	// Every ticker update is published to the broadcaster, and fanned out from there to the sinks, and to every
+	// other consumer of updates below (e.g. the order engine, the browser feeds, and FIX sessions).
+	// Sinks must be subscribed before the tickers start, so that no updates are missed.
+	updates := ticker.NewUpdateBroadcaster()
+	sinkDispatcher := sinks.NewDispatcher(updates)
+	if err := sinks.ParseSinks(ctx, sinkDispatcher); err != nil {
+		slog.Error("error when starting sinks", "err", err)
+		panic(err)
+	}
+
+	tickerHistory := history.NewStore(serverConfig.History)
+	tickerHistory.Start(updates)
+
+	var multicastPublisher *multicast.Publisher
+	if serverConfig.Multicast.Enabled {
+		multicastTickerNames := make([]string, 0, len(tickers))
+		for n := range tickers {
+			multicastTickerNames = append(multicastTickerNames, n)
+		}
+		var err error
+		multicastPublisher, err = multicast.NewPublisher(serverConfig.Multicast, multicastTickerNames)
+		if err != nil {
+			slog.Error("error when starting multicast publisher", "err", err)
+			panic(err)
+		}
+		multicastPublisher.Start(updates)
+	}
+
+	// Simulated orders are filled against every update.
+	// The accounts of the clients are booked from the executions of the engine, so must be started before any order is submitted.
+	var orderEngine *orders.Engine
+	var accountBook *accounts.Book
+	if serverConfig.Orders.Enabled {
+		orderEngine = orders.NewEngine(serverConfig.Orders, tickers)
+		orderEngine.Start(updates)
+		accountBook = accounts.NewBook(serverConfig.Accounts, tickers)
+		accountBook.Start(orderEngine)
+	}
+
+	var tickerWaitGroup sync.WaitGroup
+	if replayMode {
+		tickerWaitGroup.Go(func() {
+			if err := journal.Replay(ctx, serverConfig.Replay, replayTickers, updates); err != nil {
+				slog.Error("error during journal replay", "journalDir", serverConfig.Replay.Dir, "err", err)
+			}
+		})
+	} else {
+		for n, t := range tickers {
+			tickerWaitGroup.Go(func() {
+				slog.Debug("starting ticker", "tickerName", n)
+				ticker.StartTicker(ctx, t, updates)
+			})
+		}
 	}
 
 	// --------------------------------------------------------------------------------
 	mux := http.NewServeMux()
+	interceptors, err := servers.NewInterceptors(serverConfig)
+	if err != nil {
+		slog.Error("error when creating interceptors", "err", err)
+		panic(err)
+	}
+	handlerOptions := connect.WithInterceptors(interceptors...)
+
+	tickerInfoServer := servers.NewTickerInfoServer(tickers, updates, serverConfig.Stream)
+	tickerNames := tickerInfoServer.TickerNames
+	tickerInfoServerPath, tickerInfoServerHandler := tickerv1connect.NewTickerInfoServiceHandler(tickerInfoServer, handlerOptions)
+	mux.Handle(tickerInfoServerPath, tickerInfoServerHandler)
 
-	tickerNames := make([]string, 0, len(tickers))
-	for k := range tickers {
-		tickerNames = append(tickerNames, k)
+	snapshotServer := &servers.SnapshotServer{
+		Tickers:          tickers,
+		SnapshotFilePath: snapshotFilePath,
+		OrdersEnabled:    serverConfig.Orders.Enabled,
 	}
-	tickerInfoServer := &servers.TickerInfoServer{
-		Tickers:     tickers,
-		TickerNames: tickerNames,
+	snapshotServerPath, snapshotServerHandler := tickerv1connect.NewSnapshotServiceHandler(snapshotServer, handlerOptions)
+	mux.Handle(snapshotServerPath, snapshotServerHandler)
+
+	orderBookServer := servers.NewOrderBookServer(tickers, updates, serverConfig.Stream)
+	orderBookServerPath, orderBookServerHandler := tickerv1connect.NewOrderBookServiceHandler(orderBookServer, handlerOptions)
+	mux.Handle(orderBookServerPath, orderBookServerHandler)
+
+	orderServer := servers.NewOrderServer(orderEngine, serverConfig.Stream)
+	orderServerPath, orderServerHandler := tickerv1connect.NewOrderServiceHandler(orderServer, handlerOptions)
+	mux.Handle(orderServerPath, orderServerHandler)
+
+	accountServer := servers.NewAccountServer(accountBook, serverConfig.Accounts)
+	accountServerPath, accountServerHandler := tickerv1connect.NewAccountServiceHandler(accountServer, handlerOptions)
+	mux.Handle(accountServerPath, accountServerHandler)
+
+	// The standard gRPC health checking service reports NOT_SERVING until the server is started below,
+	// and again once shutdown begins, so probes and load balancers stop sending requests before connections are drained.
+	// The order and account services only serve while simulated orders are enabled, so are only reported then.
+	healthServiceNames := []string{tickerv1connect.TickerInfoServiceName, tickerv1connect.SnapshotServiceName, tickerv1connect.OrderBookServiceName}
+	if orderEngine != nil {
+		healthServiceNames = append(healthServiceNames, tickerv1connect.OrderServiceName, tickerv1connect.AccountServiceName)
 	}
-	tickerInfoServerPath, tickerInfoServerHandler := tickerv1connect.NewTickerInfoServiceHandler(tickerInfoServer)
-	mux.Handle(tickerInfoServerPath, tickerInfoServerHandler)
+	healthServer := servers.NewHealthServer(healthServiceNames...)
+	healthServerPath, healthServerHandler := healthv1connect.NewHealthHandler(healthServer, handlerOptions)
+	mux.Handle(healthServerPath, healthServerHandler)
+
+	// gRPC server reflection, so grpcurl and grpcui can discover the services without a copy of the proto files.
+	reflector := grpcreflect.NewStaticReflector(
+		tickerv1connect.TickerInfoServiceName,
+		tickerv1connect.SnapshotServiceName,
+		tickerv1connect.OrderBookServiceName,
+		tickerv1connect.OrderServiceName,
+		tickerv1connect.AccountServiceName,
+		healthv1connect.HealthName,
+	)
+	mux.Handle(grpcreflect.NewHandlerV1(reflector))
+	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+
+	// The recent history of every ticker is kept in memory for the REST gateway.
+	// Like the sinks, the history must subscribe before the tickers start, so it is started further above.
+	restGateway := &servers.RESTGateway{
+		Tickers:      tickers,
+		TickerNames:  tickerNames,
+		History:      tickerHistory,
+		Interceptors: interceptors,
+	}
+	restGateway.Register(mux)
+
+	// Push ticker updates to browsers over WebSockets and Server-Sent Events.
+	feedServer := servers.NewFeedServer(tickers, updates, serverConfig.Feed, interceptors)
+	feedServer.Register(mux)
+
+	// Serve market data over FIX, on its own port.
+	fixAcceptorStopped := make(chan struct{})
+	if serverConfig.FIX.Enabled {
+		fixAcceptor := fix.NewAcceptor(serverConfig.FIX, tickers, updates)
+		if err := fixAcceptor.Listen(); err != nil {
+			slog.Error("error when listening for fix sessions", "port", serverConfig.FIX.Port, "err", err)
+			panic(err)
+		}
+		go func() {
+			fixAcceptor.Serve(ctx)
+			close(fixAcceptorStopped)
+		}()
+	} else {
+		close(fixAcceptorStopped)
+	}
+
+	// Serve Prometheus metrics from the default registry, which the ticker and servers packages register their metrics with.
+	prometheus.MustRegister(ticker.NewTickerCollector(tickers))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	tlsConfig, err := servers.NewTLSConfig(serverConfig.TLS)
+	if err != nil {
+		slog.Error("error when configuring tls", "err", err)
+		panic(err)
+	}
+
+	// Without TLS, serve HTTP/2 without TLS (h2c) natively, rather than through the h2c handler wrapper.
+	// The h2c handler hijacks connections away from the http.Server, so these could not be drained by Shutdown.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if tlsConfig != nil {
+		protocols.SetHTTP2(true)
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	httpServer := &http.Server{
+		Addr:      fmt.Sprintf("localhost:%d", serverConfig.Port),
+		Handler:   mux,
+		Protocols: protocols,
+		TLSConfig: tlsConfig,
+	}
+
+	// The tickers have been parsed and started above, so the server is ready as soon as it is listening.
+	healthServer.SetAllServingStatus(healthv1.HealthCheckResponse_SERVING)
+	serverErrors := make(chan error, 1)
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			// The certificates are already loaded into the TLS config, so no files are given here.
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+		close(serverErrors)
+	}()
 
-	if err := http.ListenAndServe(
-		"localhost:8080",
-		h2c.NewHandler(mux, &http2.Server{}),
-	); err != nil {
+	select {
+	case err := <-serverErrors:
 		slog.Error("error during listen and serve of http mux", "err", err)
 		panic(err)
+	case <-ctx.Done():
+		stop()
+	}
+
+	// --------------------------------------------------------------------------------
+	// Graceful shutdown. Everything below must finish within the shutdown timeout.
+
+	shutdownTimeout := serverConfig.ShutdownTimeout
+	slog.Info("shutdown signal received, shutting down", "shutdownTimeout", shutdownTimeout)
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	// Streams and feed connections are open until the client disconnects, so would otherwise hold up the drain below.
+	// End them all first, after setting every health service NOT_SERVING so that load balancers move traffic away.
+	healthServer.Shutdown()
+	feedServer.Shutdown()
+	tickerInfoServer.Shutdown()
+	orderBookServer.Shutdown()
+	orderServer.Shutdown()
+	accountServer.Shutdown()
+
+	// Stop accepting new requests, and wait for in-flight and streaming requests to finish.
+	// If the timeout elapses first, the remaining connections are forcibly closed.
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("http server did not drain before shutdown timeout, closing remaining connections", "err", err)
+		httpServer.Close()
+	}
+
+	// FIX sessions are logged out once the context is cancelled, wait for them here.
+	select {
+	case <-fixAcceptorStopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("fix sessions did not log out before shutdown timeout")
 	}
+
+	// The ticker loops have already been signalled to stop by the cancelled context, wait for them here.
+	tickersStopped := make(chan struct{})
+	go func() {
+		tickerWaitGroup.Wait()
+		close(tickersStopped)
+	}()
+	select {
+	case <-tickersStopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("tickers did not stop before shutdown timeout")
+	}
+
+	// With no more updates to publish, close the broadcaster so each sink flushes what remains and closes.
+	updates.Close()
+	sinksStopped := make(chan struct{})
+	go func() {
+		sinkDispatcher.Wait()
+		close(sinksStopped)
+	}()
+	select {
+	case <-sinksStopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("sinks did not flush before shutdown timeout")
+	}
+	if multicastPublisher != nil {
+		multicastStopped := make(chan struct{})
+		go func() {
+			multicastPublisher.Wait()
+			close(multicastStopped)
+		}()
+		select {
+		case <-multicastStopped:
+		case <-shutdownCtx.Done():
+			slog.Warn("multicast session did not end before shutdown timeout")
+		}
+	}
+
+	// Only save once the tickers have stopped, so the snapshot is the final state of the simulation.
+	if snapshotFilePath != "" && serverConfig.Snapshot.SaveOnShutdown {
+		if _, err := ticker.SaveSnapshot(snapshotFilePath, tickers); err != nil {
+			slog.Error("error when saving snapshot on shutdown", "snapshotFilePath", snapshotFilePath, "err", err)
+		}
+	}
+
+	slog.Info("shutdown complete")
+}
+
+// Write the JSON Schema of the full config file, including every registered ticker type, to the given file path.
+// A file path of "-" writes to stdout.
+func writeConfigSchema(schemaFilePath string) error {
+	schema := config.Schema(config.ServerConfig{})
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.Title = "GEnron config"
+	schema.Properties["calendars"] = calendar.CalendarsSchema()
+	schema.Properties["tickers"] = ticker.TickersSchema()
+	schema.Properties["sinks"] = sinks.SinksSchema()
+
+	return writeJSONFile(schemaFilePath, schema)
+}
+
+// Write the given value as indented JSON to the given file path. A file path of "-" writes to stdout.
+func writeJSONFile(filePath string, value any) error {
+	file := os.Stdout
+	if filePath != "-" {
+		var err error
+		file, err = os.Create(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
 }
@@ -0,0 +1,253 @@
+// Package multicast publishes ticker updates as fixed-layout binary messages over UDP (usually multicast),
+// in the style of exchange market data feeds, with a TCP service to retransmit missed messages.
+//
+// Messages are framed in packets as in MoldUDP64. Every message has a sequence number, starting from 1 in each session,
+// and each packet carries the sequence number of its first message. All integers are big-endian.
+//
+//	Packet header (20 bytes):
+//	  [10]byte  session (ASCII, right padded with spaces)
+//	  uint64    sequence number of the first message in the packet
+//	  uint16    message count
+//	Then for each message:
+//	  uint16    message length (bytes)
+//	  []byte    message
+//
+// A packet with a message count of 0 is a heartbeat, and carries the sequence number of the next message.
+// A packet with a message count of 0xFFFF marks the end of the session.
+//
+// The messages are, in the style of ITCH:
+//
+//	System Event (10 bytes):
+//	  byte      'S'
+//	  uint64    timestamp (unix nanoseconds)
+//	  byte      event code: 'O' at the start of the session, 'C' at the end
+//
+//	Ticker Directory (27 bytes), sent at the start of the session and repeated periodically:
+//	  byte      'R'
+//	  uint16    locate code, used to identify the ticker in later messages
+//	  uint64    timestamp (unix nanoseconds)
+//	  [16]byte  ticker name (ASCII, right padded with spaces)
+//
+//	Price Update (19 bytes):
+//	  byte      'P'
+//	  uint16    locate code
+//	  uint64    timestamp (unix nanoseconds)
+//	  int64     price, with 8 implied decimal places
+//
+// The retransmission service accepts requests in the same format as MoldUDP64 (a packet header,
+// giving the first sequence number and count of the messages to retransmit). Each request is answered
+// with a single packet, prefixed with its uint16 length. If the first requested message is no longer
+// buffered, the response is a packet with no messages and the sequence number of the oldest buffered message.
+package multicast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	SessionLength    = 10
+	PacketHeaderSize = SessionLength + 8 + 2
+	TickerNameLength = 16
+
+	// The message count of a packet marking the end of the session.
+	EndOfSessionMessageCount = math.MaxUint16
+
+	// Prices are sent as integers, with this many implied decimal places.
+	PriceDecimals = 8
+	priceScale    = 1e8
+)
+
+// The message types.
+const (
+	MessageTypeSystemEvent     = 'S'
+	MessageTypeTickerDirectory = 'R'
+	MessageTypePriceUpdate     = 'P'
+)
+
+// The event codes of System Event messages.
+const (
+	EventCodeStartOfMessages = 'O'
+	EventCodeEndOfMessages   = 'C'
+)
+
+const (
+	systemEventSize     = 1 + 8 + 1
+	tickerDirectorySize = 1 + 2 + 8 + TickerNameLength
+	priceUpdateSize     = 1 + 2 + 8 + 8
+)
+
+var (
+	ErrorTruncated          = errors.New("truncated multicast packet or message")
+	ErrorUnknownMessageType = errors.New("unknown multicast message type")
+)
+
+type PacketHeader struct {
+	Session        string
+	SequenceNumber uint64
+	MessageCount   uint16
+}
+
+type SystemEvent struct {
+	Timestamp time.Time
+	EventCode byte
+}
+
+type TickerDirectory struct {
+	Locate    uint16
+	Timestamp time.Time
+	Name      string
+}
+
+type PriceUpdate struct {
+	Locate    uint16
+	Timestamp time.Time
+	Price     int64
+}
+
+// The price as a float, from the fixed point price.
+func (u PriceUpdate) Value() float64 {
+	return float64(u.Price) / priceScale
+}
+
+// --------------------------------------------------------------------------------
+// Encoding
+
+// Append an encoded packet header, e.g. to build a retransmission request.
+func AppendPacketHeader(buf []byte, header PacketHeader) []byte {
+	buf = appendPadded(buf, header.Session, SessionLength)
+	buf = binary.BigEndian.AppendUint64(buf, header.SequenceNumber)
+	return binary.BigEndian.AppendUint16(buf, header.MessageCount)
+}
+
+// Append a message block (length and message) to a packet.
+func appendMessageBlock(buf []byte, message []byte) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(message)))
+	return append(buf, message...)
+}
+
+func encodeSystemEvent(event SystemEvent) []byte {
+	message := make([]byte, 0, systemEventSize)
+	message = append(message, MessageTypeSystemEvent)
+	message = binary.BigEndian.AppendUint64(message, uint64(event.Timestamp.UnixNano()))
+	return append(message, event.EventCode)
+}
+
+func encodeTickerDirectory(directory TickerDirectory) []byte {
+	message := make([]byte, 0, tickerDirectorySize)
+	message = append(message, MessageTypeTickerDirectory)
+	message = binary.BigEndian.AppendUint16(message, directory.Locate)
+	message = binary.BigEndian.AppendUint64(message, uint64(directory.Timestamp.UnixNano()))
+	return appendPadded(message, directory.Name, TickerNameLength)
+}
+
+func encodePriceUpdate(update PriceUpdate) []byte {
+	message := make([]byte, 0, priceUpdateSize)
+	message = append(message, MessageTypePriceUpdate)
+	message = binary.BigEndian.AppendUint16(message, update.Locate)
+	message = binary.BigEndian.AppendUint64(message, uint64(update.Timestamp.UnixNano()))
+	return binary.BigEndian.AppendUint64(message, uint64(update.Price))
+}
+
+// Convert a price to fixed point, saturating at the limits of int64.
+func priceOf(value float64) int64 {
+	scaled := math.Round(value * priceScale)
+	switch {
+	case math.IsNaN(scaled):
+		return 0
+	case scaled >= math.MaxInt64:
+		return math.MaxInt64
+	case scaled <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(scaled)
+}
+
+func appendPadded(buf []byte, s string, length int) []byte {
+	buf = append(buf, s...)
+	for range length - len(s) {
+		buf = append(buf, ' ')
+	}
+	return buf
+}
+
+// --------------------------------------------------------------------------------
+// Decoding, for consumers.
+
+// Parse the header of a packet (or a retransmission request).
+func ParsePacketHeader(packet []byte) (PacketHeader, error) {
+	if len(packet) < PacketHeaderSize {
+		return PacketHeader{}, ErrorTruncated
+	}
+	return PacketHeader{
+		Session:        strings.TrimRight(string(packet[:SessionLength]), " "),
+		SequenceNumber: binary.BigEndian.Uint64(packet[SessionLength:]),
+		MessageCount:   binary.BigEndian.Uint16(packet[SessionLength+8:]),
+	}, nil
+}
+
+// Parse a packet into its header and messages. The messages alias the packet.
+func ParsePacket(packet []byte) (PacketHeader, [][]byte, error) {
+	header, err := ParsePacketHeader(packet)
+	if err != nil {
+		return header, nil, err
+	}
+	if header.MessageCount == EndOfSessionMessageCount {
+		return header, nil, nil
+	}
+
+	messages := make([][]byte, 0, header.MessageCount)
+	rest := packet[PacketHeaderSize:]
+	for range header.MessageCount {
+		if len(rest) < 2 {
+			return header, nil, ErrorTruncated
+		}
+		messageLength := int(binary.BigEndian.Uint16(rest))
+		if len(rest) < 2+messageLength {
+			return header, nil, ErrorTruncated
+		}
+		messages = append(messages, rest[2:2+messageLength])
+		rest = rest[2+messageLength:]
+	}
+	return header, messages, nil
+}
+
+// Parse a single message, returning a SystemEvent, TickerDirectory, or PriceUpdate.
+func ParseMessage(message []byte) (any, error) {
+	if len(message) == 0 {
+		return nil, ErrorTruncated
+	}
+	switch message[0] {
+	case MessageTypeSystemEvent:
+		if len(message) < systemEventSize {
+			return nil, ErrorTruncated
+		}
+		return SystemEvent{
+			Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(message[1:]))),
+			EventCode: message[9],
+		}, nil
+	case MessageTypeTickerDirectory:
+		if len(message) < tickerDirectorySize {
+			return nil, ErrorTruncated
+		}
+		return TickerDirectory{
+			Locate:    binary.BigEndian.Uint16(message[1:]),
+			Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(message[3:]))),
+			Name:      strings.TrimRight(string(message[11:11+TickerNameLength]), " "),
+		}, nil
+	case MessageTypePriceUpdate:
+		if len(message) < priceUpdateSize {
+			return nil, ErrorTruncated
+		}
+		return PriceUpdate{
+			Locate:    binary.BigEndian.Uint16(message[1:]),
+			Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(message[3:]))),
+			Price:     int64(binary.BigEndian.Uint64(message[11:])),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrorUnknownMessageType, message[0])
+}
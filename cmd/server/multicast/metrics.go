@@ -0,0 +1,37 @@
+package multicast
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics of the multicast publisher, registered with the default registry.
+var (
+	metricMessagesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "multicast",
+		Name:      "messages_total",
+		Help:      "The number of messages published.",
+	})
+
+	metricPacketsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "multicast",
+		Name:      "packets_total",
+		Help:      "The number of packets sent, including heartbeats.",
+	})
+
+	metricSendErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "multicast",
+		Name:      "send_errors_total",
+		Help:      "The number of packets that failed to send.",
+	})
+
+	metricRetransmitRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "multicast",
+		Name:      "retransmit_requests_total",
+		Help:      "The number of retransmission requests answered.",
+	})
+)
@@ -0,0 +1,319 @@
+package multicast
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	// How often to warn about send errors, rather than logging every failed packet.
+	sendErrorReportInterval = 10 * time.Second
+)
+
+// Publishes every ticker update over UDP, packing updates that arrive together into the same packet,
+// and keeps recent messages for the retransmission service.
+type Publisher struct {
+	cfg     config.MulticastConfig
+	session string
+
+	conn    net.PacketConn
+	address net.Addr
+
+	// The locate code of each ticker, assigned in name order from 1.
+	locates     map[string]uint16
+	tickerNames []string
+
+	// The packet being built, and the number of messages in it.
+	packet             []byte
+	packetMessageCount uint16
+	lastSent           time.Time
+
+	// Written only by the publishing goroutine, and read by the retransmission service.
+	mu                 sync.Mutex
+	nextSequenceNumber uint64
+	retransmitBuffer   *retransmitBuffer
+
+	retransmitServer *retransmitServer
+	done             chan struct{}
+
+	sendErrors          uint64
+	lastReportedErrors  uint64
+	lastErrorReportTime time.Time
+}
+
+// Create a publisher of the given tickers, opening the UDP socket and the retransmission service.
+func NewPublisher(cfg config.MulticastConfig, tickerNames []string) (*Publisher, error) {
+	session := cfg.Session
+	if session == "" {
+		// Unique per start (to the second), so consumers can tell a restarted feed from a gap.
+		session = strconv.FormatInt(time.Now().Unix(), 10)
+	}
+	if len(session) > SessionLength {
+		return nil, fmt.Errorf("multicast session %q is longer than %d characters", session, SessionLength)
+	}
+	if len(tickerNames) > EndOfSessionMessageCount-1 {
+		return nil, fmt.Errorf("at most %d tickers can be published over multicast", EndOfSessionMessageCount-1)
+	}
+
+	p := &Publisher{
+		cfg:                cfg,
+		session:            session,
+		locates:            make(map[string]uint16, len(tickerNames)),
+		tickerNames:        slices.Sorted(slices.Values(tickerNames)),
+		nextSequenceNumber: 1,
+		retransmitBuffer:   newRetransmitBuffer(cfg.RetransmitBufferSize),
+		done:               make(chan struct{}),
+	}
+	for i, tickerName := range p.tickerNames {
+		if len(tickerName) > TickerNameLength {
+			return nil, fmt.Errorf("ticker name %q is longer than the %d characters allowed over multicast", tickerName, TickerNameLength)
+		}
+		p.locates[tickerName] = uint16(i + 1)
+	}
+
+	if err := p.openSocket(); err != nil {
+		return nil, err
+	}
+	if cfg.RetransmitPort != 0 {
+		retransmitServer, err := newRetransmitServer(p, cfg.RetransmitPort)
+		if err != nil {
+			p.conn.Close()
+			return nil, err
+		}
+		p.retransmitServer = retransmitServer
+	}
+	return p, nil
+}
+
+// Open the UDP socket, and configure it for multicast if publishing to a multicast group.
+func (p *Publisher) openSocket() error {
+	address, err := net.ResolveUDPAddr("udp", p.cfg.Address)
+	if err != nil {
+		return fmt.Errorf("invalid multicast address %q: %w", p.cfg.Address, err)
+	}
+	var multicastInterface *net.Interface
+	if p.cfg.Interface != "" {
+		multicastInterface, err = net.InterfaceByName(p.cfg.Interface)
+		if err != nil {
+			return fmt.Errorf("invalid multicast interface %q: %w", p.cfg.Interface, err)
+		}
+	}
+
+	network := "udp6"
+	if address.IP.To4() != nil {
+		network = "udp4"
+	}
+	conn, err := net.ListenPacket(network, ":0")
+	if err != nil {
+		return err
+	}
+
+	if address.IP.IsMulticast() {
+		if network == "udp4" {
+			packetConn := ipv4.NewPacketConn(conn)
+			err = packetConn.SetMulticastTTL(p.cfg.TTL)
+			if err == nil {
+				err = packetConn.SetMulticastLoopback(p.cfg.Loopback)
+			}
+			if err == nil && multicastInterface != nil {
+				err = packetConn.SetMulticastInterface(multicastInterface)
+			}
+		} else {
+			packetConn := ipv6.NewPacketConn(conn)
+			err = packetConn.SetMulticastHopLimit(p.cfg.TTL)
+			if err == nil {
+				err = packetConn.SetMulticastLoopback(p.cfg.Loopback)
+			}
+			if err == nil && multicastInterface != nil {
+				err = packetConn.SetMulticastInterface(multicastInterface)
+			}
+		}
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("error when configuring multicast: %w", err)
+		}
+	}
+
+	p.conn = conn
+	p.address = address
+	return nil
+}
+
+// Subscribe to the broadcaster and publish every update, in a new goroutine, until the broadcaster is closed.
+// The session is then ended, and the retransmission service stopped.
+func (p *Publisher) Start(updates *ticker.UpdateBroadcaster) {
+	subscription := updates.Subscribe(p.cfg.BufferSize)
+	if p.retransmitServer != nil {
+		go p.retransmitServer.serve()
+	}
+	go func() {
+		defer close(p.done)
+		p.publish(subscription)
+	}()
+	slog.Info("publishing multicast feed", "address", p.address.String(), "session", p.session, "retransmitPort", p.cfg.RetransmitPort)
+}
+
+// Wait for the session to end. Call only after closing the broadcaster.
+func (p *Publisher) Wait() {
+	<-p.done
+}
+
+func (p *Publisher) publish(subscription *ticker.Subscription) {
+	defer p.conn.Close()
+	if p.retransmitServer != nil {
+		defer p.retransmitServer.close()
+	}
+
+	p.addMessage(encodeSystemEvent(SystemEvent{Timestamp: time.Now(), EventCode: EventCodeStartOfMessages}))
+	p.addDirectory()
+	p.flush()
+
+	heartbeatTimer := time.NewTicker(min(p.cfg.HeartbeatInterval, 100*time.Millisecond))
+	defer heartbeatTimer.Stop()
+	var directoryC <-chan time.Time
+	if p.cfg.DirectoryInterval > 0 {
+		directoryTimer := time.NewTicker(p.cfg.DirectoryInterval)
+		defer directoryTimer.Stop()
+		directoryC = directoryTimer.C
+	}
+
+	for {
+		select {
+		case update, ok := <-subscription.C:
+			if !ok {
+				p.endSession()
+				return
+			}
+			p.addUpdate(update)
+
+			// Pack any updates already waiting into the same packets, without waiting for more.
+		drain:
+			for {
+				select {
+				case update, ok := <-subscription.C:
+					if !ok {
+						p.endSession()
+						return
+					}
+					p.addUpdate(update)
+				default:
+					break drain
+				}
+			}
+			p.flush()
+		case <-directoryC:
+			p.addDirectory()
+			p.flush()
+		case now := <-heartbeatTimer.C:
+			if now.Sub(p.lastSent) >= p.cfg.HeartbeatInterval {
+				p.sendEmptyPacket(0)
+			}
+			p.reportSendErrors(now)
+		}
+	}
+}
+
+func (p *Publisher) addUpdate(update ticker.TickerUpdate) {
+	locate, ok := p.locates[update.Name]
+	if !ok {
+		return
+	}
+	p.addMessage(encodePriceUpdate(PriceUpdate{
+		Locate:    locate,
+		Timestamp: update.Timestamp,
+		Price:     priceOf(update.Value),
+	}))
+}
+
+func (p *Publisher) addDirectory() {
+	now := time.Now()
+	for _, tickerName := range p.tickerNames {
+		p.addMessage(encodeTickerDirectory(TickerDirectory{
+			Locate:    p.locates[tickerName],
+			Timestamp: now,
+			Name:      tickerName,
+		}))
+	}
+}
+
+// Send the end of messages event, then the end of session packet.
+func (p *Publisher) endSession() {
+	p.addMessage(encodeSystemEvent(SystemEvent{Timestamp: time.Now(), EventCode: EventCodeEndOfMessages}))
+	p.flush()
+	p.sendEmptyPacket(EndOfSessionMessageCount)
+	p.reportSendErrors(time.Now())
+	slog.Info("multicast session ended", "session", p.session, "messages", p.nextSequenceNumber-1)
+}
+
+// Add a message to the current packet, sending the packet first if the message would not fit.
+// The message is given the next sequence number, and kept for retransmission.
+func (p *Publisher) addMessage(message []byte) {
+	if len(p.packet)+2+len(message) > p.cfg.MaxPacketSize {
+		p.flush()
+	}
+
+	p.mu.Lock()
+	sequenceNumber := p.nextSequenceNumber
+	p.nextSequenceNumber++
+	p.retransmitBuffer.append(sequenceNumber, message)
+	p.mu.Unlock()
+
+	if p.packetMessageCount == 0 {
+		p.packet = AppendPacketHeader(p.packet[:0], PacketHeader{Session: p.session, SequenceNumber: sequenceNumber})
+	}
+	p.packet = appendMessageBlock(p.packet, message)
+	p.packetMessageCount++
+}
+
+// Send the current packet, if it has any messages.
+func (p *Publisher) flush() {
+	if p.packetMessageCount == 0 {
+		return
+	}
+	// The message count is only known now, so is written into the header last.
+	p.packet[SessionLength+8] = byte(p.packetMessageCount >> 8)
+	p.packet[SessionLength+9] = byte(p.packetMessageCount)
+	p.send(p.packet)
+	metricMessagesTotal.Add(float64(p.packetMessageCount))
+	p.packetMessageCount = 0
+}
+
+// Send a packet with no messages: a heartbeat (a count of 0) or the end of the session (EndOfSessionMessageCount).
+func (p *Publisher) sendEmptyPacket(messageCount uint16) {
+	p.mu.Lock()
+	nextSequenceNumber := p.nextSequenceNumber
+	p.mu.Unlock()
+	p.send(AppendPacketHeader(nil, PacketHeader{Session: p.session, SequenceNumber: nextSequenceNumber, MessageCount: messageCount}))
+}
+
+func (p *Publisher) send(packet []byte) {
+	p.lastSent = time.Now()
+	metricPacketsTotal.Inc()
+	if _, err := p.conn.WriteTo(packet, p.address); err != nil {
+		metricSendErrorsTotal.Inc()
+		p.sendErrors++
+		if p.sendErrors == 1 {
+			slog.Warn("error when sending multicast packet", "address", p.address.String(), "err", err)
+		}
+	}
+}
+
+// Warn about any send errors since the last report, at most once per report interval.
+func (p *Publisher) reportSendErrors(now time.Time) {
+	if p.sendErrors == p.lastReportedErrors || now.Sub(p.lastErrorReportTime) < sendErrorReportInterval {
+		return
+	}
+	slog.Warn("multicast packets failed to send", "address", p.address.String(), "failedPackets", p.sendErrors-p.lastReportedErrors)
+	p.lastReportedErrors = p.sendErrors
+	p.lastErrorReportTime = now
+}
@@ -0,0 +1,169 @@
+package multicast
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// Retransmissions are sent over TCP, so are limited only by the uint16 length prefix.
+	maxRetransmitPacketSize = math.MaxUint16
+
+	retransmitWriteTimeout = 10 * time.Second
+)
+
+// A ring buffer of the most recent messages, by sequence number. Not safe for concurrent use.
+type retransmitBuffer struct {
+	messages [][]byte
+	// The sequence number of the oldest message in the buffer.
+	firstSequenceNumber uint64
+	start               int
+	length              int
+}
+
+func newRetransmitBuffer(capacity int) *retransmitBuffer {
+	return &retransmitBuffer{
+		messages:            make([][]byte, capacity),
+		firstSequenceNumber: 1,
+	}
+}
+
+// Append the message with the given sequence number, which must follow the last appended message.
+func (b *retransmitBuffer) append(sequenceNumber uint64, message []byte) {
+	if b.length < len(b.messages) {
+		b.messages[(b.start+b.length)%len(b.messages)] = message
+		b.length++
+		return
+	}
+	b.messages[b.start] = message
+	b.start = (b.start + 1) % len(b.messages)
+	b.firstSequenceNumber = sequenceNumber - uint64(b.length) + 1
+}
+
+// Build the response packet to a retransmission request: as many of the requested messages as fit,
+// or an empty packet with the sequence number of the oldest buffered message if the first is no longer buffered.
+func (b *retransmitBuffer) responsePacket(session string, sequenceNumber uint64, messageCount uint16) []byte {
+	if sequenceNumber < b.firstSequenceNumber {
+		return AppendPacketHeader(nil, PacketHeader{Session: session, SequenceNumber: b.firstSequenceNumber})
+	}
+
+	packet := AppendPacketHeader(nil, PacketHeader{Session: session, SequenceNumber: sequenceNumber})
+	var count uint16
+	for i := sequenceNumber - b.firstSequenceNumber; i < uint64(b.length) && count < messageCount; i++ {
+		message := b.messages[(b.start+int(i))%len(b.messages)]
+		if len(packet)+2+len(message) > maxRetransmitPacketSize {
+			break
+		}
+		packet = appendMessageBlock(packet, message)
+		count++
+	}
+	binary.BigEndian.PutUint16(packet[SessionLength+8:], count)
+	return packet
+}
+
+// --------------------------------------------------------------------------------
+
+// Serves retransmission requests over TCP, from the messages buffered by the publisher.
+type retransmitServer struct {
+	publisher *Publisher
+	listener  net.Listener
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+func newRetransmitServer(publisher *Publisher, port int) (*retransmitServer, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return nil, err
+	}
+	return &retransmitServer{
+		publisher: publisher,
+		listener:  listener,
+		conns:     make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// Accept connections until the server is closed.
+func (s *retransmitServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				slog.Error("error when accepting retransmission connection", "err", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go func() {
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			if err := s.handleConnection(conn); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				slog.Warn("retransmission connection ended with error", "remoteAddr", conn.RemoteAddr().String(), "err", err)
+			}
+		}()
+	}
+}
+
+// Answer each request on the connection, until the client disconnects.
+func (s *retransmitServer) handleConnection(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+	request := make([]byte, PacketHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, request); err != nil {
+			return err
+		}
+		header, err := ParsePacketHeader(request)
+		if err != nil {
+			return err
+		}
+		if header.Session != s.publisher.session {
+			return fmt.Errorf("request for unknown session %q", header.Session)
+		}
+		metricRetransmitRequestsTotal.Inc()
+
+		s.publisher.mu.Lock()
+		packet := s.publisher.retransmitBuffer.responsePacket(s.publisher.session, header.SequenceNumber, header.MessageCount)
+		s.publisher.mu.Unlock()
+
+		response := binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(packet)), uint16(len(packet)))
+		response = append(response, packet...)
+		conn.SetWriteDeadline(time.Now().Add(retransmitWriteTimeout))
+		if _, err := conn.Write(response); err != nil {
+			return err
+		}
+	}
+}
+
+// Stop accepting connections, and close every open connection.
+func (s *retransmitServer) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
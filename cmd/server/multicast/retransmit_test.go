@@ -0,0 +1,125 @@
+package multicast
+
+import (
+	"bytes"
+	"testing"
+)
+
+// A message of the given size, whose first byte is the sequence number (mod 256), to check which message was returned.
+func testRetransmitMessage(sequenceNumber uint64, size int) []byte {
+	message := bytes.Repeat([]byte{'.'}, size)
+	message[0] = byte(sequenceNumber)
+	return message
+}
+
+func TestRetransmitBufferResponsePacket(t *testing.T) {
+	const session = "TEST"
+
+	tests := []struct {
+		name        string
+		capacity    int
+		appended    uint64
+		messageSize int
+
+		requestSequenceNumber uint64
+		requestMessageCount   uint16
+
+		wantSequenceNumber  uint64
+		wantSequenceNumbers []uint64
+	}{
+		{
+			name:                  "before wraparound",
+			capacity:              4,
+			appended:              3,
+			requestSequenceNumber: 1,
+			requestMessageCount:   2,
+			wantSequenceNumber:    1,
+			wantSequenceNumbers:   []uint64{1, 2},
+		},
+		{
+			name:                  "after wraparound",
+			capacity:              4,
+			appended:              10,
+			requestSequenceNumber: 8,
+			requestMessageCount:   10,
+			wantSequenceNumber:    8,
+			wantSequenceNumbers:   []uint64{8, 9, 10},
+		},
+		{
+			name:                  "oldest buffered after wraparound",
+			capacity:              4,
+			appended:              10,
+			requestSequenceNumber: 7,
+			requestMessageCount:   2,
+			wantSequenceNumber:    7,
+			wantSequenceNumbers:   []uint64{7, 8},
+		},
+		{
+			name:                  "wrapped exactly once",
+			capacity:              4,
+			appended:              8,
+			requestSequenceNumber: 5,
+			requestMessageCount:   4,
+			wantSequenceNumber:    5,
+			wantSequenceNumbers:   []uint64{5, 6, 7, 8},
+		},
+		{
+			name:                  "first no longer buffered",
+			capacity:              4,
+			appended:              10,
+			requestSequenceNumber: 5,
+			requestMessageCount:   4,
+			wantSequenceNumber:    7,
+		},
+		{
+			name:                  "not yet sent",
+			capacity:              4,
+			appended:              10,
+			requestSequenceNumber: 11,
+			requestMessageCount:   4,
+			wantSequenceNumber:    11,
+		},
+		{
+			name:                  "limited by packet size",
+			capacity:              4,
+			appended:              4,
+			messageSize:           30000,
+			requestSequenceNumber: 1,
+			requestMessageCount:   4,
+			wantSequenceNumber:    1,
+			wantSequenceNumbers:   []uint64{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageSize := max(tt.messageSize, priceUpdateSize)
+			b := newRetransmitBuffer(tt.capacity)
+			for sequenceNumber := uint64(1); sequenceNumber <= tt.appended; sequenceNumber++ {
+				b.append(sequenceNumber, testRetransmitMessage(sequenceNumber, messageSize))
+			}
+
+			packet := b.responsePacket(session, tt.requestSequenceNumber, tt.requestMessageCount)
+			if len(packet) > maxRetransmitPacketSize {
+				t.Errorf("packet of %d bytes is larger than the maximum %d", len(packet), maxRetransmitPacketSize)
+			}
+			header, messages, err := ParsePacket(packet)
+			if err != nil {
+				t.Fatalf("error parsing response packet: %v", err)
+			}
+			if header.Session != session {
+				t.Errorf("session is %q, expected %q", header.Session, session)
+			}
+			if header.SequenceNumber != tt.wantSequenceNumber {
+				t.Errorf("sequence number is %d, expected %d", header.SequenceNumber, tt.wantSequenceNumber)
+			}
+			if len(messages) != len(tt.wantSequenceNumbers) {
+				t.Fatalf("got %d messages, expected %d", len(messages), len(tt.wantSequenceNumbers))
+			}
+			for i, message := range messages {
+				if want := testRetransmitMessage(tt.wantSequenceNumbers[i], messageSize); !bytes.Equal(message, want) {
+					t.Errorf("message %d is for sequence number %d, expected %d", i, message[0], tt.wantSequenceNumbers[i])
+				}
+			}
+		})
+	}
+}
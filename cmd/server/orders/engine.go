@@ -0,0 +1,498 @@
+// Package orders is a paper trading venue, accepting simulated orders from clients and filling them
+// against the quotes of the tickers, with a configurable latency, partial fills, and rejects.
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+var (
+	ErrorUnknownTicker       = errors.New("no ticker exists with requested name")
+	ErrorInvalidSide         = errors.New("order side must be buy or sell")
+	ErrorInvalidOrderType    = errors.New("order type must be market or limit")
+	ErrorInvalidTimeInForce  = errors.New("time in force must be gtc or ioc")
+	ErrorInvalidSize         = errors.New("order size must be greater than zero")
+	ErrorInvalidLimitPrice   = errors.New("limit orders must have a limit price greater than zero")
+	ErrorOrderDoesNotExist   = errors.New("no working order exists with requested ID")
+	ErrorOrderAlreadyPending = errors.New("a cancel of the order is already pending")
+)
+
+type OrderType string
+
+const (
+	// Fills at the quote of the ticker, at any price.
+	OrderTypeMarket OrderType = "market"
+
+	// Fills at the limit price or better.
+	OrderTypeLimit OrderType = "limit"
+)
+
+type TimeInForce string
+
+const (
+	// Works until filled or cancelled.
+	TimeInForceGTC TimeInForce = "gtc"
+
+	// Immediate or cancel: fills what it can on arrival, and the rest is cancelled.
+	TimeInForceIOC TimeInForce = "ioc"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPendingNew      OrderStatus = "pendingnew"
+	OrderStatusNew             OrderStatus = "new"
+	OrderStatusPartiallyFilled OrderStatus = "partiallyfilled"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusPendingCancel   OrderStatus = "pendingcancel"
+	OrderStatusCanceled        OrderStatus = "canceled"
+	OrderStatusRejected        OrderStatus = "rejected"
+)
+
+type ExecutionType string
+
+const (
+	ExecutionTypeNew      ExecutionType = "new"
+	ExecutionTypeTrade    ExecutionType = "trade"
+	ExecutionTypeCanceled ExecutionType = "canceled"
+	ExecutionTypeRejected ExecutionType = "rejected"
+)
+
+// The reasons the venue rejects an order on arrival. Each reason is also the label of the rejects metric.
+const (
+	RejectReasonRandom        = "random"
+	RejectReasonMaxOrderSize  = "maxordersize"
+	RejectReasonMaxOpenOrders = "maxopenorders"
+	RejectReasonPriceCollar   = "pricecollar"
+	RejectReasonMarketClosed  = "marketclosed"
+	RejectReasonMargin        = "margin"
+)
+
+// A new order, as submitted by a client.
+type OrderRequest struct {
+	// The client submitting the order. Only this client may cancel the order, or see its executions.
+	Owner string
+
+	// The ID given by the client, if any, echoed back on every execution.
+	ClientOrderID string
+
+	TickerName  string
+	Side        ticker.Side
+	Type        OrderType
+	TimeInForce TimeInForce
+	Size        int64
+
+	// The limit price, for limit orders.
+	LimitPrice float64
+}
+
+// A simulated order of a client, as of some point in time.
+type Order struct {
+	ID uint64
+	OrderRequest
+
+	Status             OrderStatus
+	FilledSize         int64
+	AverageFillPrice   float64
+	SubmittedTimestamp time.Time
+
+	// Why the order was rejected, for rejected orders.
+	RejectReason string
+}
+
+// A change to an order, e.g. a fill.
+type Execution struct {
+	ID        uint64
+	Timestamp time.Time
+	Type      ExecutionType
+
+	// The order after the execution.
+	Order Order
+
+	// The size and price of the fill, for trade executions.
+	FillSize  int64
+	FillPrice float64
+}
+
+// An order held by the engine, from submission until it is filled, cancelled, or rejected.
+type workingOrder struct {
+	Order
+
+	// Whether the order has arrived at the venue, after the latency.
+	arrived bool
+
+	// Whether a cancel has been requested, and whether it has arrived at the venue.
+	// A cancel arriving before the order cancels the order as soon as it arrives.
+	cancelRequested bool
+	cancelArrived   bool
+}
+
+// The size already filled against the current quote of a ticker, by side, so that
+// orders arriving between updates share the quoted size rather than each filling against all of it.
+type consumedLiquidity struct {
+	bid int64
+	ask int64
+}
+
+// Fills the orders of clients against the quotes of the tickers.
+// Orders arrive at the venue after the configured latency, and are matched against the quote at that time.
+// Any remaining size of a market order, or a limit order, then works until it is filled at a later update.
+// Safe for concurrent use.
+type Engine struct {
+	cfg     config.OrdersConfig
+	tickers map[string]ticker.Ticker
+
+	mu       sync.Mutex
+	randGen  *rand.Rand
+	orders   map[uint64]*workingOrder
+	consumed map[string]*consumedLiquidity
+
+	// The arrived orders of each ticker, in time priority.
+	working map[string][]*workingOrder
+
+	// The number of arrived orders of each client.
+	openOrders map[string]int
+
+	nextOrderID     uint64
+	nextExecutionID uint64
+
+	hooks       []func(Execution)
+	checks      []orderCheck
+	subscribers map[*ExecutionSubscription]struct{}
+	closed      bool
+
+	// The clock of the engine, replaced in tests to simulate the latency without waiting.
+	now       func() time.Time
+	afterFunc func(time.Duration, func())
+}
+
+func NewEngine(cfg config.OrdersConfig, tickers map[string]ticker.Ticker) *Engine {
+	// Seeded as the tickers are, so that a run can be reproduced by setting the logged seed in the config.
+	var randomSeed int64
+	if cfg.RandomSeed != nil {
+		randomSeed = *cfg.RandomSeed
+	} else {
+		randomSeed = rand.Int64()
+		slog.Info("no order engine random seed specified, using generated random seed", "randomSeed", randomSeed)
+	}
+
+	return &Engine{
+		cfg:         cfg,
+		tickers:     tickers,
+		randGen:     rand.New(rand.NewPCG(uint64(randomSeed), uint64(randomSeed))),
+		orders:      make(map[uint64]*workingOrder),
+		consumed:    make(map[string]*consumedLiquidity),
+		working:     make(map[string][]*workingOrder),
+		openOrders:  make(map[string]int),
+		nextOrderID: 1,
+		subscribers: make(map[*ExecutionSubscription]struct{}),
+		now:         time.Now,
+		afterFunc: func(delay time.Duration, f func()) {
+			time.AfterFunc(delay, f)
+		},
+	}
+}
+
+// A pre-trade check of an arriving order, given the ticker as the order arrives.
+// Returns a message for the client if the order is rejected, or an empty string if it is accepted.
+type OrderCheck func(order Order, info ticker.TickerInfo) string
+
+type orderCheck struct {
+	reason string
+	check  OrderCheck
+}
+
+// Add a pre-trade check of every arriving order, rejecting the orders it fails with the given reason
+// (also the label of the rejects metric). Checks run in the order added, after the limits of the engine, and before random rejects.
+//
+// Checks are called with the engine mutex locked, so must be quick, and must not call back into the engine.
+func (e *Engine) AddCheck(reason string, check OrderCheck) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.checks = append(e.checks, orderCheck{reason: reason, check: check})
+}
+
+// Subscribe to the broadcaster and fill working orders against every update, in a new goroutine,
+// until the broadcaster is closed. Every execution subscription is then closed.
+func (e *Engine) Start(updates *ticker.UpdateBroadcaster) {
+	subscription := updates.Subscribe(e.cfg.BufferSize)
+	go func() {
+		for update := range subscription.C {
+			e.onUpdate(update)
+		}
+		e.close()
+	}()
+}
+
+// Submit a new order. The order is validated immediately, but arrives at the venue (where it may be rejected)
+// only after the latency. With no latency, the order has arrived, and been matched, by the time this returns.
+//
+// Returns the order as of submission, or an error if the request is invalid (e.g. ErrorUnknownTicker).
+func (e *Engine) Submit(req OrderRequest) (Order, error) {
+	if _, ok := e.tickers[req.TickerName]; !ok {
+		return Order{}, ErrorUnknownTicker
+	}
+	if req.Side != ticker.SideBuy && req.Side != ticker.SideSell {
+		return Order{}, ErrorInvalidSide
+	}
+	if req.TimeInForce != TimeInForceGTC && req.TimeInForce != TimeInForceIOC {
+		return Order{}, ErrorInvalidTimeInForce
+	}
+	if req.Size <= 0 {
+		return Order{}, ErrorInvalidSize
+	}
+	switch req.Type {
+	case OrderTypeMarket:
+		req.LimitPrice = 0
+	case OrderTypeLimit:
+		if req.LimitPrice <= 0 || math.IsInf(req.LimitPrice, 0) || math.IsNaN(req.LimitPrice) {
+			return Order{}, ErrorInvalidLimitPrice
+		}
+	default:
+		return Order{}, ErrorInvalidOrderType
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o := &workingOrder{
+		Order: Order{
+			ID:                 e.nextOrderID,
+			OrderRequest:       req,
+			Status:             OrderStatusPendingNew,
+			SubmittedTimestamp: e.now(),
+		},
+	}
+	e.nextOrderID++
+	e.orders[o.ID] = o
+	metricOrdersSubmittedTotal.WithLabelValues(req.TickerName).Inc()
+
+	e.afterLatency(func() { e.arrive(o) })
+	return o.Order, nil
+}
+
+// Request a cancel of a working order of the given client. The cancel arrives at the venue after the latency,
+// so the order may still be filled in the meantime.
+//
+// Returns the order as of the request, or ErrorOrderDoesNotExist if the client has no working order with the ID.
+func (e *Engine) Cancel(owner string, orderID uint64) (Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, ok := e.orders[orderID]
+	if !ok || o.Owner != owner {
+		return Order{}, ErrorOrderDoesNotExist
+	}
+	if o.cancelRequested {
+		return Order{}, ErrorOrderAlreadyPending
+	}
+	o.cancelRequested = true
+	o.Status = OrderStatusPendingCancel
+
+	e.afterLatency(func() { e.arriveCancel(o) })
+	return o.Order, nil
+}
+
+// Call the given function with the engine mutex locked, after the latency (and a random jitter).
+// With no latency, the function is called immediately, and the mutex must already be locked.
+func (e *Engine) afterLatency(f func()) {
+	delay := e.cfg.Latency
+	if e.cfg.LatencyJitter > 0 {
+		delay += time.Duration(e.randGen.Int64N(int64(e.cfg.LatencyJitter) + 1))
+	}
+	if delay == 0 {
+		f()
+		return
+	}
+	e.afterFunc(delay, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		f()
+	})
+}
+
+// Process the arrival of an order at the venue: reject it, or accept it and match it against the current quote.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) arrive(o *workingOrder) {
+	// The value checked by the price collar, and the quote matched against, are read together, so are of the same update.
+	info := e.tickers[o.TickerName].GetSnapshot()
+	if reason, message := e.rejectReason(o, info); reason != "" {
+		metricOrdersRejectedTotal.WithLabelValues(reason).Inc()
+		o.RejectReason = message
+		e.finish(o, OrderStatusRejected)
+		e.publish(Execution{Type: ExecutionTypeRejected, Order: o.Order})
+		return
+	}
+
+	o.arrived = true
+	o.Status = OrderStatusNew
+	if o.cancelRequested {
+		o.Status = OrderStatusPendingCancel
+	}
+	e.working[o.TickerName] = append(e.working[o.TickerName], o)
+	e.openOrders[o.Owner]++
+	e.publish(Execution{Type: ExecutionTypeNew, Order: o.Order})
+
+	// A cancel that arrived first cancels the order before it can fill.
+	if o.cancelArrived {
+		e.cancel(o)
+		return
+	}
+
+	e.match(o, info.Quote, true)
+	if o.TimeInForce == TimeInForceIOC && e.orders[o.ID] != nil {
+		e.cancel(o)
+	}
+}
+
+// Process the arrival of a cancel at the venue.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) arriveCancel(o *workingOrder) {
+	if _, ok := e.orders[o.ID]; !ok {
+		// Already filled (or rejected) before the cancel arrived.
+		return
+	}
+	if !o.arrived {
+		o.cancelArrived = true
+		return
+	}
+	e.cancel(o)
+}
+
+// Get the reason (and a message for the client) that the venue rejects an arriving order, or empty strings if it is accepted,
+// given the ticker as the order arrives.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) rejectReason(o *workingOrder, info ticker.TickerInfo) (string, string) {
+	if marketStatus := e.tickers[o.TickerName].GetMarketStatus(e.now()); !marketStatus.Open {
+		return RejectReasonMarketClosed, fmt.Sprintf("market of calendar %q is closed", marketStatus.Calendar)
+	}
+	if e.cfg.MaxOrderSize > 0 && o.Size > e.cfg.MaxOrderSize {
+		return RejectReasonMaxOrderSize, fmt.Sprintf("order size exceeds the maximum of %d", e.cfg.MaxOrderSize)
+	}
+	if e.cfg.MaxOpenOrders > 0 && e.openOrders[o.Owner] >= e.cfg.MaxOpenOrders {
+		return RejectReasonMaxOpenOrders, fmt.Sprintf("client already has the maximum of %d working orders", e.cfg.MaxOpenOrders)
+	}
+	if e.cfg.PriceCollar > 0 && o.Type == OrderTypeLimit {
+		value := info.Value
+		if math.Abs(o.LimitPrice-value) > e.cfg.PriceCollar*value {
+			return RejectReasonPriceCollar, fmt.Sprintf("limit price is further than %g of the value %g", e.cfg.PriceCollar, value)
+		}
+	}
+	for _, check := range e.checks {
+		if message := check.check(o.Order, info); message != "" {
+			return check.reason, message
+		}
+	}
+	if e.cfg.RejectProbability > 0 && e.randGen.Float64() < e.cfg.RejectProbability {
+		return RejectReasonRandom, "rejected by the venue"
+	}
+	return "", ""
+}
+
+// Fill the working orders of the updated ticker against the new quote, in time priority.
+func (e *Engine) onUpdate(update ticker.TickerUpdate) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.consumed, update.Name)
+	for _, o := range slices.Clone(e.working[update.Name]) {
+		e.match(o, update.Quote, o.Type == OrderTypeMarket)
+	}
+}
+
+// Fill as much of an arrived order as the quote allows.
+// An order taking liquidity (a market order, or a limit order on arrival) fills at the quote.
+// A resting limit order fills at its limit price, once the quote moves through it.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) match(o *workingOrder, quote ticker.Quote, taker bool) {
+	consumed, ok := e.consumed[o.TickerName]
+	if !ok {
+		consumed = &consumedLiquidity{}
+		e.consumed[o.TickerName] = consumed
+	}
+
+	price, quotedSize, consumedSize, hasPrice := quote.Ask, quote.AskSize, &consumed.ask, quote.HasAsk
+	if o.Side == ticker.SideSell {
+		price, quotedSize, consumedSize, hasPrice = quote.Bid, quote.BidSize, &consumed.bid, quote.HasBid
+	}
+	// With no liquidity on the side the order takes (e.g. an empty side of an order book), the order waits for a later update.
+	if !hasPrice || price <= 0 {
+		return
+	}
+	if o.Type == OrderTypeLimit {
+		if (o.Side == ticker.SideBuy && price > o.LimitPrice) || (o.Side == ticker.SideSell && price < o.LimitPrice) {
+			return
+		}
+		if !taker {
+			price = o.LimitPrice
+		}
+	}
+
+	// A quoted size of zero on a side with a price (e.g. of a replay ticker) has no limit.
+	fillSize := o.Size - o.FilledSize
+	if e.cfg.PartialFills && quotedSize > 0 {
+		fillSize = min(fillSize, quotedSize-*consumedSize)
+		if fillSize <= 0 {
+			return
+		}
+		*consumedSize += fillSize
+	}
+
+	// The fill moves the ticker at its next update, through the impact model of the ticker.
+	e.tickers[o.TickerName].RecordExecution(o.Side, fillSize)
+
+	o.AverageFillPrice = (o.AverageFillPrice*float64(o.FilledSize) + price*float64(fillSize)) / float64(o.FilledSize+fillSize)
+	o.FilledSize += fillSize
+	if o.FilledSize == o.Size {
+		e.finish(o, OrderStatusFilled)
+	} else if !o.cancelRequested {
+		o.Status = OrderStatusPartiallyFilled
+	}
+	metricFillsTotal.WithLabelValues(o.TickerName).Inc()
+	metricFilledVolumeTotal.WithLabelValues(o.TickerName).Add(float64(fillSize))
+	e.publish(Execution{
+		Type:      ExecutionTypeTrade,
+		Order:     o.Order,
+		FillSize:  fillSize,
+		FillPrice: price,
+	})
+}
+
+// Cancel the remaining size of an arrived order.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) cancel(o *workingOrder) {
+	e.finish(o, OrderStatusCanceled)
+	e.publish(Execution{Type: ExecutionTypeCanceled, Order: o.Order})
+}
+
+// Set the final status of an order, and stop working it.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) finish(o *workingOrder, status OrderStatus) {
+	o.Status = status
+	delete(e.orders, o.ID)
+	if !o.arrived {
+		return
+	}
+	e.working[o.TickerName] = slices.DeleteFunc(e.working[o.TickerName], func(w *workingOrder) bool { return w == o })
+	e.openOrders[o.Owner]--
+	if e.openOrders[o.Owner] == 0 {
+		delete(e.openOrders, o.Owner)
+	}
+}
@@ -0,0 +1,485 @@
+package orders
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/calendar"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// A ticker serving a quote set by the test, and recording the executions of the engine against it.
+// Only the methods used by the engine are implemented.
+type fakeTicker struct {
+	ticker.Ticker
+	info ticker.TickerInfo
+
+	// The signed size of every recorded execution, positive for buys.
+	executions []int64
+}
+
+func (t *fakeTicker) GetSnapshot() ticker.TickerInfo {
+	return t.info
+}
+
+func (t *fakeTicker) GetMarketStatus(at time.Time) calendar.Status {
+	return calendar.Status{Open: true}
+}
+
+func (t *fakeTicker) RecordExecution(side ticker.Side, size int64) {
+	if side == ticker.SideSell {
+		size = -size
+	}
+	t.executions = append(t.executions, size)
+}
+
+// A clock whose time only moves when advanced by the test. Delayed functions are called by advance, or by fire.
+type fakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at    time.Time
+	f     func()
+	fired bool
+}
+
+func (c *fakeClock) afterFunc(delay time.Duration, f func()) {
+	c.timers = append(c.timers, &fakeTimer{at: c.now.Add(delay), f: f})
+}
+
+// Move the time forward, calling every delayed function due by the new time, in the order they are due,
+// with the time set to when each is due.
+func (c *fakeClock) advance(d time.Duration) {
+	end := c.now.Add(d)
+	for {
+		due := slices.DeleteFunc(slices.Clone(c.timers), func(timer *fakeTimer) bool {
+			return timer.fired || timer.at.After(end)
+		})
+		if len(due) == 0 {
+			c.now = end
+			return
+		}
+		timer := slices.MinFunc(due, func(a, b *fakeTimer) int { return a.at.Compare(b.at) })
+		c.now = timer.at
+		timer.fired = true
+		timer.f()
+	}
+}
+
+// Call the i-th delayed function now, regardless of when it is due, e.g. to deliver messages out of order.
+func (c *fakeClock) fire(i int) {
+	c.timers[i].fired = true
+	c.timers[i].f()
+}
+
+// A summary of an execution, leaving out the fields that depend on the time.
+type testExecution struct {
+	OrderID   uint64
+	Type      ExecutionType
+	Status    OrderStatus
+	FillSize  int64
+	FillPrice float64
+}
+
+type testEngine struct {
+	*Engine
+	clock      *fakeClock
+	ticker     *fakeTicker
+	executions []Execution
+}
+
+// Create an engine with a single ticker "T" quoted by the given quote, and a fake clock.
+func newTestEngine(t *testing.T, cfg config.OrdersConfig, quote ticker.Quote) *testEngine {
+	t.Helper()
+
+	seed := int64(1)
+	cfg.RandomSeed = &seed
+	fake := &fakeTicker{info: ticker.TickerInfo{Name: "T", Value: 100, Quote: quote}}
+	e := &testEngine{
+		Engine: NewEngine(cfg, map[string]ticker.Ticker{"T": fake}),
+		clock:  &fakeClock{now: time.Unix(1_700_000_000, 0)},
+		ticker: fake,
+	}
+	e.now = func() time.Time { return e.clock.now }
+	e.afterFunc = e.clock.afterFunc
+	e.OnExecution(func(execution Execution) {
+		e.executions = append(e.executions, execution)
+	})
+	return e
+}
+
+// Set the quote of the ticker, and fill the working orders against it as an update would.
+func (e *testEngine) update(quote ticker.Quote) {
+	e.ticker.info.Quote = quote
+	e.onUpdate(ticker.TickerUpdate{Name: "T", Timestamp: e.clock.now, Value: e.ticker.info.Value, Quote: quote})
+}
+
+func (e *testEngine) submit(t *testing.T, req OrderRequest) Order {
+	t.Helper()
+
+	req.Owner = "client"
+	req.TickerName = "T"
+	if req.TimeInForce == "" {
+		req.TimeInForce = TimeInForceGTC
+	}
+	order, err := e.Submit(req)
+	if err != nil {
+		t.Fatalf("error submitting order: %v", err)
+	}
+	return order
+}
+
+func (e *testEngine) checkExecutions(t *testing.T, want []testExecution) {
+	t.Helper()
+
+	got := make([]testExecution, 0, len(e.executions))
+	for _, execution := range e.executions {
+		got = append(got, testExecution{
+			OrderID:   execution.Order.ID,
+			Type:      execution.Type,
+			Status:    execution.Order.Status,
+			FillSize:  execution.FillSize,
+			FillPrice: execution.FillPrice,
+		})
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("executions are %+v, expected %+v", got, want)
+	}
+}
+
+func testQuote(bid float64, ask float64, size int64) ticker.Quote {
+	return ticker.Quote{Bid: bid, Ask: ask, BidSize: size, AskSize: size, HasBid: true, HasAsk: true}
+}
+
+func TestEngineFills(t *testing.T) {
+	quote := testQuote(99.9, 100.1, 100)
+
+	tests := []struct {
+		name         string
+		partialFills bool
+		quote        ticker.Quote
+		orders       []OrderRequest
+		// The quotes of the updates after the orders are submitted.
+		updates []ticker.Quote
+
+		wantExecutions []testExecution
+		// The signed size of every execution recorded against the ticker, positive for buys.
+		wantTickerExecutions []int64
+	}{
+		{
+			name:   "market buy fills at the ask",
+			quote:  quote,
+			orders: []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 50}},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 100.1},
+			},
+			wantTickerExecutions: []int64{50},
+		},
+		{
+			name:   "market sell fills at the bid",
+			quote:  quote,
+			orders: []OrderRequest{{Side: ticker.SideSell, Type: OrderTypeMarket, Size: 50}},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 99.9},
+			},
+			wantTickerExecutions: []int64{-50},
+		},
+		{
+			name:         "partial fills of the quoted size, then the rest at the next update",
+			partialFills: true,
+			quote:        quote,
+			orders:       []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 150}},
+			updates:      []ticker.Quote{testQuote(100, 100.2, 100)},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusPartiallyFilled, FillSize: 100, FillPrice: 100.1},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 100.2},
+			},
+			wantTickerExecutions: []int64{100, 50},
+		},
+		{
+			name:   "without partial fills, the full size fills",
+			quote:  quote,
+			orders: []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 150}},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 150, FillPrice: 100.1},
+			},
+			wantTickerExecutions: []int64{150},
+		},
+		{
+			name:         "orders share the quoted size between updates",
+			partialFills: true,
+			quote:        quote,
+			orders: []OrderRequest{
+				{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 80},
+				{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 80},
+				{Side: ticker.SideSell, Type: OrderTypeMarket, Size: 80},
+			},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 80, FillPrice: 100.1},
+				{OrderID: 2, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 2, Type: ExecutionTypeTrade, Status: OrderStatusPartiallyFilled, FillSize: 20, FillPrice: 100.1},
+				{OrderID: 3, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 3, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 80, FillPrice: 99.9},
+			},
+			wantTickerExecutions: []int64{80, 20, -80},
+		},
+		{
+			name:   "marketable limit order fills at the quote",
+			quote:  quote,
+			orders: []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeLimit, Size: 50, LimitPrice: 100.5}},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 100.1},
+			},
+			wantTickerExecutions: []int64{50},
+		},
+		{
+			name:    "resting limit order fills at its limit once the quote moves through it",
+			quote:   quote,
+			orders:  []OrderRequest{{Side: ticker.SideSell, Type: OrderTypeLimit, Size: 50, LimitPrice: 100}},
+			updates: []ticker.Quote{testQuote(99.95, 100.05, 100), testQuote(100.05, 100.15, 100)},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 100},
+			},
+			wantTickerExecutions: []int64{-50},
+		},
+		{
+			name:    "market order waits while the side it takes has no price",
+			quote:   ticker.Quote{Bid: 99.9, BidSize: 100, HasBid: true},
+			orders:  []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 50}},
+			updates: []ticker.Quote{{Bid: 99.9, BidSize: 100, HasBid: true}, quote},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 100.1},
+			},
+			wantTickerExecutions: []int64{50},
+		},
+		{
+			name:         "IOC remainder is cancelled",
+			partialFills: true,
+			quote:        quote,
+			orders:       []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeMarket, TimeInForce: TimeInForceIOC, Size: 150}},
+			updates:      []ticker.Quote{quote},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusPartiallyFilled, FillSize: 100, FillPrice: 100.1},
+				{OrderID: 1, Type: ExecutionTypeCanceled, Status: OrderStatusCanceled},
+			},
+			wantTickerExecutions: []int64{100},
+		},
+		{
+			name:    "IOC limit order that cannot fill is cancelled",
+			quote:   quote,
+			orders:  []OrderRequest{{Side: ticker.SideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceIOC, Size: 50, LimitPrice: 100}},
+			updates: []ticker.Quote{testQuote(99.8, 99.9, 100)},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeCanceled, Status: OrderStatusCanceled},
+			},
+		},
+		{
+			name:   "IOC order that fills is not cancelled",
+			quote:  quote,
+			orders: []OrderRequest{{Side: ticker.SideSell, Type: OrderTypeMarket, TimeInForce: TimeInForceIOC, Size: 50}},
+			wantExecutions: []testExecution{
+				{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+				{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 99.9},
+			},
+			wantTickerExecutions: []int64{-50},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEngine(t, config.OrdersConfig{PartialFills: tt.partialFills}, tt.quote)
+			for _, req := range tt.orders {
+				e.submit(t, req)
+			}
+			for _, quote := range tt.updates {
+				e.update(quote)
+			}
+
+			e.checkExecutions(t, tt.wantExecutions)
+			if !slices.Equal(e.ticker.executions, tt.wantTickerExecutions) {
+				t.Errorf("ticker executions are %v, expected %v", e.ticker.executions, tt.wantTickerExecutions)
+			}
+		})
+	}
+}
+
+func TestEngineRejects(t *testing.T) {
+	quote := testQuote(99.9, 100.1, 100)
+
+	tests := []struct {
+		name       string
+		cfg        config.OrdersConfig
+		order      OrderRequest
+		wantReason string
+	}{
+		{
+			name:       "larger than the maximum order size",
+			cfg:        config.OrdersConfig{MaxOrderSize: 100},
+			order:      OrderRequest{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 101},
+			wantReason: "order size exceeds the maximum of 100",
+		},
+		{
+			name:       "outside the price collar",
+			cfg:        config.OrdersConfig{PriceCollar: 0.1},
+			order:      OrderRequest{Side: ticker.SideBuy, Type: OrderTypeLimit, Size: 10, LimitPrice: 89},
+			wantReason: "limit price is further than 0.1 of the value 100",
+		},
+		{
+			name:       "at random",
+			cfg:        config.OrdersConfig{RejectProbability: 1},
+			order:      OrderRequest{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 10},
+			wantReason: "rejected by the venue",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEngine(t, tt.cfg, quote)
+			e.submit(t, tt.order)
+
+			e.checkExecutions(t, []testExecution{{OrderID: 1, Type: ExecutionTypeRejected, Status: OrderStatusRejected}})
+			if reason := e.executions[0].Order.RejectReason; reason != tt.wantReason {
+				t.Errorf("reject reason is %q, expected %q", reason, tt.wantReason)
+			}
+			if len(e.ticker.executions) != 0 {
+				t.Errorf("ticker executions are %v, expected none", e.ticker.executions)
+			}
+		})
+	}
+}
+
+func TestEngineLatency(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	e := newTestEngine(t, config.OrdersConfig{Latency: latency}, testQuote(99.9, 100.1, 100))
+	submitted := e.clock.now
+	order := e.submit(t, OrderRequest{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 50})
+	if order.Status != OrderStatusPendingNew || !order.SubmittedTimestamp.Equal(submitted) {
+		t.Errorf("submitted order is %+v, expected pending new at %v", order, submitted)
+	}
+
+	// The quote moves before the order arrives, so the order fills at the quote on arrival.
+	e.clock.advance(latency - time.Nanosecond)
+	e.checkExecutions(t, []testExecution{})
+	e.ticker.info.Quote = testQuote(100.9, 101.1, 100)
+	e.clock.advance(time.Nanosecond)
+	e.checkExecutions(t, []testExecution{
+		{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+		{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 101.1},
+	})
+	for _, execution := range e.executions {
+		if want := submitted.Add(latency); !execution.Timestamp.Equal(want) {
+			t.Errorf("execution is timestamped %v, expected the arrival at %v", execution.Timestamp, want)
+		}
+	}
+}
+
+func TestEngineLatencyJitter(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	const jitter = 5 * time.Millisecond
+	e := newTestEngine(t, config.OrdersConfig{Latency: latency, LatencyJitter: jitter}, testQuote(99.9, 100.1, 100))
+	for range 20 {
+		e.submit(t, OrderRequest{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 1})
+	}
+
+	e.clock.advance(latency - time.Nanosecond)
+	if len(e.executions) != 0 {
+		t.Fatalf("%d executions before the latency, expected none", len(e.executions))
+	}
+	e.clock.advance(jitter + time.Nanosecond)
+	if len(e.executions) != 40 {
+		t.Fatalf("%d executions after the latency and jitter, expected 40", len(e.executions))
+	}
+	arrivals := make(map[time.Time]bool)
+	for _, execution := range e.executions {
+		arrivals[execution.Timestamp] = true
+	}
+	if len(arrivals) < 2 {
+		t.Errorf("every order arrived at the same time, expected jitter")
+	}
+}
+
+func TestEngineCancel(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	quote := testQuote(99.9, 100.1, 100)
+
+	t.Run("cancel of a resting order", func(t *testing.T) {
+		e := newTestEngine(t, config.OrdersConfig{Latency: latency}, quote)
+		e.submit(t, OrderRequest{Side: ticker.SideBuy, Type: OrderTypeLimit, Size: 50, LimitPrice: 100})
+		e.clock.advance(latency)
+
+		order, err := e.Cancel("client", 1)
+		if err != nil {
+			t.Fatalf("error cancelling order: %v", err)
+		}
+		if order.Status != OrderStatusPendingCancel {
+			t.Errorf("order status is %q, expected %q", order.Status, OrderStatusPendingCancel)
+		}
+		if _, err := e.Cancel("client", 1); !errors.Is(err, ErrorOrderAlreadyPending) {
+			t.Errorf("error cancelling again is %v, expected %v", err, ErrorOrderAlreadyPending)
+		}
+		e.clock.advance(latency)
+		e.checkExecutions(t, []testExecution{
+			{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+			{OrderID: 1, Type: ExecutionTypeCanceled, Status: OrderStatusCanceled},
+		})
+		if _, err := e.Cancel("client", 1); !errors.Is(err, ErrorOrderDoesNotExist) {
+			t.Errorf("error cancelling a cancelled order is %v, expected %v", err, ErrorOrderDoesNotExist)
+		}
+	})
+
+	t.Run("cancel arriving before the order", func(t *testing.T) {
+		e := newTestEngine(t, config.OrdersConfig{Latency: latency}, quote)
+		e.submit(t, OrderRequest{Side: ticker.SideBuy, Type: OrderTypeMarket, Size: 50})
+		if _, err := e.Cancel("client", 1); err != nil {
+			t.Fatalf("error cancelling order: %v", err)
+		}
+
+		// The cancel overtakes the order, so the order is cancelled as soon as it arrives, before it can fill.
+		e.clock.fire(1)
+		e.checkExecutions(t, []testExecution{})
+		e.clock.fire(0)
+		e.checkExecutions(t, []testExecution{
+			{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusPendingCancel},
+			{OrderID: 1, Type: ExecutionTypeCanceled, Status: OrderStatusCanceled},
+		})
+		if len(e.ticker.executions) != 0 {
+			t.Errorf("ticker executions are %v, expected none", e.ticker.executions)
+		}
+	})
+
+	t.Run("order filled before the cancel arrives", func(t *testing.T) {
+		e := newTestEngine(t, config.OrdersConfig{Latency: latency}, quote)
+		e.submit(t, OrderRequest{Side: ticker.SideSell, Type: OrderTypeLimit, Size: 50, LimitPrice: 100})
+		e.clock.advance(latency)
+		if _, err := e.Cancel("client", 1); err != nil {
+			t.Fatalf("error cancelling order: %v", err)
+		}
+		e.update(testQuote(100.05, 100.15, 100))
+		e.clock.advance(latency)
+		e.checkExecutions(t, []testExecution{
+			{OrderID: 1, Type: ExecutionTypeNew, Status: OrderStatusNew},
+			{OrderID: 1, Type: ExecutionTypeTrade, Status: OrderStatusFilled, FillSize: 50, FillPrice: 100},
+		})
+	})
+
+	t.Run("cancel of an order of another client", func(t *testing.T) {
+		e := newTestEngine(t, config.OrdersConfig{}, quote)
+		e.submit(t, OrderRequest{Side: ticker.SideBuy, Type: OrderTypeLimit, Size: 50, LimitPrice: 100})
+		if _, err := e.Cancel("other", 1); !errors.Is(err, ErrorOrderDoesNotExist) {
+			t.Errorf("error cancelling an order of another client is %v, expected %v", err, ErrorOrderDoesNotExist)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package orders
+
+import (
+	"sync/atomic"
+)
+
+// A subscription to the executions of an Engine.
+// Executions are received from the channel C, which is closed when the subscription is cancelled
+// or the engine stops.
+type ExecutionSubscription struct {
+	C <-chan Execution
+
+	executions chan Execution
+	engine     *Engine
+	dropped    atomic.Uint64
+}
+
+// Subscribe to all future executions, of every client, buffering at most bufferSize executions.
+// Executions are dropped while the buffer is full, so the buffer should be large enough for any burst of fills.
+func (e *Engine) Subscribe(bufferSize int) *ExecutionSubscription {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	executions := make(chan Execution, bufferSize)
+	subscription := &ExecutionSubscription{
+		C:          executions,
+		executions: executions,
+		engine:     e,
+	}
+	if e.closed {
+		close(executions)
+		return subscription
+	}
+	e.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Cancel the subscription, closing the channel C. Executions already buffered may still be received.
+// Safe to call more than once, and after the engine stops.
+func (s *ExecutionSubscription) Unsubscribe() {
+	s.engine.mu.Lock()
+	defer s.engine.mu.Unlock()
+
+	if _, ok := s.engine.subscribers[s]; !ok {
+		return
+	}
+	delete(s.engine.subscribers, s)
+	close(s.executions)
+}
+
+// Get the number of executions dropped because the subscription buffer was full.
+func (s *ExecutionSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Add a hook called with every execution, synchronously, before the execution is sent to any subscriber.
+// Unlike subscriptions, hooks never miss an execution, and have seen every execution by the time the client can,
+// so are used by anything that must stay consistent with the orders (e.g. the accounts of the clients).
+//
+// Hooks are called with the engine mutex locked, so must be quick, and must not call back into the engine.
+func (e *Engine) OnExecution(hook func(Execution)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.hooks = append(e.hooks, hook)
+}
+
+// Number and timestamp an execution, call every hook, then send it to every subscriber,
+// dropping it for any subscriber whose buffer is full.
+// Executions are published with the engine mutex locked, so every hook and subscriber receives them in order.
+//
+// Does not lock the mutex, since this method is called with the mutex locked.
+func (e *Engine) publish(execution Execution) {
+	e.nextExecutionID++
+	execution.ID = e.nextExecutionID
+	execution.Timestamp = e.now()
+	for _, hook := range e.hooks {
+		hook(execution)
+	}
+	for subscription := range e.subscribers {
+		select {
+		case subscription.executions <- execution:
+		default:
+			subscription.dropped.Add(1)
+			metricExecutionsDroppedTotal.Inc()
+		}
+	}
+}
+
+// Close the channel of every subscription, once no more executions will be published.
+func (e *Engine) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return
+	}
+	e.closed = true
+	for subscription := range e.subscribers {
+		close(subscription.executions)
+	}
+	clear(e.subscribers)
+}
@@ -0,0 +1,44 @@
+package orders
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics of the order engine, registered with the default registry.
+var (
+	metricOrdersSubmittedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "orders",
+		Name:      "submitted_total",
+		Help:      "The number of simulated orders submitted, by ticker.",
+	}, []string{"ticker"})
+
+	metricOrdersRejectedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "orders",
+		Name:      "rejected_total",
+		Help:      "The number of simulated orders rejected on arrival, by reason (random, maxordersize, maxopenorders, pricecollar, marketclosed, or margin).",
+	}, []string{"reason"})
+
+	metricFillsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "orders",
+		Name:      "fills_total",
+		Help:      "The number of fills of simulated orders, by ticker.",
+	}, []string{"ticker"})
+
+	metricFilledVolumeTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "orders",
+		Name:      "filled_volume_total",
+		Help:      "The total size filled of simulated orders, by ticker.",
+	}, []string{"ticker"})
+
+	metricExecutionsDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "orders",
+		Name:      "executions_dropped_total",
+		Help:      "The number of executions dropped because the buffer of a subscriber (e.g. a StreamExecutions stream) was full.",
+	})
+)
@@ -0,0 +1,94 @@
+package servers
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+)
+
+// A connect interceptor writing one structured log line per RPC, with the procedure, client, status code, and duration.
+// Successful RPCs are sampled (see config.AccessLogConfig.SampleRate), since at high request rates
+// logging every request is far more expensive than the request itself. Failed RPCs are always logged,
+// except those rejected by the rate limiter, which are sampled too so a flooding client cannot flood the logs.
+type AccessLogInterceptor struct {
+	sampleRate   float64
+	successLevel slog.Level
+}
+
+func NewAccessLogInterceptor(cfg config.AccessLogConfig) *AccessLogInterceptor {
+	successLevel := slog.LevelInfo
+	if cfg.Level == "debug" {
+		successLevel = slog.LevelDebug
+	}
+	return &AccessLogInterceptor{
+		sampleRate:   cfg.SampleRate,
+		successLevel: successLevel,
+	}
+}
+
+func (i *AccessLogInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, client := withCallClient(ctx, req.Peer())
+		startTime := time.Now()
+		res, err := next(ctx, req)
+		i.log(ctx, req.Spec(), req.Peer(), client, err, time.Since(startTime))
+		return res, err
+	}
+}
+
+// Client streams are passed through untouched, see NewInterceptors.
+func (i *AccessLogInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *AccessLogInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, client := withCallClient(ctx, conn.Peer())
+		startTime := time.Now()
+		err := next(ctx, conn)
+		i.log(ctx, conn.Spec(), conn.Peer(), client, err, time.Since(startTime))
+		return err
+	}
+}
+
+func (i *AccessLogInterceptor) wrapHTTP(procedure string, next http.Handler) http.Handler {
+	spec := connect.Spec{Procedure: procedure}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, client := withCallClient(r.Context(), httpPeer(r))
+		startTime := time.Now()
+		next.ServeHTTP(w, r.WithContext(ctx))
+		i.log(ctx, spec, httpPeer(r), client, w.(*httpResponseRecorder).Err(), time.Since(startTime))
+	})
+}
+
+func (i *AccessLogInterceptor) log(ctx context.Context, spec connect.Spec, peer connect.Peer, client *callClient, err error, duration time.Duration) {
+	level := i.successLevel
+	code := "ok"
+	sampled := true
+	if err != nil {
+		level = slog.LevelWarn
+		code = connect.CodeOf(err).String()
+		sampled = connect.CodeOf(err) == connect.CodeResourceExhausted
+	}
+	if !slog.Default().Enabled(ctx, level) || (sampled && rand.Float64() >= i.sampleRate) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("procedure", spec.Procedure),
+		slog.String("protocol", peer.Protocol),
+		slog.String("peer", peer.Addr),
+		slog.String("client", client.name),
+		slog.String("code", code),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("err", err))
+	}
+	slog.LogAttrs(ctx, level, "rpc handled", attrs...)
+}
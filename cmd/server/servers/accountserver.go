@@ -0,0 +1,114 @@
+package servers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/accounts"
+	"github.com/hmcalister/genron/cmd/server/config"
+	tickerv1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+)
+
+// Serves the accounts of the clients of the OrderService.
+// Each client sees only its own account, so without authentication every client shares the same account.
+type AccountServer struct {
+	// The accounts, or nil if order entry is disabled.
+	book *accounts.Book
+	cfg  config.AccountsConfig
+
+	// Closed by Shutdown, to end every stream.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewAccountServer(book *accounts.Book, cfg config.AccountsConfig) *AccountServer {
+	return &AccountServer{
+		book: book,
+		cfg:  cfg,
+		done: make(chan struct{}),
+	}
+}
+
+// End every stream, see the graceful shutdown in main.
+func (serv *AccountServer) Shutdown() {
+	serv.doneOnce.Do(func() {
+		close(serv.done)
+	})
+}
+
+func (serv *AccountServer) GetPortfolio(
+	ctx context.Context,
+	req *connect.Request[tickerv1.GetPortfolioRequest],
+) (*connect.Response[tickerv1.GetPortfolioResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if serv.book == nil {
+		return nil, ErrorOrdersDisabled
+	}
+
+	res := connect.NewResponse(&tickerv1.GetPortfolioResponse{
+		Portfolio: newPortfolioMessage(serv.book.Portfolio(ClientName(ctx))),
+	})
+	return res, nil
+}
+
+// Stream the portfolio of the client every stream interval, starting immediately,
+// until the client disconnects or the server shuts down.
+func (serv *AccountServer) StreamPortfolio(
+	ctx context.Context,
+	req *connect.Request[tickerv1.StreamPortfolioRequest],
+	stream *connect.ServerStream[tickerv1.StreamPortfolioResponse],
+) error {
+	if serv.book == nil {
+		return ErrorOrdersDisabled
+	}
+
+	clientName := ClientName(ctx)
+	timer := time.NewTicker(serv.cfg.StreamInterval)
+	defer timer.Stop()
+	for {
+		if err := stream.Send(&tickerv1.StreamPortfolioResponse{
+			Portfolio: newPortfolioMessage(serv.book.Portfolio(clientName)),
+		}); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-serv.done:
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+func newPortfolioMessage(portfolio accounts.Portfolio) *tickerv1.Portfolio {
+	positionMessages := make([]*tickerv1.Position, 0, len(portfolio.Positions))
+	for _, position := range portfolio.Positions {
+		positionMessages = append(positionMessages, &tickerv1.Position{
+			TickerName:    position.TickerName,
+			Size:          position.Size,
+			AveragePrice:  position.AveragePrice,
+			MarketPrice:   position.MarketPrice,
+			MarketValue:   position.MarketValue,
+			RealisedPnl:   position.RealisedPnL,
+			UnrealisedPnl: position.UnrealisedPnL,
+		})
+	}
+	return &tickerv1.Portfolio{
+		ClientName:    portfolio.ClientName,
+		Timestamp:     portfolio.Timestamp.UnixNano(),
+		Cash:          portfolio.Cash,
+		Position:      positionMessages,
+		MarketValue:   portfolio.MarketValue,
+		Equity:        portfolio.Equity,
+		RealisedPnl:   portfolio.RealisedPnL,
+		UnrealisedPnl: portfolio.UnrealisedPnL,
+		Margin:        portfolio.Margin,
+		ExcessMargin:  portfolio.ExcessMargin,
+	}
+}
@@ -0,0 +1,136 @@
+package servers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/gen/api/grpc/health/v1/healthv1connect"
+)
+
+// The authentication modes of the AuthInterceptor.
+const (
+	// No authentication, every client is allowed.
+	AuthModeNone = "none"
+
+	// Clients send an API key in a header (by default X-API-Key).
+	AuthModeAPIKey = "apikey"
+
+	// Clients send a token in the Authorization header, as "Bearer <token>".
+	AuthModeBearer = "bearer"
+)
+
+var (
+	ErrorUnauthenticated = connect.NewError(connect.CodeUnauthenticated, errors.New("missing or invalid credentials"))
+)
+
+// A connect interceptor rejecting any RPC without a valid API key or bearer token.
+// The client name of the matching key is set on the call, for logging and rate limiting.
+//
+// The health service is exempt, since Kubernetes gRPC probes cannot send credentials.
+type AuthInterceptor struct {
+	mode   string
+	header string
+	keys   []authKey
+}
+
+type authKey struct {
+	clientName string
+	key        []byte
+}
+
+func NewAuthInterceptor(cfg config.AuthConfig) (*AuthInterceptor, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, fmt.Errorf("auth mode %q requires at least one key", cfg.Mode)
+	}
+
+	i := &AuthInterceptor{
+		mode:   cfg.Mode,
+		header: cfg.Header,
+		keys:   make([]authKey, 0, len(cfg.Keys)),
+	}
+	if i.mode == AuthModeBearer {
+		i.header = "Authorization"
+	}
+	for clientName, key := range cfg.Keys {
+		if key == "" {
+			return nil, fmt.Errorf("auth key of client %q is empty", clientName)
+		}
+		i.keys = append(i.keys, authKey{clientName: clientName, key: []byte(key)})
+	}
+	return i, nil
+}
+
+func (i *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, client := withCallClient(ctx, req.Peer())
+		if err := i.authenticate(req.Spec(), req.Header(), client); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// Client streams are passed through untouched, see NewInterceptors.
+func (i *AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, client := withCallClient(ctx, conn.Peer())
+		if err := i.authenticate(conn.Spec(), conn.RequestHeader(), client); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+func (i *AuthInterceptor) wrapHTTP(procedure string, next http.Handler) http.Handler {
+	spec := connect.Spec{Procedure: procedure}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, client := withCallClient(r.Context(), httpPeer(r))
+		if err := i.authenticate(spec, r.Header, client); err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Check the credentials in the request header, and name the client of the call after the matching key.
+func (i *AuthInterceptor) authenticate(spec connect.Spec, header http.Header, client *callClient) error {
+	if strings.HasPrefix(spec.Procedure, "/"+healthv1connect.HealthName+"/") {
+		return nil
+	}
+
+	credential := header.Get(i.header)
+	if i.mode == AuthModeBearer {
+		token, ok := strings.CutPrefix(credential, "Bearer ")
+		if !ok {
+			return ErrorUnauthenticated
+		}
+		credential = token
+	}
+	if credential == "" {
+		return ErrorUnauthenticated
+	}
+
+	// Every key is compared (in constant time), so the time taken does not reveal which key, if any, nearly matched.
+	matchedClientName := ""
+	for _, k := range i.keys {
+		if subtle.ConstantTimeCompare([]byte(credential), k.key) == 1 {
+			matchedClientName = k.clientName
+		}
+	}
+	if matchedClientName == "" {
+		return ErrorUnauthenticated
+	}
+	client.name = matchedClientName
+	return nil
+}
@@ -0,0 +1,314 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// The types of feed messages.
+const (
+	// Client to server: add tickers to the subscription, and optionally change the throttle.
+	feedMessageSubscribe = "subscribe"
+
+	// Client to server: remove tickers from the subscription.
+	feedMessageUnsubscribe = "unsubscribe"
+
+	// Server to client: the current subscription, sent after every subscribe or unsubscribe.
+	feedMessageSubscribed = "subscribed"
+
+	// Server to client: a ticker update.
+	feedMessageUpdate = "update"
+
+	// Server to client: an invalid request. The connection stays open.
+	feedMessageError = "error"
+)
+
+const (
+	feedWebSocketProcedure        = "GET /ws"
+	feedServerSentEventsProcedure = "GET /sse"
+)
+
+// Subscribing to this ticker name subscribes to every ticker.
+const feedAllTickers = "*"
+
+// A message of a feed, in either direction. Only the fields relevant to the type are set.
+type feedMessage struct {
+	Type string `json:"type"`
+
+	// Subscribe, unsubscribe, and subscribed.
+	Tickers  []string `json:"tickers,omitempty"`
+	Throttle string   `json:"throttle,omitempty"`
+
+	// Update.
+	Name      string        `json:"name,omitempty"`
+	Timestamp *time.Time    `json:"timestamp,omitempty"`
+	Value     *float64      `json:"value,omitempty"`
+	Quote     *ticker.Quote `json:"quote,omitempty"`
+
+	// Error.
+	Message string `json:"message,omitempty"`
+}
+
+// Pushes ticker updates as JSON to browsers, over WebSockets (at /ws) and Server-Sent Events (at /sse).
+// Every connection has its own subscription to the update broadcaster, filtered to the subscribed tickers.
+//
+// Updates are throttled per connection: at most one update of each ticker is pushed per throttle interval,
+// and it is always the latest. A slow connection therefore only sees fewer, fresher updates.
+type FeedServer struct {
+	tickers      map[string]ticker.Ticker
+	updates      *ticker.UpdateBroadcaster
+	cfg          config.FeedConfig
+	interceptors []connect.Interceptor
+
+	// Closed by Shutdown, to end every connection.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewFeedServer(tickers map[string]ticker.Ticker, updates *ticker.UpdateBroadcaster, cfg config.FeedConfig, interceptors []connect.Interceptor) *FeedServer {
+	return &FeedServer{
+		tickers:      tickers,
+		updates:      updates,
+		cfg:          cfg,
+		interceptors: interceptors,
+		done:         make(chan struct{}),
+	}
+}
+
+// Register the /ws and /sse endpoints on the given mux.
+func (f *FeedServer) Register(mux *http.ServeMux) {
+	mux.Handle(feedWebSocketProcedure, WrapHTTPHandler(feedWebSocketProcedure, http.HandlerFunc(f.serveWebSocket), f.interceptors))
+	mux.Handle(feedServerSentEventsProcedure, WrapHTTPHandler(feedServerSentEventsProcedure, http.HandlerFunc(f.serveServerSentEvents), f.interceptors))
+}
+
+// End every feed connection, see the graceful shutdown in main.
+func (f *FeedServer) Shutdown() {
+	f.doneOnce.Do(func() {
+		close(f.done)
+	})
+}
+
+// Whether a browser page of the given origin may connect.
+// Requests without an origin (i.e. not from a browser) and requests from the server origin itself are always allowed.
+func (f *FeedServer) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(originURL.Host, r.Host) {
+		return true
+	}
+	for _, pattern := range f.cfg.AllowedOrigins {
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(originURL.Host)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// --------------------------------------------------------------------------------
+
+// The state of a single feed connection.
+type feedSession struct {
+	feed *FeedServer
+
+	// The procedure of the endpoint, for metrics.
+	procedure string
+
+	allTickers        bool
+	subscribedTickers map[string]struct{}
+	throttle          time.Duration
+
+	// The latest update of each ticker since the last flush, when throttled.
+	pendingUpdates map[string]ticker.TickerUpdate
+
+	// Send a message to the client. Called only from the session goroutine.
+	send func(feedMessage) error
+}
+
+func (f *FeedServer) newFeedSession(procedure string, send func(feedMessage) error) *feedSession {
+	return &feedSession{
+		feed:              f,
+		procedure:         procedure,
+		subscribedTickers: make(map[string]struct{}),
+		throttle:          max(f.cfg.DefaultThrottle, f.cfg.MinThrottle),
+		pendingUpdates:    make(map[string]ticker.TickerUpdate),
+		send:              send,
+	}
+}
+
+// Parse the initial subscription of a connection from the query parameters, e.g. ?tickers=a,b&throttle=250ms.
+// Returns nil if there is no initial subscription.
+func initialFeedRequest(r *http.Request) *feedMessage {
+	query := r.URL.Query()
+	if !query.Has("tickers") {
+		return nil
+	}
+	return &feedMessage{
+		Type:     feedMessageSubscribe,
+		Tickers:  strings.Split(query.Get("tickers"), ","),
+		Throttle: query.Get("throttle"),
+	}
+}
+
+// Apply a subscribe or unsubscribe request, returning the reply to send.
+// Requests which could not be parsed are given as errors, and returned unchanged.
+func (s *feedSession) apply(request feedMessage) feedMessage {
+	if request.Type == feedMessageError {
+		return request
+	}
+
+	var unknownTickers []string
+	for _, tickerName := range request.Tickers {
+		if _, ok := s.feed.tickers[tickerName]; !ok && tickerName != feedAllTickers {
+			unknownTickers = append(unknownTickers, tickerName)
+		}
+	}
+	if len(unknownTickers) > 0 {
+		return feedMessage{Type: feedMessageError, Message: fmt.Sprintf("no tickers exist with names %v", unknownTickers)}
+	}
+
+	switch request.Type {
+	case feedMessageSubscribe:
+		if request.Throttle != "" {
+			throttle, err := time.ParseDuration(request.Throttle)
+			if err != nil || throttle < 0 {
+				return feedMessage{Type: feedMessageError, Message: "throttle must be a non-negative duration such as \"100ms\""}
+			}
+			// Clients may slow down their feed, but not speed it up past the minimum throttle.
+			s.throttle = max(throttle, s.feed.cfg.MinThrottle)
+		}
+		for _, tickerName := range request.Tickers {
+			if tickerName == feedAllTickers {
+				s.allTickers = true
+			} else {
+				s.subscribedTickers[tickerName] = struct{}{}
+			}
+		}
+	case feedMessageUnsubscribe:
+		for _, tickerName := range request.Tickers {
+			if tickerName == feedAllTickers {
+				s.allTickers = false
+				clear(s.subscribedTickers)
+			} else {
+				delete(s.subscribedTickers, tickerName)
+			}
+			delete(s.pendingUpdates, tickerName)
+		}
+	default:
+		return feedMessage{Type: feedMessageError, Message: fmt.Sprintf("unknown message type %q, expected subscribe or unsubscribe", request.Type)}
+	}
+
+	subscribedTickers := slices.Sorted(maps.Keys(s.subscribedTickers))
+	if s.allTickers {
+		subscribedTickers = append([]string{feedAllTickers}, subscribedTickers...)
+	}
+	return feedMessage{Type: feedMessageSubscribed, Tickers: subscribedTickers, Throttle: s.throttle.String()}
+}
+
+func (s *feedSession) wants(tickerName string) bool {
+	if s.allTickers {
+		return true
+	}
+	_, ok := s.subscribedTickers[tickerName]
+	return ok
+}
+
+func newFeedUpdateMessage(update ticker.TickerUpdate) feedMessage {
+	return feedMessage{
+		Type:      feedMessageUpdate,
+		Name:      update.Name,
+		Timestamp: &update.Timestamp,
+		Value:     &update.Value,
+		Quote:     &update.Quote,
+	}
+}
+
+// Push subscribed updates to the client, and apply requests from the client, until the client disconnects
+// (the context is cancelled or the requests channel is closed), the feed server shuts down, or a send fails.
+// A nil requests channel (e.g. for Server-Sent Events) is never read.
+func (s *feedSession) run(ctx context.Context, requests <-chan feedMessage) error {
+	activeStreams := metricActiveStreams.WithLabelValues(s.procedure)
+	activeStreams.Inc()
+	defer activeStreams.Dec()
+
+	subscription := s.feed.updates.Subscribe(s.feed.cfg.BufferSize)
+	defer subscription.Unsubscribe()
+
+	// The flush timer only runs while throttled.
+	var flushTimer *time.Ticker
+	var flushC <-chan time.Time
+	resetFlushTimer := func() {
+		if flushTimer != nil {
+			flushTimer.Stop()
+			flushTimer, flushC = nil, nil
+		}
+		if s.throttle > 0 {
+			flushTimer = time.NewTicker(s.throttle)
+			flushC = flushTimer.C
+		}
+	}
+	resetFlushTimer()
+	defer func() {
+		if flushTimer != nil {
+			flushTimer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.feed.done:
+			return nil
+		case update, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			if !s.wants(update.Name) {
+				continue
+			}
+			if s.throttle == 0 {
+				if err := s.send(newFeedUpdateMessage(update)); err != nil {
+					return err
+				}
+				continue
+			}
+			s.pendingUpdates[update.Name] = update
+		case <-flushC:
+			for _, update := range s.pendingUpdates {
+				if err := s.send(newFeedUpdateMessage(update)); err != nil {
+					return err
+				}
+			}
+			clear(s.pendingUpdates)
+		case request, ok := <-requests:
+			if !ok {
+				return nil
+			}
+			previousThrottle := s.throttle
+			if err := s.send(s.apply(request)); err != nil {
+				return err
+			}
+			if s.throttle != previousThrottle {
+				resetFlushTimer()
+			}
+		}
+	}
+}
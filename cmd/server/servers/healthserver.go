@@ -0,0 +1,140 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"connectrpc.com/connect"
+	healthv1 "github.com/hmcalister/genron/gen/api/grpc/health/v1"
+)
+
+var (
+	ErrorHealthServiceUnknown = connect.NewError(connect.CodeNotFound, errors.New("no service is known with requested name"))
+)
+
+// Implements the standard gRPC health checking protocol (grpc.health.v1.Health),
+// as used by Kubernetes gRPC probes, grpcurl, grpc_health_probe, and load balancers.
+//
+// The empty service name is the overall health of the server. Every other service must be added with SetServingStatus,
+// and is then reported until the server stops.
+type HealthServer struct {
+	mu       sync.RWMutex
+	statuses map[string]healthv1.HealthCheckResponse_ServingStatus
+
+	// Closed (and replaced) whenever any status changes, to wake every Watch.
+	statusChanged chan struct{}
+
+	// Set by Shutdown, after which every Watch returns once it has sent the final status.
+	shutdown bool
+}
+
+// Create a new health server, with the overall health and each of the given services NOT_SERVING.
+func NewHealthServer(serviceNames ...string) *HealthServer {
+	serv := &HealthServer{
+		statuses:      make(map[string]healthv1.HealthCheckResponse_ServingStatus),
+		statusChanged: make(chan struct{}),
+	}
+	serv.statuses[""] = healthv1.HealthCheckResponse_NOT_SERVING
+	for _, serviceName := range serviceNames {
+		serv.statuses[serviceName] = healthv1.HealthCheckResponse_NOT_SERVING
+	}
+	return serv
+}
+
+// Set the status of the given service, notifying any watchers.
+// The empty service name sets the overall health of the server.
+func (serv *HealthServer) SetServingStatus(serviceName string, status healthv1.HealthCheckResponse_ServingStatus) {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+
+	serv.statuses[serviceName] = status
+	close(serv.statusChanged)
+	serv.statusChanged = make(chan struct{})
+}
+
+// Set the status of every known service (and the overall health), notifying any watchers.
+func (serv *HealthServer) SetAllServingStatus(status healthv1.HealthCheckResponse_ServingStatus) {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+
+	for serviceName := range serv.statuses {
+		serv.statuses[serviceName] = status
+	}
+	close(serv.statusChanged)
+	serv.statusChanged = make(chan struct{})
+}
+
+// Set every service NOT_SERVING, and end every Watch once it has sent the final status.
+// Clients are expected to retry a finished Watch, which by then the server refuses.
+func (serv *HealthServer) Shutdown() {
+	serv.mu.Lock()
+	defer serv.mu.Unlock()
+
+	for serviceName := range serv.statuses {
+		serv.statuses[serviceName] = healthv1.HealthCheckResponse_NOT_SERVING
+	}
+	serv.shutdown = true
+	close(serv.statusChanged)
+	serv.statusChanged = make(chan struct{})
+}
+
+// Get the status of the given service, whether the service is known, whether the server is shutting down,
+// and a channel closed on the next change of any status.
+func (serv *HealthServer) getServingStatus(serviceName string) (healthv1.HealthCheckResponse_ServingStatus, bool, bool, <-chan struct{}) {
+	serv.mu.RLock()
+	defer serv.mu.RUnlock()
+
+	status, ok := serv.statuses[serviceName]
+	return status, ok, serv.shutdown, serv.statusChanged
+}
+
+func (serv *HealthServer) Check(
+	ctx context.Context,
+	req *connect.Request[healthv1.HealthCheckRequest],
+) (*connect.Response[healthv1.HealthCheckResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	status, ok, _, _ := serv.getServingStatus(req.Msg.Service)
+	if !ok {
+		return nil, ErrorHealthServiceUnknown
+	}
+
+	res := connect.NewResponse(&healthv1.HealthCheckResponse{
+		Status: status,
+	})
+	return res, nil
+}
+
+// Send the current status of the requested service, then a new message on every change, until the client disconnects.
+// An unknown service is reported as SERVICE_UNKNOWN rather than failing the call.
+func (serv *HealthServer) Watch(
+	ctx context.Context,
+	req *connect.Request[healthv1.HealthCheckRequest],
+	stream *connect.ServerStream[healthv1.HealthCheckResponse],
+) error {
+	lastSentStatus := healthv1.HealthCheckResponse_ServingStatus(-1)
+	for {
+		status, ok, shutdown, statusChanged := serv.getServingStatus(req.Msg.Service)
+		if !ok {
+			status = healthv1.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if status != lastSentStatus {
+			if err := stream.Send(&healthv1.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			lastSentStatus = status
+		}
+		if shutdown {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-statusChanged:
+		}
+	}
+}
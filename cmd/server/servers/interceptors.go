@@ -0,0 +1,186 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+)
+
+// Create the interceptor chain wrapping every RPC handler, in order from outermost to innermost:
+// metrics, access logging, authentication, then rate limiting.
+// Authentication runs before rate limiting, so authenticated clients are limited by client name rather than address.
+// The server only handles RPCs, and never makes them, so each interceptor passes client streams through untouched.
+//
+// Returns an error if the auth config is unusable, e.g. an auth mode with no keys.
+func NewInterceptors(serverConfig config.ServerConfig) ([]connect.Interceptor, error) {
+	interceptors := []connect.Interceptor{
+		NewMetricsInterceptor(),
+	}
+	if serverConfig.AccessLog.Enabled {
+		interceptors = append(interceptors, NewAccessLogInterceptor(serverConfig.AccessLog))
+	}
+	if serverConfig.Auth.Mode != AuthModeNone {
+		authInterceptor, err := NewAuthInterceptor(serverConfig.Auth)
+		if err != nil {
+			return nil, err
+		}
+		interceptors = append(interceptors, authInterceptor)
+	}
+	if serverConfig.RateLimit.Rate > 0 {
+		interceptors = append(interceptors, NewRateLimitInterceptor(serverConfig.RateLimit))
+	}
+	return interceptors, nil
+}
+
+// The client making an RPC, shared between the interceptors of a single call.
+// Created by the outermost interceptor to need it, so that inner interceptors (i.e. authentication)
+// can name the client for outer interceptors (i.e. access logging).
+type callClient struct {
+	// The authenticated client name, or empty if the client is not authenticated.
+	name string
+
+	// The address of the client, without the port.
+	address string
+}
+
+type callClientContextKey struct{}
+
+// Get the client of the current call from the context, adding a new client (from the peer address) if there is none.
+func withCallClient(ctx context.Context, peer connect.Peer) (context.Context, *callClient) {
+	if client, ok := ctx.Value(callClientContextKey{}).(*callClient); ok {
+		return ctx, client
+	}
+
+	address := peer.Addr
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	client := &callClient{address: address}
+	return context.WithValue(ctx, callClientContextKey{}, client), client
+}
+
+// Get the authenticated name of the client making the current call, or the empty string if the client is not authenticated.
+func ClientName(ctx context.Context) string {
+	if client, ok := ctx.Value(callClientContextKey{}).(*callClient); ok {
+		return client.name
+	}
+	return ""
+}
+
+// The identity of the client, for logs and rate limiting: the authenticated name if any, otherwise the address.
+func (c *callClient) identity() string {
+	if c.name != "" {
+		return c.name
+	}
+	return c.address
+}
+
+// --------------------------------------------------------------------------------
+
+// An interceptor that also applies to plain HTTP handlers, such as the REST gateway.
+type httpInterceptor interface {
+	// Wrap the handler of the given procedure, which names the handler in logs and metrics, e.g. "GET /v1/tickers".
+	// The response writer passed to the handler is always an *httpResponseRecorder.
+	wrapHTTP(procedure string, next http.Handler) http.Handler
+}
+
+// Wrap a plain HTTP handler in the same interceptors as the RPC handlers, so that it is
+// measured, logged, authenticated, and rate limited in the same way.
+// Interceptors that do not support plain HTTP handlers are skipped.
+func WrapHTTPHandler(procedure string, handler http.Handler, interceptors []connect.Interceptor) http.Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		if interceptor, ok := interceptors[i].(httpInterceptor); ok {
+			handler = interceptor.wrapHTTP(procedure, handler)
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(&httpResponseRecorder{ResponseWriter: w, status: http.StatusOK}, r)
+	})
+}
+
+// The connect.Peer of a plain HTTP request.
+func httpPeer(r *http.Request) connect.Peer {
+	return connect.Peer{
+		Addr:     r.RemoteAddr,
+		Protocol: "http",
+	}
+}
+
+// Records the status (and, for errors written by writeHTTPError, the error) of a plain HTTP response.
+type httpResponseRecorder struct {
+	http.ResponseWriter
+	status int
+	err    error
+}
+
+func (rec *httpResponseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Allow http.ResponseController to reach the underlying response writer, e.g. to flush.
+func (rec *httpResponseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// The error of the response, as written by writeHTTPError, or a generic error for any other error status.
+func (rec *httpResponseRecorder) Err() error {
+	if rec.err != nil || rec.status < 400 {
+		return rec.err
+	}
+	code := connect.CodeUnknown
+	switch rec.status {
+	case http.StatusBadRequest:
+		code = connect.CodeInvalidArgument
+	case http.StatusNotFound:
+		code = connect.CodeNotFound
+	case http.StatusMethodNotAllowed:
+		code = connect.CodeUnimplemented
+	}
+	return connect.NewError(code, errors.New(http.StatusText(rec.status)))
+}
+
+// The HTTP status of each error code, as in the Connect protocol.
+var httpStatusOfCode = map[connect.Code]int{
+	connect.CodeCanceled:           499,
+	connect.CodeUnknown:            http.StatusInternalServerError,
+	connect.CodeInvalidArgument:    http.StatusBadRequest,
+	connect.CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+	connect.CodeNotFound:           http.StatusNotFound,
+	connect.CodeAlreadyExists:      http.StatusConflict,
+	connect.CodePermissionDenied:   http.StatusForbidden,
+	connect.CodeResourceExhausted:  http.StatusTooManyRequests,
+	connect.CodeFailedPrecondition: http.StatusBadRequest,
+	connect.CodeAborted:            http.StatusConflict,
+	connect.CodeOutOfRange:         http.StatusBadRequest,
+	connect.CodeUnimplemented:      http.StatusNotImplemented,
+	connect.CodeInternal:           http.StatusInternalServerError,
+	connect.CodeUnavailable:        http.StatusServiceUnavailable,
+	connect.CodeDataLoss:           http.StatusInternalServerError,
+	connect.CodeUnauthenticated:    http.StatusUnauthorized,
+}
+
+// Write an error to a plain HTTP response, as JSON in the same format as Connect errors: {"code": ..., "message": ...}.
+func writeHTTPError(w http.ResponseWriter, err error) {
+	code := connect.CodeOf(err)
+	message := err.Error()
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		message = connectErr.Message()
+	}
+	if rec, ok := w.(*httpResponseRecorder); ok {
+		rec.err = err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusOfCode[code])
+	json.NewEncoder(w).Encode(map[string]string{
+		"code":    code.String(),
+		"message": message,
+	})
+}
@@ -0,0 +1,89 @@
+package servers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics of the RPC servers, recorded by the MetricsInterceptor and registered with the default registry.
+var (
+	metricRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "The number of completed RPCs, by procedure and status code.",
+	}, []string{"procedure", "code"})
+
+	metricRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "genron",
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "The time taken to handle each RPC, by procedure and status code. For streaming RPCs, this is the lifetime of the stream.",
+		Buckets:   prometheus.ExponentialBuckets(1e-5, 4, 12),
+	}, []string{"procedure", "code"})
+
+	metricActiveStreams = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "genron",
+		Subsystem: "rpc",
+		Name:      "active_streams",
+		Help:      "The number of currently open streaming RPCs, by procedure.",
+	}, []string{"procedure"})
+)
+
+// A connect interceptor recording the count and latency of every RPC (by procedure and code),
+// and the number of open streams. Add to every handler with connect.WithInterceptors.
+type MetricsInterceptor struct{}
+
+func NewMetricsInterceptor() *MetricsInterceptor {
+	return &MetricsInterceptor{}
+}
+
+func (i *MetricsInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		startTime := time.Now()
+		res, err := next(ctx, req)
+		observeRequest(req.Spec().Procedure, err, time.Since(startTime))
+		return res, err
+	}
+}
+
+// Client streams are passed through untouched, see NewInterceptors.
+func (i *MetricsInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *MetricsInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		activeStreams := metricActiveStreams.WithLabelValues(procedure)
+		activeStreams.Inc()
+		defer activeStreams.Dec()
+
+		startTime := time.Now()
+		err := next(ctx, conn)
+		observeRequest(procedure, err, time.Since(startTime))
+		return err
+	}
+}
+
+func (i *MetricsInterceptor) wrapHTTP(procedure string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		next.ServeHTTP(w, r)
+		observeRequest(procedure, w.(*httpResponseRecorder).Err(), time.Since(startTime))
+	})
+}
+
+func observeRequest(procedure string, err error, duration time.Duration) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	metricRequestsTotal.WithLabelValues(procedure, code).Inc()
+	metricRequestDuration.WithLabelValues(procedure, code).Observe(duration.Seconds())
+}
@@ -0,0 +1,114 @@
+package servers
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+)
+
+// Generate the OpenAPI 3.1 document describing every endpoint of the REST gateway.
+// The document is generated from the same route table used to register the endpoints, so always matches the server.
+func OpenAPIDocument() map[string]any {
+	errorSchema := &config.JSONSchema{
+		Type: "object",
+		Properties: map[string]*config.JSONSchema{
+			"code":    {Type: "string", Description: "The error code, as in Connect and gRPC, e.g. \"not_found\"."},
+			"message": {Type: "string", Description: "A description of the error."},
+		},
+		Required: []string{"code", "message"},
+	}
+
+	paths := make(map[string]any)
+	for _, route := range (&RESTGateway{}).routes() {
+		parameters := make([]map[string]any, 0, len(route.parameters))
+		for _, parameter := range route.parameters {
+			parameters = append(parameters, map[string]any{
+				"name":        parameter.name,
+				"in":          parameter.in,
+				"required":    parameter.required,
+				"description": parameter.description,
+				"schema":      &config.JSONSchema{Type: parameter.datatype, Format: parameter.format},
+			})
+		}
+
+		pathItem, ok := paths[route.path].(map[string]any)
+		if !ok {
+			pathItem = make(map[string]any)
+			paths[route.path] = pathItem
+		}
+		pathItem[strings.ToLower(route.method)] = map[string]any{
+			"operationId": route.operationID,
+			"summary":     route.summary,
+			"parameters":  parameters,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": typeSchema(reflect.TypeOf(route.response), "")},
+					},
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": errorSchema},
+					},
+				},
+			},
+		}
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "GEnron REST API",
+			"version":     "v1",
+			"description": "Plain HTTP GET endpoints returning JSON, alongside the Connect, gRPC, and gRPC-Web API. Endpoints are authenticated and rate limited in the same way as RPCs, if configured.",
+		},
+		"paths": paths,
+	}
+}
+
+// Generate the JSON Schema of a response type, from the json and description tags of each field.
+// Fields tagged omitempty are not required.
+func typeSchema(t reflect.Type, description string) *config.JSONSchema {
+	if t.Kind() == reflect.Pointer {
+		return typeSchema(t.Elem(), description)
+	}
+	schema := &config.JSONSchema{
+		Description: description,
+	}
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		schema.Type = "string"
+		schema.Format = "date-time"
+	case t.Kind() == reflect.String:
+		schema.Type = "string"
+	case t.Kind() == reflect.Bool:
+		schema.Type = "boolean"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		schema.Type = "number"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		schema.Type = "integer"
+	case t.Kind() == reflect.Slice:
+		schema.Type = "array"
+		schema.Items = typeSchema(t.Elem(), "")
+	case t.Kind() == reflect.Struct:
+		schema.Type = "object"
+		schema.Properties = make(map[string]*config.JSONSchema)
+		for i := range t.NumField() {
+			field := t.Field(i)
+			jsonName, jsonOptions, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if jsonName == "" || jsonName == "-" {
+				continue
+			}
+			schema.Properties[jsonName] = typeSchema(field.Type, field.Tag.Get("description"))
+			if !slices.Contains(strings.Split(jsonOptions, ","), "omitempty") {
+				schema.Required = append(schema.Required, jsonName)
+			}
+		}
+	}
+	return schema
+}
@@ -0,0 +1,152 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	tickerv1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+)
+
+const (
+	// The number of levels of each side returned when no depth is requested, and the most that may be requested.
+	defaultOrderBookDepth = 10
+	maxOrderBookDepth     = 1000
+)
+
+var (
+	ErrorTickerHasNoOrderBook = connect.NewError(connect.CodeFailedPrecondition, errors.New("requested ticker has no order book"))
+	ErrorInvalidDepth         = connect.NewError(connect.CodeInvalidArgument, errors.New("depth must be between 0 and 1000"))
+)
+
+// Serves the depth of the tickers with an order book, such as OrderBook tickers.
+type OrderBookServer struct {
+	// A map from ticker name to ticker structs
+	Tickers map[string]ticker.Ticker
+
+	// The broadcaster of ticker updates, for the streaming RPCs.
+	updates *ticker.UpdateBroadcaster
+	cfg     config.StreamConfig
+
+	// Closed by Shutdown, to end every stream.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewOrderBookServer(tickers map[string]ticker.Ticker, updates *ticker.UpdateBroadcaster, cfg config.StreamConfig) *OrderBookServer {
+	return &OrderBookServer{
+		Tickers: tickers,
+		updates: updates,
+		cfg:     cfg,
+		done:    make(chan struct{}),
+	}
+}
+
+// End every stream, see the graceful shutdown in main.
+func (serv *OrderBookServer) Shutdown() {
+	serv.doneOnce.Do(func() {
+		close(serv.done)
+	})
+}
+
+func (serv *OrderBookServer) GetOrderBook(
+	ctx context.Context,
+	req *connect.Request[tickerv1.GetOrderBookRequest],
+) (*connect.Response[tickerv1.GetOrderBookResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	source, depth, err := serv.orderBookSource(req.Msg.TickerName, req.Msg.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	res := connect.NewResponse(&tickerv1.GetOrderBookResponse{
+		OrderBook: newOrderBookMessage(req.Msg.TickerName, source.GetOrderBook(depth)),
+	})
+	return res, nil
+}
+
+// Stream the order book of a ticker after every update, until the client disconnects or the server shuts down.
+// The book is read when each response is sent, so a slow client may see the book after a later update.
+func (serv *OrderBookServer) StreamOrderBook(
+	ctx context.Context,
+	req *connect.Request[tickerv1.StreamOrderBookRequest],
+	stream *connect.ServerStream[tickerv1.StreamOrderBookResponse],
+) error {
+	source, depth, err := serv.orderBookSource(req.Msg.TickerName, req.Msg.Depth)
+	if err != nil {
+		return err
+	}
+
+	subscription := serv.updates.Subscribe(serv.cfg.BufferSize)
+	defer subscription.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-serv.done:
+			return nil
+		case update, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			if update.Name != req.Msg.TickerName {
+				continue
+			}
+			if err := stream.Send(&tickerv1.StreamOrderBookResponse{
+				OrderBook: newOrderBookMessage(req.Msg.TickerName, source.GetOrderBook(depth)),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Get the order book source of the named ticker, and the number of levels to return for the requested depth.
+func (serv *OrderBookServer) orderBookSource(tickerName string, requestedDepth int32) (ticker.OrderBookSource, int, error) {
+	requestedTicker, ok := serv.Tickers[tickerName]
+	if !ok {
+		return nil, 0, ErrorTickerDoesNotExist
+	}
+	source, ok := requestedTicker.(ticker.OrderBookSource)
+	if !ok {
+		return nil, 0, ErrorTickerHasNoOrderBook
+	}
+	if requestedDepth < 0 || requestedDepth > maxOrderBookDepth {
+		return nil, 0, ErrorInvalidDepth
+	}
+	depth := int(requestedDepth)
+	if depth == 0 {
+		depth = defaultOrderBookDepth
+	}
+	return source, depth, nil
+}
+
+func newOrderBookMessage(tickerName string, depth ticker.OrderBookDepth) *tickerv1.OrderBook {
+	orderBook := &tickerv1.OrderBook{
+		TickerName: tickerName,
+		Timestamp:  depth.Timestamp.UnixNano(),
+		Bid:        make([]*tickerv1.PriceLevel, 0, len(depth.Bids)),
+		Ask:        make([]*tickerv1.PriceLevel, 0, len(depth.Asks)),
+	}
+	for _, level := range depth.Bids {
+		orderBook.Bid = append(orderBook.Bid, newPriceLevelMessage(level))
+	}
+	for _, level := range depth.Asks {
+		orderBook.Ask = append(orderBook.Ask, newPriceLevelMessage(level))
+	}
+	return orderBook
+}
+
+func newPriceLevelMessage(level ticker.PriceLevel) *tickerv1.PriceLevel {
+	return &tickerv1.PriceLevel{
+		Price:      level.Price,
+		Size:       level.Size,
+		OrderCount: int32(level.Orders),
+	}
+}
@@ -0,0 +1,229 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/orders"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	tickerv1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+)
+
+var (
+	ErrorOrdersDisabled    = connect.NewError(connect.CodeFailedPrecondition, errors.New("order entry is disabled"))
+	ErrorOrderDoesNotExist = connect.NewError(connect.CodeNotFound, orders.ErrorOrderDoesNotExist)
+)
+
+// Serves the simulated order entry of the order engine.
+// Orders belong to the authenticated client submitting them, so without authentication every client shares the same orders.
+type OrderServer struct {
+	// The order engine, or nil if order entry is disabled.
+	engine *orders.Engine
+	cfg    config.StreamConfig
+
+	// Closed by Shutdown, to end every stream.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewOrderServer(engine *orders.Engine, cfg config.StreamConfig) *OrderServer {
+	return &OrderServer{
+		engine: engine,
+		cfg:    cfg,
+		done:   make(chan struct{}),
+	}
+}
+
+// End every stream, see the graceful shutdown in main.
+func (serv *OrderServer) Shutdown() {
+	serv.doneOnce.Do(func() {
+		close(serv.done)
+	})
+}
+
+func (serv *OrderServer) SubmitOrder(
+	ctx context.Context,
+	req *connect.Request[tickerv1.SubmitOrderRequest],
+) (*connect.Response[tickerv1.SubmitOrderResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if serv.engine == nil {
+		return nil, ErrorOrdersDisabled
+	}
+
+	order, err := serv.engine.Submit(orders.OrderRequest{
+		Owner:         ClientName(ctx),
+		ClientOrderID: req.Msg.ClientOrderId,
+		TickerName:    req.Msg.TickerName,
+		Side:          sideFromMessage(req.Msg.Side),
+		Type:          orderTypeFromMessage(req.Msg.Type),
+		TimeInForce:   timeInForceFromMessage(req.Msg.TimeInForce),
+		Size:          req.Msg.Size,
+		LimitPrice:    req.Msg.LimitPrice,
+	})
+	if errors.Is(err, orders.ErrorUnknownTicker) {
+		return nil, ErrorTickerDoesNotExist
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	res := connect.NewResponse(&tickerv1.SubmitOrderResponse{
+		Order: newOrderMessage(order),
+	})
+	return res, nil
+}
+
+func (serv *OrderServer) CancelOrder(
+	ctx context.Context,
+	req *connect.Request[tickerv1.CancelOrderRequest],
+) (*connect.Response[tickerv1.CancelOrderResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if serv.engine == nil {
+		return nil, ErrorOrdersDisabled
+	}
+
+	order, err := serv.engine.Cancel(ClientName(ctx), req.Msg.OrderId)
+	if errors.Is(err, orders.ErrorOrderDoesNotExist) {
+		return nil, ErrorOrderDoesNotExist
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	res := connect.NewResponse(&tickerv1.CancelOrderResponse{
+		Order: newOrderMessage(order),
+	})
+	return res, nil
+}
+
+// Stream the executions of the orders of the client, until the client disconnects or the server shuts down.
+// Only executions after the stream starts are sent, so clients should stream executions before submitting orders.
+func (serv *OrderServer) StreamExecutions(
+	ctx context.Context,
+	req *connect.Request[tickerv1.StreamExecutionsRequest],
+	stream *connect.ServerStream[tickerv1.StreamExecutionsResponse],
+) error {
+	if serv.engine == nil {
+		return ErrorOrdersDisabled
+	}
+
+	owner := ClientName(ctx)
+	subscription := serv.engine.Subscribe(serv.cfg.BufferSize)
+	defer subscription.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-serv.done:
+			return nil
+		case execution, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			if execution.Order.Owner != owner {
+				continue
+			}
+			if err := stream.Send(&tickerv1.StreamExecutionsResponse{
+				Execution: newExecutionMessage(execution),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newOrderMessage(order orders.Order) *tickerv1.Order {
+	return &tickerv1.Order{
+		OrderId:            order.ID,
+		ClientOrderId:      order.ClientOrderID,
+		TickerName:         order.TickerName,
+		Side:               sideMessage(order.Side),
+		Type:               orderTypeMessages[order.Type],
+		TimeInForce:        timeInForceMessages[order.TimeInForce],
+		Size:               order.Size,
+		LimitPrice:         order.LimitPrice,
+		Status:             orderStatusMessages[order.Status],
+		FilledSize:         order.FilledSize,
+		AverageFillPrice:   order.AverageFillPrice,
+		SubmittedTimestamp: order.SubmittedTimestamp.UnixNano(),
+		RejectReason:       order.RejectReason,
+	}
+}
+
+func newExecutionMessage(execution orders.Execution) *tickerv1.Execution {
+	return &tickerv1.Execution{
+		ExecutionId: execution.ID,
+		Timestamp:   execution.Timestamp.UnixNano(),
+		Type:        executionTypeMessages[execution.Type],
+		Order:       newOrderMessage(execution.Order),
+		FillSize:    execution.FillSize,
+		FillPrice:   execution.FillPrice,
+	}
+}
+
+var (
+	orderTypeMessages = map[orders.OrderType]tickerv1.OrderType{
+		orders.OrderTypeMarket: tickerv1.OrderType_ORDER_TYPE_MARKET,
+		orders.OrderTypeLimit:  tickerv1.OrderType_ORDER_TYPE_LIMIT,
+	}
+	timeInForceMessages = map[orders.TimeInForce]tickerv1.TimeInForce{
+		orders.TimeInForceGTC: tickerv1.TimeInForce_TIME_IN_FORCE_GTC,
+		orders.TimeInForceIOC: tickerv1.TimeInForce_TIME_IN_FORCE_IOC,
+	}
+	orderStatusMessages = map[orders.OrderStatus]tickerv1.OrderStatus{
+		orders.OrderStatusPendingNew:      tickerv1.OrderStatus_ORDER_STATUS_PENDING_NEW,
+		orders.OrderStatusNew:             tickerv1.OrderStatus_ORDER_STATUS_NEW,
+		orders.OrderStatusPartiallyFilled: tickerv1.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED,
+		orders.OrderStatusFilled:          tickerv1.OrderStatus_ORDER_STATUS_FILLED,
+		orders.OrderStatusPendingCancel:   tickerv1.OrderStatus_ORDER_STATUS_PENDING_CANCEL,
+		orders.OrderStatusCanceled:        tickerv1.OrderStatus_ORDER_STATUS_CANCELED,
+		orders.OrderStatusRejected:        tickerv1.OrderStatus_ORDER_STATUS_REJECTED,
+	}
+	executionTypeMessages = map[orders.ExecutionType]tickerv1.ExecutionType{
+		orders.ExecutionTypeNew:      tickerv1.ExecutionType_EXECUTION_TYPE_NEW,
+		orders.ExecutionTypeTrade:    tickerv1.ExecutionType_EXECUTION_TYPE_TRADE,
+		orders.ExecutionTypeCanceled: tickerv1.ExecutionType_EXECUTION_TYPE_CANCELED,
+		orders.ExecutionTypeRejected: tickerv1.ExecutionType_EXECUTION_TYPE_REJECTED,
+	}
+)
+
+func sideMessage(side ticker.Side) tickerv1.Side {
+	if side == ticker.SideBuy {
+		return tickerv1.Side_SIDE_BUY
+	}
+	return tickerv1.Side_SIDE_SELL
+}
+
+// Get the side of a message. An unspecified side is left empty, and so rejected by the engine.
+func sideFromMessage(side tickerv1.Side) ticker.Side {
+	switch side {
+	case tickerv1.Side_SIDE_BUY:
+		return ticker.SideBuy
+	case tickerv1.Side_SIDE_SELL:
+		return ticker.SideSell
+	}
+	return ""
+}
+
+// Get the order type of a message. An unspecified type is left empty, and so rejected by the engine.
+func orderTypeFromMessage(orderType tickerv1.OrderType) orders.OrderType {
+	for t, message := range orderTypeMessages {
+		if message == orderType {
+			return t
+		}
+	}
+	return ""
+}
+
+// Get the time in force of a message. An unspecified time in force is good till cancelled.
+func timeInForceFromMessage(timeInForce tickerv1.TimeInForce) orders.TimeInForce {
+	if timeInForce == tickerv1.TimeInForce_TIME_IN_FORCE_IOC {
+		return orders.TimeInForceIOC
+	}
+	return orders.TimeInForceGTC
+}
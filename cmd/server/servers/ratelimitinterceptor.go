@@ -0,0 +1,110 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/config"
+	"golang.org/x/time/rate"
+)
+
+var (
+	ErrorRateLimited = connect.NewError(connect.CodeResourceExhausted, errors.New("rate limit exceeded, slow down"))
+)
+
+// A connect interceptor limiting the rate of RPCs from each client with a token bucket.
+// Clients are identified by their authenticated name if any, otherwise by their address.
+// An RPC over the limit fails immediately with ResourceExhausted, rather than waiting for a token.
+//
+// A stream takes a single token when opened, regardless of how long it stays open.
+type RateLimitInterceptor struct {
+	rate        rate.Limit
+	burst       int
+	idleTimeout time.Duration
+
+	mu               sync.Mutex
+	clientLimiters   map[string]*clientLimiter
+	lastEvictionTime time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewRateLimitInterceptor(cfg config.RateLimitConfig) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		rate:             rate.Limit(cfg.Rate),
+		burst:            cfg.Burst,
+		idleTimeout:      cfg.IdleTimeout,
+		clientLimiters:   make(map[string]*clientLimiter),
+		lastEvictionTime: time.Now(),
+	}
+}
+
+func (i *RateLimitInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, client := withCallClient(ctx, req.Peer())
+		if !i.allow(client.identity()) {
+			return nil, ErrorRateLimited
+		}
+		return next(ctx, req)
+	}
+}
+
+// Client streams are passed through untouched, see NewInterceptors.
+func (i *RateLimitInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *RateLimitInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, client := withCallClient(ctx, conn.Peer())
+		if !i.allow(client.identity()) {
+			return ErrorRateLimited
+		}
+		return next(ctx, conn)
+	}
+}
+
+func (i *RateLimitInterceptor) wrapHTTP(procedure string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, client := withCallClient(r.Context(), httpPeer(r))
+		if !i.allow(client.identity()) {
+			writeHTTPError(w, ErrorRateLimited)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Take a token from the bucket of the given client, creating the bucket if needed.
+// Returns false if the bucket is empty.
+func (i *RateLimitInterceptor) allow(clientIdentity string) bool {
+	now := time.Now()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// Forget idle clients, so the map does not grow without bound when clients come and go.
+	if now.Sub(i.lastEvictionTime) > i.idleTimeout {
+		for identity, l := range i.clientLimiters {
+			if now.Sub(l.lastSeen) > i.idleTimeout {
+				delete(i.clientLimiters, identity)
+			}
+		}
+		i.lastEvictionTime = now
+	}
+
+	l, ok := i.clientLimiters[clientIdentity]
+	if !ok {
+		l = &clientLimiter{limiter: rate.NewLimiter(i.rate, i.burst)}
+		i.clientLimiters[clientIdentity] = l
+	}
+	l.lastSeen = now
+	return l.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,341 @@
+package servers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/calendar"
+	"github.com/hmcalister/genron/cmd/server/history"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+const (
+	// The number of points (or candles) returned when no limit is given.
+	restDefaultLimit = 1000
+
+	// The largest limit accepted.
+	restMaxLimit = 100000
+)
+
+var (
+	ErrorHistoryDisabled = connect.NewError(connect.CodeFailedPrecondition, errors.New("history is disabled, set history.maxpoints to enable"))
+)
+
+// Serves plain HTTP GET endpoints returning JSON, for clients that cannot easily make RPCs (e.g. curl, spreadsheets, and notebooks).
+// Every endpoint is described by the OpenAPI document served at /v1/openapi.json (see OpenAPIDocument).
+//
+// Timestamps are RFC 3339 strings with nanoseconds, e.g. "2025-01-02T15:04:05.123456789Z".
+// Errors are returned as JSON in the same format as Connect errors, e.g. {"code": "not_found", "message": "..."}.
+type RESTGateway struct {
+	// A map from ticker name to ticker structs
+	Tickers     map[string]ticker.Ticker
+	TickerNames []string
+
+	// The recent history of every ticker, for the history and candles endpoints.
+	History *history.Store
+
+	// The interceptors of the RPC handlers, which are applied to every endpoint. See WrapHTTPHandler.
+	Interceptors []connect.Interceptor
+}
+
+// A single endpoint of the REST gateway, used both to register the handler and to generate the OpenAPI document.
+type restRoute struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	parameters  []restParameter
+
+	// The zero value of the response type, for the OpenAPI document.
+	response any
+
+	// Handle a request, returning the response (encoded as JSON) or an error.
+	handle func(r *http.Request) (any, error)
+}
+
+type restParameter struct {
+	name string
+	// Either "path" or "query".
+	in          string
+	datatype    string
+	format      string
+	description string
+	required    bool
+}
+
+// --------------------------------------------------------------------------------
+// Response types. The description tags are used in the OpenAPI document.
+
+type restTicker struct {
+	Name                 string           `json:"name" description:"The ticker name."`
+	Value                float64          `json:"value" description:"The current value of the ticker."`
+	LastUpdatedTimestamp time.Time        `json:"lastUpdatedTimestamp" description:"The time of the last update of the ticker."`
+	UpdatePeriod         string           `json:"updatePeriod" description:"The expected time between updates of the ticker, e.g. \"1ms\"."`
+	Quote                restQuote        `json:"quote" description:"The current two-sided quote of the ticker, around the value."`
+	MarketStatus         restMarketStatus `json:"marketStatus" description:"The status of the market of the ticker, from the calendar of the ticker."`
+}
+
+type restMarketStatus struct {
+	Calendar           string     `json:"calendar" description:"The name of the calendar, or empty for a ticker with no calendar, which is always open."`
+	Open               bool       `json:"open" description:"Whether the market is open."`
+	NextOpenTimestamp  *time.Time `json:"nextOpenTimestamp,omitempty" description:"The next open after now. Omitted if there is none, e.g. for a ticker with no calendar."`
+	NextCloseTimestamp *time.Time `json:"nextCloseTimestamp,omitempty" description:"The close of the current session if open, otherwise of the next session. Omitted if there is none, e.g. for a ticker with no calendar."`
+}
+
+type restQuote struct {
+	Bid     float64 `json:"bid" description:"The bid price."`
+	Ask     float64 `json:"ask" description:"The ask price."`
+	BidSize int64   `json:"bidSize" description:"The size quoted at the bid."`
+	AskSize int64   `json:"askSize" description:"The size quoted at the ask."`
+	HasBid  bool    `json:"hasBid" description:"Whether the quote has a bid. A side with no liquidity (e.g. an empty side of an order book) has no price."`
+	HasAsk  bool    `json:"hasAsk" description:"Whether the quote has an ask. A side with no liquidity (e.g. an empty side of an order book) has no price."`
+}
+
+type restTickersResponse struct {
+	Tickers []restTicker `json:"tickers" description:"Every ticker, sorted by name."`
+}
+
+type restPoint struct {
+	Timestamp time.Time `json:"timestamp" description:"The time of the update."`
+	Value     float64   `json:"value" description:"The value of the ticker after the update."`
+	Volume    int64     `json:"volume" description:"The total size of the trades since the previous point."`
+}
+
+type restHistoryResponse struct {
+	Name   string      `json:"name" description:"The ticker name."`
+	Points []restPoint `json:"points" description:"The recorded updates of the ticker, oldest first."`
+}
+
+type restCandle struct {
+	StartTimestamp time.Time `json:"startTimestamp" description:"The start of the interval of the candle, aligned to the unix epoch."`
+	Open           float64   `json:"open" description:"The first value in the interval."`
+	High           float64   `json:"high" description:"The highest value in the interval."`
+	Low            float64   `json:"low" description:"The lowest value in the interval."`
+	Close          float64   `json:"close" description:"The last value in the interval."`
+	Count          int       `json:"count" description:"The number of updates in the interval."`
+	Volume         int64     `json:"volume" description:"The total size of the trades in the interval."`
+}
+
+type restCandlesResponse struct {
+	Name     string       `json:"name" description:"The ticker name."`
+	Interval string       `json:"interval" description:"The interval of each candle, e.g. \"1m0s\"."`
+	Candles  []restCandle `json:"candles" description:"The candles of the ticker, oldest first. Intervals with no updates have no candle."`
+}
+
+// --------------------------------------------------------------------------------
+
+// Register every endpoint of the gateway (and the OpenAPI document) on the given mux.
+func (g *RESTGateway) Register(mux *http.ServeMux) {
+	for _, route := range g.routes() {
+		procedure := route.method + " " + route.path
+		mux.Handle(procedure, WrapHTTPHandler(procedure, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			response, err := route.handle(r)
+			if err != nil {
+				writeHTTPError(w, err)
+				return
+			}
+			writeJSON(w, response)
+		}), g.Interceptors))
+	}
+
+	openAPIProcedure := "GET /v1/openapi.json"
+	mux.Handle(openAPIProcedure, WrapHTTPHandler(openAPIProcedure, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, OpenAPIDocument())
+	}), g.Interceptors))
+}
+
+func writeJSON(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (g *RESTGateway) routes() []restRoute {
+	tickerNameParameter := restParameter{name: "name", in: "path", datatype: "string", description: "The ticker name.", required: true}
+	fromParameter := restParameter{name: "from", in: "query", datatype: "string", description: "Only include updates at or after this time, as an RFC 3339 timestamp or integer unix nanoseconds."}
+	toParameter := restParameter{name: "to", in: "query", datatype: "string", description: "Only include updates before this time, as an RFC 3339 timestamp or integer unix nanoseconds."}
+	limitParameter := restParameter{name: "limit", in: "query", datatype: "integer", description: fmt.Sprintf("The maximum number of results, keeping the most recent. Defaults to %d, and at most %d.", restDefaultLimit, restMaxLimit)}
+
+	return []restRoute{
+		{
+			method:      http.MethodGet,
+			path:        "/v1/tickers",
+			operationID: "listTickers",
+			summary:     "Get the current value of every ticker.",
+			response:    restTickersResponse{},
+			handle:      g.listTickers,
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/v1/tickers/{name}",
+			operationID: "getTicker",
+			summary:     "Get the current value of a ticker.",
+			parameters:  []restParameter{tickerNameParameter},
+			response:    restTicker{},
+			handle:      g.getTicker,
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/v1/tickers/{name}/history",
+			operationID: "getTickerHistory",
+			summary:     "Get the recent updates of a ticker, from the in-memory history.",
+			parameters:  []restParameter{tickerNameParameter, fromParameter, toParameter, limitParameter},
+			response:    restHistoryResponse{},
+			handle:      g.getTickerHistory,
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/v1/tickers/{name}/candles",
+			operationID: "getTickerCandles",
+			summary:     "Get open, high, low, close candles of a ticker, aggregated from the in-memory history.",
+			parameters: []restParameter{
+				tickerNameParameter,
+				{name: "interval", in: "query", datatype: "string", description: "The interval of each candle, as a duration such as \"1s\" or \"5m\".", required: true},
+				fromParameter,
+				toParameter,
+				limitParameter,
+			},
+			response: restCandlesResponse{},
+			handle:   g.getTickerCandles,
+		},
+	}
+}
+
+func newRESTTicker(t ticker.Ticker) restTicker {
+	info := t.GetSnapshot()
+	return restTicker{
+		Name:                 info.Name,
+		Value:                info.Value,
+		LastUpdatedTimestamp: info.LastUpdatedTimestamp,
+		UpdatePeriod:         info.UpdatePeriod.String(),
+		Quote:                restQuote(info.Quote),
+		MarketStatus:         newRESTMarketStatus(t.GetMarketStatus(time.Now())),
+	}
+}
+
+func newRESTMarketStatus(status calendar.Status) restMarketStatus {
+	return restMarketStatus{
+		Calendar:           status.Calendar,
+		Open:               status.Open,
+		NextOpenTimestamp:  optionalTimestamp(status.NextOpen),
+		NextCloseTimestamp: optionalTimestamp(status.NextClose),
+	}
+}
+
+// Get a pointer to the given timestamp, or nil if it is the zero time, so that it is omitted from the response.
+func optionalTimestamp(timestamp time.Time) *time.Time {
+	if timestamp.IsZero() {
+		return nil
+	}
+	return &timestamp
+}
+
+func (g *RESTGateway) listTickers(r *http.Request) (any, error) {
+	tickerNames := slices.Sorted(slices.Values(g.TickerNames))
+	tickers := make([]restTicker, 0, len(tickerNames))
+	for _, tickerName := range tickerNames {
+		tickers = append(tickers, newRESTTicker(g.Tickers[tickerName]))
+	}
+	return restTickersResponse{Tickers: tickers}, nil
+}
+
+func (g *RESTGateway) getTicker(r *http.Request) (any, error) {
+	requestedTicker, ok := g.Tickers[r.PathValue("name")]
+	if !ok {
+		return nil, ErrorTickerDoesNotExist
+	}
+	return newRESTTicker(requestedTicker), nil
+}
+
+func (g *RESTGateway) getTickerHistory(r *http.Request) (any, error) {
+	tickerName, from, to, limit, err := g.parseHistoryQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	points, _ := g.History.Points(tickerName, from, to, limit)
+	response := restHistoryResponse{
+		Name:   tickerName,
+		Points: make([]restPoint, 0, len(points)),
+	}
+	for _, point := range points {
+		response.Points = append(response.Points, restPoint{Timestamp: point.Timestamp, Value: point.Value, Volume: point.Volume})
+	}
+	return response, nil
+}
+
+func (g *RESTGateway) getTickerCandles(r *http.Request) (any, error) {
+	tickerName, from, to, limit, err := g.parseHistoryQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := time.ParseDuration(r.URL.Query().Get("interval"))
+	if err != nil || interval <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("interval must be a positive duration such as \"1m\""))
+	}
+
+	candles, _ := g.History.Candles(tickerName, interval, from, to, limit)
+	response := restCandlesResponse{
+		Name:     tickerName,
+		Interval: interval.String(),
+		Candles:  make([]restCandle, 0, len(candles)),
+	}
+	for _, candle := range candles {
+		response.Candles = append(response.Candles, restCandle{
+			StartTimestamp: candle.StartTimestamp,
+			Open:           candle.Open,
+			High:           candle.High,
+			Low:            candle.Low,
+			Close:          candle.Close,
+			Count:          candle.Count,
+			Volume:         candle.Volume,
+		})
+	}
+	return response, nil
+}
+
+// Parse the ticker name, and the from, to, and limit query parameters, shared by the history and candles endpoints.
+func (g *RESTGateway) parseHistoryQuery(r *http.Request) (string, time.Time, time.Time, int, error) {
+	tickerName := r.PathValue("name")
+	if _, ok := g.Tickers[tickerName]; !ok {
+		return "", time.Time{}, time.Time{}, 0, ErrorTickerDoesNotExist
+	}
+	if !g.History.Enabled() {
+		return "", time.Time{}, time.Time{}, 0, ErrorHistoryDisabled
+	}
+
+	query := r.URL.Query()
+	from, err := parseQueryTimestamp(query.Get("from"))
+	if err != nil {
+		return "", time.Time{}, time.Time{}, 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid from: %w", err))
+	}
+	to, err := parseQueryTimestamp(query.Get("to"))
+	if err != nil {
+		return "", time.Time{}, time.Time{}, 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid to: %w", err))
+	}
+
+	limit := restDefaultLimit
+	if limitString := query.Get("limit"); limitString != "" {
+		limit, err = strconv.Atoi(limitString)
+		if err != nil || limit <= 0 || limit > restMaxLimit {
+			return "", time.Time{}, time.Time{}, 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("limit must be an integer between 1 and %d", restMaxLimit))
+		}
+	}
+	return tickerName, from, to, limit, nil
+}
+
+// Parse a timestamp given as either RFC 3339 or integer unix nanoseconds. An empty string is the zero time.
+func parseQueryTimestamp(timestampString string) (time.Time, error) {
+	if timestampString == "" {
+		return time.Time{}, nil
+	}
+	if unixNanoseconds, err := strconv.ParseInt(timestampString, 10, 64); err == nil {
+		return time.Unix(0, unixNanoseconds), nil
+	}
+	return time.Parse(time.RFC3339Nano, timestampString)
+}
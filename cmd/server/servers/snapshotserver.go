@@ -0,0 +1,98 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+
+	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	tickerv1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+)
+
+var (
+	ErrorSnapshotsDisabled     = connect.NewError(connect.CodeFailedPrecondition, errors.New("no snapshot file is configured"))
+	ErrorSnapshotDoesNotExist  = connect.NewError(connect.CodeNotFound, errors.New("no snapshot has been saved"))
+	ErrorSnapshotOrdersEnabled = connect.NewError(connect.CodeFailedPrecondition, errors.New("snapshots cannot be restored while simulated orders are enabled, since snapshots do not include orders or accounts"))
+)
+
+type SnapshotServer struct {
+	// A map from ticker name to ticker structs
+	Tickers map[string]ticker.Ticker
+
+	// The file to save snapshots to, and restore snapshots from.
+	// If empty, snapshots are disabled and all requests fail.
+	SnapshotFilePath string
+
+	// If true, restoring fails, since the working orders and accounts are not in the snapshot,
+	// so would no longer match the restored tickers.
+	OrdersEnabled bool
+}
+
+func (serv *SnapshotServer) SaveSnapshot(
+	ctx context.Context,
+	req *connect.Request[tickerv1.SaveSnapshotRequest],
+) (*connect.Response[tickerv1.SaveSnapshotResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if serv.SnapshotFilePath == "" {
+		return nil, ErrorSnapshotsDisabled
+	}
+
+	snapshot, err := ticker.SaveSnapshot(serv.SnapshotFilePath, serv.Tickers)
+	if err != nil {
+		slog.Error("error when saving snapshot", "snapshotFilePath", serv.SnapshotFilePath, "err", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	tickerNames := make([]string, 0, len(snapshot.Tickers))
+	for _, state := range snapshot.Tickers {
+		tickerNames = append(tickerNames, state.Name)
+	}
+	res := connect.NewResponse(&tickerv1.SaveSnapshotResponse{
+		SnapshotFilePath: serv.SnapshotFilePath,
+		CreatedTimestamp: snapshot.CreatedTimestamp.UnixNano(),
+		TickerName:       tickerNames,
+	})
+	return res, nil
+}
+
+func (serv *SnapshotServer) RestoreSnapshot(
+	ctx context.Context,
+	req *connect.Request[tickerv1.RestoreSnapshotRequest],
+) (*connect.Response[tickerv1.RestoreSnapshotResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if serv.SnapshotFilePath == "" {
+		return nil, ErrorSnapshotsDisabled
+	}
+	if serv.OrdersEnabled {
+		return nil, ErrorSnapshotOrdersEnabled
+	}
+
+	snapshot, err := ticker.LoadSnapshot(serv.SnapshotFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrorSnapshotDoesNotExist
+	} else if err != nil {
+		slog.Error("error when loading snapshot", "snapshotFilePath", serv.SnapshotFilePath, "err", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	restoredTickerNames, err := ticker.RestoreSnapshot(snapshot, serv.Tickers)
+	if err != nil {
+		slog.Error("error when restoring snapshot", "snapshotFilePath", serv.SnapshotFilePath, "err", err)
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	res := connect.NewResponse(&tickerv1.RestoreSnapshotResponse{
+		SnapshotFilePath: serv.SnapshotFilePath,
+		CreatedTimestamp: snapshot.CreatedTimestamp.UnixNano(),
+		TickerName:       restoredTickerNames,
+	})
+	return res, nil
+}
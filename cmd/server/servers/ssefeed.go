@@ -0,0 +1,56 @@
+package servers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Serve a Server-Sent Events feed. Each feedMessage is sent as an event named after the message type,
+// with the JSON message as the data, e.g. "event: update\ndata: {...}\n\n".
+//
+// Server-Sent Events are one way, so the subscription is given only by the query parameters,
+// e.g. /sse?tickers=a,b&throttle=250ms. To change the subscription, reconnect.
+func (f *FeedServer) serveServerSentEvents(w http.ResponseWriter, r *http.Request) {
+	if !f.originAllowed(r) {
+		writeHTTPError(w, ErrorOriginNotAllowed)
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	responseController := http.NewResponseController(w)
+	session := f.newFeedSession(feedServerSentEventsProcedure, func(message feedMessage) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		// Best effort, as not every response writer supports deadlines.
+		responseController.SetWriteDeadline(time.Now().Add(f.cfg.WriteTimeout))
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, data); err != nil {
+			return err
+		}
+		return responseController.Flush()
+	})
+
+	request := initialFeedRequest(r)
+	if request == nil {
+		request = &feedMessage{Type: feedMessageSubscribe}
+	}
+	// The subscription cannot be corrected without reconnecting, so the feed ends after an error.
+	reply := session.apply(*request)
+	if err := session.send(reply); err != nil || reply.Type == feedMessageError {
+		return
+	}
+	if err := session.run(r.Context(), nil); err != nil {
+		slog.Debug("server-sent events feed write failed", "client", ClientName(r.Context()), "err", err)
+	}
+}
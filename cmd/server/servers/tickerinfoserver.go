@@ -3,9 +3,12 @@ package servers
 import (
 	"context"
 	"errors"
-	"log/slog"
+	"sync"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/hmcalister/genron/cmd/server/calendar"
+	"github.com/hmcalister/genron/cmd/server/config"
 	"github.com/hmcalister/genron/cmd/server/ticker"
 	tickerv1 "github.com/hmcalister/genron/gen/api/ticker/v1"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -19,18 +22,41 @@ type TickerInfoServer struct {
 	// A map from ticker name to ticker structs
 	Tickers     map[string]ticker.Ticker
 	TickerNames []string
+
+	// The broadcaster of ticker updates, for the streaming RPCs.
+	updates *ticker.UpdateBroadcaster
+	cfg     config.StreamConfig
+
+	// Closed by Shutdown, to end every stream.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewTickerInfoServer(tickers map[string]ticker.Ticker, updates *ticker.UpdateBroadcaster, cfg config.StreamConfig) *TickerInfoServer {
+	tickerNames := make([]string, 0, len(tickers))
+	for tickerName := range tickers {
+		tickerNames = append(tickerNames, tickerName)
+	}
+	return &TickerInfoServer{
+		Tickers:     tickers,
+		TickerNames: tickerNames,
+		updates:     updates,
+		cfg:         cfg,
+		done:        make(chan struct{}),
+	}
+}
+
+// End every stream, see the graceful shutdown in main.
+func (serv *TickerInfoServer) Shutdown() {
+	serv.doneOnce.Do(func() {
+		close(serv.done)
+	})
 }
 
 func (serv *TickerInfoServer) GetAllTickerNames(
 	ctx context.Context,
 	req *connect.Request[emptypb.Empty],
 ) (*connect.Response[tickerv1.GetAllTickerNamesResponse], error) {
-	slog.Info("new get all ticker names request",
-		"ctx", ctx,
-		"reqHeader", req.Header(),
-		"reqMsg", req.Msg,
-	)
-
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -45,32 +71,141 @@ func (serv *TickerInfoServer) GetTickerValue(
 	ctx context.Context,
 	req *connect.Request[tickerv1.GetTickerValueRequest],
 ) (*connect.Response[tickerv1.GetTickerValueResponse], error) {
-	slog.Info("new get ticker value request",
-		"ctx", ctx,
-		"reqHeader", req.Header(),
-		"reqMsg", req.Msg,
-	)
-
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
 	requestedTicker, ok := serv.Tickers[req.Msg.TickerName]
 	if !ok {
-		slog.Info("requested ticker name does not exist",
-			"reqMsg", req.Msg,
-			"requestedTickerName", req.Msg.TickerName,
-		)
 		return nil, ErrorTickerDoesNotExist
 	}
 
-	// TODO: Rate limiting, exhausting, etc...
-	tickerName, newValue, lastUpdatedTimestamp, _ := requestedTicker.GetInfo()
+	info := requestedTicker.GetSnapshot()
 
 	res := connect.NewResponse(&tickerv1.GetTickerValueResponse{
-		TickerName:           tickerName,
-		TickerValue:          newValue,
-		LastUpdatedTimestamp: lastUpdatedTimestamp.UnixNano(),
+		TickerName:           info.Name,
+		TickerValue:          info.Value,
+		LastUpdatedTimestamp: info.LastUpdatedTimestamp.UnixNano(),
+		Quote:                newQuoteMessage(info.Quote),
+		MarketStatus:         newMarketStatusMessage(requestedTicker.GetMarketStatus(time.Now())),
+	})
+	return res, nil
+}
+
+func (serv *TickerInfoServer) ListTickerTypes(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[tickerv1.ListTickerTypesResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tickerTypes := ticker.ListTickerTypes()
+	tickerTypeMessages := make([]*tickerv1.TickerType, 0, len(tickerTypes))
+	for _, tickerType := range tickerTypes {
+		parameters := tickerType.AllParameters()
+		parameterMessages := make([]*tickerv1.TickerParameter, 0, len(parameters))
+		for _, parameter := range parameters {
+			parameterMessages = append(parameterMessages, &tickerv1.TickerParameter{
+				Name:         parameter.Name,
+				Datatype:     parameter.Datatype,
+				DefaultValue: parameter.Default,
+				Required:     parameter.Required,
+				Description:  parameter.Description,
+			})
+		}
+		tickerTypeMessages = append(tickerTypeMessages, &tickerv1.TickerType{
+			Name:        tickerType.Name,
+			Description: tickerType.Description,
+			Parameter:   parameterMessages,
+		})
+	}
+
+	res := connect.NewResponse(&tickerv1.ListTickerTypesResponse{
+		TickerType: tickerTypeMessages,
 	})
 	return res, nil
 }
+
+// Stream the trades of the requested tickers (or of every ticker, if none are requested) as they are generated,
+// with the trades of each update in a single response, until the client disconnects or the server shuts down.
+func (serv *TickerInfoServer) StreamTrades(
+	ctx context.Context,
+	req *connect.Request[tickerv1.StreamTradesRequest],
+	stream *connect.ServerStream[tickerv1.StreamTradesResponse],
+) error {
+	requestedTickerNames := make(map[string]struct{}, len(req.Msg.TickerName))
+	for _, tickerName := range req.Msg.TickerName {
+		if _, ok := serv.Tickers[tickerName]; !ok {
+			return ErrorTickerDoesNotExist
+		}
+		requestedTickerNames[tickerName] = struct{}{}
+	}
+
+	subscription := serv.updates.Subscribe(serv.cfg.BufferSize)
+	defer subscription.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-serv.done:
+			return nil
+		case update, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			if len(update.Trades) == 0 {
+				continue
+			}
+			if _, ok := requestedTickerNames[update.Name]; !ok && len(requestedTickerNames) > 0 {
+				continue
+			}
+
+			tradeMessages := make([]*tickerv1.Trade, 0, len(update.Trades))
+			for _, trade := range update.Trades {
+				tradeMessages = append(tradeMessages, newTradeMessage(update.Name, trade))
+			}
+			if err := stream.Send(&tickerv1.StreamTradesResponse{Trade: tradeMessages}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newTradeMessage(tickerName string, trade ticker.Trade) *tickerv1.Trade {
+	return &tickerv1.Trade{
+		TickerName: tickerName,
+		Timestamp:  trade.Timestamp.UnixNano(),
+		Price:      trade.Price,
+		Size:       trade.Size,
+		Aggressor:  sideMessage(trade.Aggressor),
+	}
+}
+
+func newMarketStatusMessage(status calendar.Status) *tickerv1.MarketStatus {
+	return &tickerv1.MarketStatus{
+		Calendar:           status.Calendar,
+		Open:               status.Open,
+		NextOpenTimestamp:  unixNanoOrZero(status.NextOpen),
+		NextCloseTimestamp: unixNanoOrZero(status.NextClose),
+	}
+}
+
+// The zero time is far outside the range of UnixNano, so is sent as zero.
+func unixNanoOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func newQuoteMessage(quote ticker.Quote) *tickerv1.Quote {
+	return &tickerv1.Quote{
+		Bid:     quote.Bid,
+		Ask:     quote.Ask,
+		BidSize: quote.BidSize,
+		AskSize: quote.AskSize,
+		HasBid:  quote.HasBid,
+		HasAsk:  quote.HasAsk,
+	}
+}
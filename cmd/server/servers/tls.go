@@ -0,0 +1,143 @@
+package servers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+)
+
+const (
+	// How long a generated self-signed certificate is valid for.
+	selfSignedCertificateValidity = 365 * 24 * time.Hour
+)
+
+var (
+	ErrorTLSMissingCertificate = errors.New("tls is enabled, but no certificate and key files are set")
+	ErrorTLSNoClientCAs        = errors.New("no CA certificates found in client CA file")
+
+	// The hosts a generated self-signed certificate is valid for.
+	selfSignedCertificateHosts = []string{"localhost", "127.0.0.1", "::1"}
+)
+
+// Create the TLS config of the HTTP server from the given config,
+// loading the certificate and key files (or generating a self-signed certificate) and the client CAs.
+// Returns nil (and no error) if TLS is disabled.
+func NewTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Advertise HTTP/2 first, so gRPC clients (which require HTTP/2) can connect.
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if cfg.MinVersion == "1.3" {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
+
+	var certificate tls.Certificate
+	var err error
+	switch {
+	case cfg.SelfSigned:
+		certificate, err = generateSelfSignedCertificate(cfg.SelfSignedCertFile)
+	case cfg.CertFile != "" && cfg.KeyFile != "":
+		certificate, err = tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	default:
+		err = ErrorTLSMissingCertificate
+	}
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.Certificates = []tls.Certificate{certificate}
+
+	if cfg.ClientCAFile != "" {
+		clientCAs, err := loadCertificatePool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// Load every PEM encoded certificate in the given file into a new certificate pool.
+func loadCertificatePool(certificateFilePath string) (*x509.CertPool, error) {
+	certificatesPEM, err := os.ReadFile(certificateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	certificatePool := x509.NewCertPool()
+	if !certificatePool.AppendCertsFromPEM(certificatesPEM) {
+		return nil, fmt.Errorf("error loading %q: %w", certificateFilePath, ErrorTLSNoClientCAs)
+	}
+	return certificatePool, nil
+}
+
+// Generate a new ECDSA key and self-signed certificate for localhost.
+// If a file path is given, the certificate (but not the key) is written there, so clients can trust it.
+func generateSelfSignedCertificate(certificateFilePath string) (tls.Certificate, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	notBefore := time.Now().Add(-time.Minute)
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"GEnron self-signed"}, CommonName: "localhost"},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(selfSignedCertificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		// The certificate is its own CA, so clients can trust it directly.
+		IsCA: true,
+	}
+	for _, host := range selfSignedCertificateHosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if certificateFilePath != "" {
+		certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER})
+		if err := os.WriteFile(certificateFilePath, certificatePEM, 0644); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	slog.Warn("using generated self-signed certificate, do not use in production",
+		"hosts", selfSignedCertificateHosts,
+		"certificateFilePath", certificateFilePath,
+	)
+
+	return tls.Certificate{
+		Certificate: [][]byte{certificateDER},
+		PrivateKey:  privateKey,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+var (
+	ErrorOriginNotAllowed = connect.NewError(connect.CodePermissionDenied, errors.New("origin is not allowed, add it to feed.allowedorigins"))
+)
+
+// Serve a WebSocket feed. Every message, in either direction, is a single JSON feedMessage in a text frame.
+func (f *FeedServer) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !f.originAllowed(r) {
+		writeHTTPError(w, ErrorOriginNotAllowed)
+		return
+	}
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		// The origin is already checked above, against the same patterns as Server-Sent Events.
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		// Accept has already written an error response.
+		slog.Debug("websocket feed accept failed", "client", ClientName(r.Context()), "err", err)
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	session := f.newFeedSession(feedWebSocketProcedure, func(message feedMessage) error {
+		writeCtx, writeCancel := context.WithTimeout(ctx, f.cfg.WriteTimeout)
+		defer writeCancel()
+		return wsjson.Write(writeCtx, conn, message)
+	})
+
+	// Read requests from the client in a separate goroutine, as reads block until a message arrives.
+	// The channel is closed when the client closes the connection.
+	requests := make(chan feedMessage)
+	go func() {
+		defer close(requests)
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			var request feedMessage
+			if err := json.Unmarshal(data, &request); err != nil {
+				request = feedMessage{Type: feedMessageError, Message: "invalid JSON: " + err.Error()}
+			}
+			select {
+			case requests <- request:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if request := initialFeedRequest(r); request != nil {
+		if err := session.send(session.apply(*request)); err != nil {
+			return
+		}
+	}
+
+	err = session.run(ctx, requests)
+	select {
+	case <-f.done:
+		conn.Close(websocket.StatusGoingAway, "server is shutting down")
+	default:
+		if err != nil {
+			slog.Debug("websocket feed write failed", "client", ClientName(r.Context()), "err", err)
+			conn.Close(websocket.StatusPolicyViolation, "write timed out")
+			return
+		}
+		conn.Close(websocket.StatusNormalClosure, "")
+	}
+}
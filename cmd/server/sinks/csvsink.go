@@ -0,0 +1,32 @@
+package sinks
+
+import (
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterSinkType(SinkType{
+		Name:        "csv",
+		Description: "Write ticker updates to a CSV file, with the header name,timestamp,value. Timestamps are unix nanoseconds.",
+		Config:      CSVSinkConfig{},
+		New: func(sinkConfig *viper.Viper) (Sink, error) {
+			var cfg CSVSinkConfig
+			if err := config.Decode(sinkConfig, &cfg); err != nil {
+				return nil, err
+			}
+			return &lineSink{
+				filePath:     cfg.File,
+				appendToFile: cfg.Append,
+				format:       lineFormatCSV,
+			}, nil
+		},
+	})
+}
+
+type CSVSinkConfig struct {
+	BaseSinkConfig `mapstructure:",squash"`
+
+	File   string `mapstructure:"file" validate:"required" description:"The file to write ticker updates to."`
+	Append bool   `mapstructure:"append" default:"false" description:"Append to the file if it exists, rather than truncating it."`
+}
@@ -0,0 +1,225 @@
+package sinks
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+const (
+	// The delay before the first retry of a failed write. Each subsequent retry doubles the delay.
+	sinkInitialRetryDelay = 100 * time.Millisecond
+
+	// How often to warn about updates dropped because a sink could not keep up.
+	sinkDropReportInterval = 10 * time.Second
+)
+
+// Fans every ticker update out to any number of sinks.
+// Each sink has its own subscription (and so its own buffer), batching, sampling, and error policy,
+// so a slow or failing sink cannot affect the others.
+type Dispatcher struct {
+	updates   *ticker.UpdateBroadcaster
+	waitGroup sync.WaitGroup
+}
+
+func NewDispatcher(updates *ticker.UpdateBroadcaster) *Dispatcher {
+	return &Dispatcher{
+		updates: updates,
+	}
+}
+
+// Start sending updates to the given (already opened) sink, in a new goroutine.
+// The sink is flushed and closed once the broadcaster is closed.
+func (d *Dispatcher) Start(sink Sink, cfg BaseSinkConfig) {
+	subscription := d.updates.Subscribe(cfg.BufferSize)
+	d.waitGroup.Go(func() {
+		runSink(sink, cfg, subscription)
+	})
+}
+
+// Wait for every sink to flush and close. Call only after closing the broadcaster.
+func (d *Dispatcher) Wait() {
+	d.waitGroup.Wait()
+}
+
+// A batch of updates waiting to be written to a sink, optionally followed by a flush.
+type pendingBatch struct {
+	updates []ticker.TickerUpdate
+	flush   bool
+}
+
+// Collect updates from the subscription into batches, and write them to the sink,
+// until the subscription is closed or the sink is stopped by its error policy.
+//
+// Batches are written by a separate goroutine, through a bounded queue of pending batches.
+// While the sink is slow (or retrying) the queue fills, and once it is full no more updates are taken
+// from the subscription, so updates queue up in the sink buffer and are then dropped. Memory use stays bounded,
+// and the tickers are never slowed.
+func runSink(sink Sink, cfg BaseSinkConfig, subscription *ticker.Subscription) {
+	pendingBatches := make(chan pendingBatch, cfg.MaxPendingBatches)
+	sinkStopped := make(chan struct{})
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		writePendingBatches(sink, cfg, pendingBatches, sinkStopped)
+	}()
+
+	collectBatches(cfg, subscription, pendingBatches, sinkStopped)
+	close(pendingBatches)
+	<-writerDone
+	closeSink(sink, cfg, subscription)
+}
+
+// Collect updates from the subscription into batches, sending each batch to be written when full,
+// or when the flush interval elapses. Sending blocks while the pending batch queue is full, which is the backpressure.
+// Returns once the subscription is closed and the last batch is sent, or once the sink is stopped.
+func collectBatches(cfg BaseSinkConfig, subscription *ticker.Subscription, pendingBatches chan<- pendingBatch, sinkStopped <-chan struct{}) {
+	flushTimer := time.NewTicker(cfg.FlushInterval)
+	defer flushTimer.Stop()
+	dropReportTimer := time.NewTicker(sinkDropReportInterval)
+	defer dropReportTimer.Stop()
+
+	// The timestamp of the last written update of each ticker, for sampling.
+	lastWrittenTimestamps := make(map[string]time.Time)
+	var lastReportedDropped uint64
+	batch := make([]ticker.TickerUpdate, 0, cfg.BatchSize)
+
+	// Send the current batch (even if empty, so that the flush happens in order with the writes).
+	// Returns false if the sink has been stopped by its error policy.
+	sendBatch := func(flush bool) bool {
+		if len(batch) == 0 && !flush {
+			return true
+		}
+		select {
+		case pendingBatches <- pendingBatch{updates: batch, flush: flush}:
+		case <-sinkStopped:
+			return false
+		}
+		batch = make([]ticker.TickerUpdate, 0, cfg.BatchSize)
+		return true
+	}
+
+	for {
+		select {
+		case update, ok := <-subscription.C:
+			if !ok {
+				sendBatch(true)
+				return
+			}
+
+			if cfg.SamplingInterval > 0 {
+				lastWrittenTimestamp, ok := lastWrittenTimestamps[update.Name]
+				if ok && update.Timestamp.Sub(lastWrittenTimestamp) < cfg.SamplingInterval {
+					continue
+				}
+				lastWrittenTimestamps[update.Name] = update.Timestamp
+			}
+
+			batch = append(batch, update)
+			if len(batch) >= cfg.BatchSize && !sendBatch(false) {
+				subscription.Unsubscribe()
+				return
+			}
+		case <-flushTimer.C:
+			if !sendBatch(true) {
+				subscription.Unsubscribe()
+				return
+			}
+		case <-sinkStopped:
+			subscription.Unsubscribe()
+			return
+		case <-dropReportTimer.C:
+			if dropped := subscription.Dropped(); dropped > lastReportedDropped {
+				slog.Warn("sink cannot keep up, updates dropped",
+					"sinkName", cfg.Name,
+					"droppedSinceLastReport", dropped-lastReportedDropped,
+					"droppedTotal", dropped,
+					"pendingBatches", len(pendingBatches),
+				)
+				lastReportedDropped = dropped
+			}
+		}
+	}
+}
+
+// Write (and flush) pending batches to the sink in order, until the queue is closed.
+// If the sink is stopped by its error policy, sinkStopped is closed, and any remaining batches are discarded.
+func writePendingBatches(sink Sink, cfg BaseSinkConfig, pendingBatches <-chan pendingBatch, sinkStopped chan<- struct{}) {
+	stopped := false
+	for batch := range pendingBatches {
+		if stopped {
+			continue
+		}
+		ok := true
+		if len(batch.updates) > 0 {
+			// Only the updates not yet written are retried, so a partially written batch is not duplicated.
+			remaining := batch.updates
+			ok = handleSinkError(cfg, "write", len(batch.updates), func() error {
+				written, err := sink.Write(context.Background(), remaining)
+				remaining = remaining[written:]
+				return err
+			})
+		}
+		if ok && batch.flush {
+			ok = handleSinkError(cfg, "flush", 0, func() error {
+				return sink.Flush(context.Background())
+			})
+		}
+		if !ok {
+			stopped = true
+			close(sinkStopped)
+		}
+	}
+}
+
+// Call the given sink operation, handling any error according to the error policy of the sink.
+// Returns false if the sink should be stopped.
+func handleSinkError(cfg BaseSinkConfig, operation string, batchSize int, call func() error) bool {
+	retryDelay := sinkInitialRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil {
+			return true
+		}
+
+		switch {
+		case cfg.ErrorPolicy == SinkErrorPolicyRetry && attempt < cfg.MaxRetries:
+			slog.Warn("error in sink, retrying",
+				"sinkName", cfg.Name,
+				"operation", operation,
+				"batchSize", batchSize,
+				"retryDelay", retryDelay,
+				"err", err,
+			)
+			time.Sleep(retryDelay)
+			retryDelay *= 2
+		case cfg.ErrorPolicy == SinkErrorPolicyStop:
+			slog.Error("error in sink, stopping sink",
+				"sinkName", cfg.Name,
+				"operation", operation,
+				"batchSize", batchSize,
+				"err", err,
+			)
+			return false
+		default:
+			slog.Error("error in sink, discarding batch",
+				"sinkName", cfg.Name,
+				"operation", operation,
+				"batchSize", batchSize,
+				"attempts", attempt+1,
+				"err", err,
+			)
+			return true
+		}
+	}
+}
+
+func closeSink(sink Sink, cfg BaseSinkConfig, subscription *ticker.Subscription) {
+	if err := sink.Close(); err != nil {
+		slog.Error("error when closing sink", "sinkName", cfg.Name, "err", err)
+	}
+	slog.Debug("sink closed", "sinkName", cfg.Name, "droppedUpdates", subscription.Dropped())
+}
@@ -0,0 +1,392 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+var errorTestSink = errors.New("test sink error")
+
+// A sink recording every call made by the dispatcher.
+type fakeSink struct {
+	// Called at the start of every write, with the number of the call (from zero) and the batch.
+	// Returns the number of updates of the batch to write, and the error to return. If nil, every write succeeds.
+	writeResult func(call int, batch []ticker.TickerUpdate) (int, error)
+
+	// If set, every write waits for a value from this channel, after sending on writing (if set).
+	release chan struct{}
+	writing chan struct{}
+
+	mu         sync.Mutex
+	writeCalls int
+	calls      []string
+	written    []ticker.TickerUpdate
+	closed     bool
+}
+
+func (s *fakeSink) Open(ctx context.Context) error {
+	return nil
+}
+
+func (s *fakeSink) Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error) {
+	if s.writing != nil {
+		s.writing <- struct{}{}
+	}
+	if s.release != nil {
+		<-s.release
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	written, err := len(batch), error(nil)
+	if s.writeResult != nil {
+		written, err = s.writeResult(s.writeCalls, batch)
+	}
+	s.writeCalls++
+	s.calls = append(s.calls, fmt.Sprintf("write %d", len(batch)))
+	s.written = append(s.written, batch[:written]...)
+	return written, err
+}
+
+func (s *fakeSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, "flush")
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, "close")
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSink) getCalls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.calls)
+}
+
+func testSinkConfig() BaseSinkConfig {
+	return BaseSinkConfig{
+		Name:              "test",
+		Type:              "test",
+		BufferSize:        1024,
+		BatchSize:         1000,
+		FlushInterval:     time.Hour,
+		ErrorPolicy:       SinkErrorPolicyDrop,
+		MaxPendingBatches: 16,
+	}
+}
+
+// Updates of a single ticker, a millisecond apart.
+func testSinkUpdates(n int) []ticker.TickerUpdate {
+	start := time.Unix(1_700_000_000, 0)
+	updates := make([]ticker.TickerUpdate, n)
+	for i := range updates {
+		updates[i] = ticker.TickerUpdate{
+			Name:      "ticker01",
+			Timestamp: start.Add(time.Duration(i) * time.Millisecond),
+			Value:     float64(i),
+		}
+	}
+	return updates
+}
+
+// Check the updates written to a sink are the given updates. Test updates have no quotes or trades.
+func equalUpdates(got []ticker.TickerUpdate, want []ticker.TickerUpdate) bool {
+	return slices.EqualFunc(got, want, func(a, b ticker.TickerUpdate) bool {
+		return a.Name == b.Name && a.Timestamp.Equal(b.Timestamp) && a.Value == b.Value
+	})
+}
+
+// Send the given updates through a dispatcher to the sink, then close the broadcaster and wait for the sink to close.
+func dispatchUpdates(t *testing.T, sink *fakeSink, cfg BaseSinkConfig, updates []ticker.TickerUpdate) {
+	t.Helper()
+
+	broadcaster := ticker.NewUpdateBroadcaster()
+	dispatcher := NewDispatcher(broadcaster)
+	dispatcher.Start(sink, cfg)
+	for _, update := range updates {
+		broadcaster.Publish(update)
+	}
+	broadcaster.Close()
+	dispatcher.Wait()
+
+	if !sink.closed {
+		t.Error("sink was not closed")
+	}
+}
+
+// Wait until the condition is true, failing the test if it is not true within a few seconds.
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", description)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDispatcherBatching(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		updates   int
+		wantCalls []string
+	}{
+		{
+			name:      "batches by size",
+			batchSize: 3,
+			updates:   7,
+			wantCalls: []string{"write 3", "write 3", "write 1", "flush", "close"},
+		},
+		{
+			name:      "full batches",
+			batchSize: 2,
+			updates:   4,
+			wantCalls: []string{"write 2", "write 2", "flush", "close"},
+		},
+		{
+			name:      "flushes on shutdown",
+			batchSize: 100,
+			updates:   5,
+			wantCalls: []string{"write 5", "flush", "close"},
+		},
+		{
+			name:      "flushes on shutdown with no updates",
+			batchSize: 100,
+			updates:   0,
+			wantCalls: []string{"flush", "close"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testSinkConfig()
+			cfg.BatchSize = tt.batchSize
+			sink := &fakeSink{}
+			updates := testSinkUpdates(tt.updates)
+			dispatchUpdates(t, sink, cfg, updates)
+
+			if calls := sink.getCalls(); !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls are %v, expected %v", calls, tt.wantCalls)
+			}
+			if !equalUpdates(sink.written, updates) {
+				t.Errorf("wrote %v, expected %v", sink.written, updates)
+			}
+		})
+	}
+}
+
+func TestDispatcherFlushInterval(t *testing.T) {
+	cfg := testSinkConfig()
+	cfg.FlushInterval = 10 * time.Millisecond
+	sink := &fakeSink{}
+	broadcaster := ticker.NewUpdateBroadcaster()
+	dispatcher := NewDispatcher(broadcaster)
+	dispatcher.Start(sink, cfg)
+
+	// The batch is far from full, so is only written once the flush interval elapses.
+	updates := testSinkUpdates(2)
+	for _, update := range updates {
+		broadcaster.Publish(update)
+	}
+	// The flush timer may also fire before the updates arrive, flushing an empty batch first.
+	waitFor(t, "the batch to be written and flushed", func() bool {
+		calls := sink.getCalls()
+		i := slices.Index(calls, "write 2")
+		return i >= 0 && i+1 < len(calls) && calls[i+1] == "flush"
+	})
+
+	broadcaster.Close()
+	dispatcher.Wait()
+	if !equalUpdates(sink.written, updates) {
+		t.Errorf("wrote %v, expected %v", sink.written, updates)
+	}
+}
+
+func TestDispatcherSampling(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	update := func(name string, offset time.Duration) ticker.TickerUpdate {
+		return ticker.TickerUpdate{Name: name, Timestamp: start.Add(offset), Value: float64(offset.Milliseconds())}
+	}
+	updates := []ticker.TickerUpdate{
+		update("ticker01", 0),
+		update("ticker02", 0),
+		update("ticker01", 5*time.Millisecond),
+		update("ticker01", 10*time.Millisecond),
+		update("ticker02", 9*time.Millisecond),
+		update("ticker01", 15*time.Millisecond),
+		update("ticker02", 12*time.Millisecond),
+		update("ticker01", 21*time.Millisecond),
+	}
+	// Each ticker is sampled from its own last written update, rather than on a fixed grid.
+	want := []ticker.TickerUpdate{updates[0], updates[1], updates[3], updates[6], updates[7]}
+
+	cfg := testSinkConfig()
+	cfg.SamplingInterval = 10 * time.Millisecond
+	sink := &fakeSink{}
+	dispatchUpdates(t, sink, cfg, updates)
+	if !equalUpdates(sink.written, want) {
+		t.Errorf("wrote %v, expected %v", sink.written, want)
+	}
+}
+
+func TestDispatcherErrorPolicy(t *testing.T) {
+	updates := testSinkUpdates(4)
+
+	tests := []struct {
+		name        string
+		errorPolicy string
+		maxRetries  int
+		// The write calls that fail, by call number, and the number of updates each writes before failing.
+		// Calls not listed write the whole batch.
+		writeResults map[int]int
+		wantCalls    []string
+		wantWritten  []ticker.TickerUpdate
+	}{
+		{
+			name:         "drop discards the batch",
+			errorPolicy:  SinkErrorPolicyDrop,
+			writeResults: map[int]int{0: 0},
+			wantCalls:    []string{"write 2", "write 2", "flush", "close"},
+			wantWritten:  updates[2:],
+		},
+		{
+			name:         "retry writes the batch",
+			errorPolicy:  SinkErrorPolicyRetry,
+			maxRetries:   3,
+			writeResults: map[int]int{0: 0, 1: 0},
+			wantCalls:    []string{"write 2", "write 2", "write 2", "write 2", "flush", "close"},
+			wantWritten:  updates,
+		},
+		{
+			name:         "retry discards the batch after the maximum retries",
+			errorPolicy:  SinkErrorPolicyRetry,
+			maxRetries:   1,
+			writeResults: map[int]int{0: 0, 1: 0},
+			wantCalls:    []string{"write 2", "write 2", "write 2", "flush", "close"},
+			wantWritten:  updates[2:],
+		},
+		{
+			name:         "retry writes only the rest of a partially written batch",
+			errorPolicy:  SinkErrorPolicyRetry,
+			maxRetries:   3,
+			writeResults: map[int]int{0: 1},
+			wantCalls:    []string{"write 2", "write 1", "write 2", "flush", "close"},
+			wantWritten:  updates,
+		},
+		{
+			name:         "stop closes the sink",
+			errorPolicy:  SinkErrorPolicyStop,
+			writeResults: map[int]int{0: 0},
+			wantCalls:    []string{"write 2", "close"},
+			wantWritten:  nil,
+		},
+		{
+			name:         "stop keeps a partially written batch",
+			errorPolicy:  SinkErrorPolicyStop,
+			writeResults: map[int]int{0: 1},
+			wantCalls:    []string{"write 2", "close"},
+			wantWritten:  updates[:1],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testSinkConfig()
+			cfg.BatchSize = 2
+			cfg.ErrorPolicy = tt.errorPolicy
+			cfg.MaxRetries = tt.maxRetries
+			sink := &fakeSink{
+				writeResult: func(call int, batch []ticker.TickerUpdate) (int, error) {
+					if written, ok := tt.writeResults[call]; ok {
+						return written, errorTestSink
+					}
+					return len(batch), nil
+				},
+			}
+			dispatchUpdates(t, sink, cfg, updates)
+
+			if calls := sink.getCalls(); !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls are %v, expected %v", calls, tt.wantCalls)
+			}
+			if !equalUpdates(sink.written, tt.wantWritten) {
+				t.Errorf("wrote %v, expected %v", sink.written, tt.wantWritten)
+			}
+		})
+	}
+}
+
+func TestDispatcherBackpressure(t *testing.T) {
+	cfg := testSinkConfig()
+	cfg.BufferSize = 2
+	cfg.BatchSize = 1
+	cfg.MaxPendingBatches = 1
+	sink := &fakeSink{
+		writing: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	broadcaster := ticker.NewUpdateBroadcaster()
+	subscription := broadcaster.Subscribe(cfg.BufferSize)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runSink(sink, cfg, subscription)
+	}()
+
+	updates := testSinkUpdates(7)
+
+	// The first update is being written, and the sink is slow to write it.
+	broadcaster.Publish(updates[0])
+	<-sink.writing
+
+	// The next update fills the pending batch queue, and the one after is taken from the buffer
+	// but cannot be queued, so no more updates are taken.
+	for _, update := range updates[1:3] {
+		broadcaster.Publish(update)
+		waitFor(t, "the update to be taken from the buffer", func() bool {
+			return len(subscription.C) == 0
+		})
+	}
+
+	// The buffer fills, then further updates are dropped.
+	for _, update := range updates[3:] {
+		broadcaster.Publish(update)
+	}
+	if dropped := subscription.Dropped(); dropped != 2 {
+		t.Errorf("dropped %d updates, expected 2", dropped)
+	}
+
+	// Once the sink catches up, the queued and buffered updates are written.
+	go func() {
+		for {
+			select {
+			case sink.release <- struct{}{}:
+			case <-sink.writing:
+			case <-done:
+				return
+			}
+		}
+	}()
+	broadcaster.Close()
+	<-done
+	if !equalUpdates(sink.written, updates[:5]) {
+		t.Errorf("wrote %v, expected %v", sink.written, updates[:5])
+	}
+}
@@ -0,0 +1,65 @@
+package sinks
+
+import (
+	"context"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/journal"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterSinkType(SinkType{
+		Name:        "journal",
+		Description: "Append ticker updates to a compact, checksummed, append-only binary journal, split into segment files. A journal can be replayed by the server in replay mode.",
+		Config:      JournalSinkConfig{},
+		New: func(sinkConfig *viper.Viper) (Sink, error) {
+			var cfg JournalSinkConfig
+			if err := config.Decode(sinkConfig, &cfg); err != nil {
+				return nil, err
+			}
+			return &JournalSink{cfg: cfg}, nil
+		},
+	})
+}
+
+type JournalSinkConfig struct {
+	BaseSinkConfig `mapstructure:",squash"`
+
+	Dir         string `mapstructure:"dir" validate:"required" description:"The directory of the journal. Created if it does not exist. Each run appends new segments after any existing segments."`
+	SegmentSize int64  `mapstructure:"segmentsize" default:"67108864" validate:"gt=0" description:"The size (in bytes) at which the journal rotates to a new segment file."`
+	Sync        bool   `mapstructure:"sync" default:"true" description:"Sync the current segment to disk on every flush."`
+}
+
+// Appends ticker updates to a binary journal. See the journal package for the format.
+type JournalSink struct {
+	cfg    JournalSinkConfig
+	writer *journal.Writer
+}
+
+func (s *JournalSink) Open(ctx context.Context) error {
+	writer, err := journal.NewWriter(s.cfg.Dir, s.cfg.SegmentSize, s.cfg.Sync)
+	if err != nil {
+		return err
+	}
+	s.writer = writer
+	return nil
+}
+
+func (s *JournalSink) Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error) {
+	for i, update := range batch {
+		if err := s.writer.Append(update); err != nil {
+			return i, err
+		}
+	}
+	return len(batch), nil
+}
+
+func (s *JournalSink) Flush(ctx context.Context) error {
+	return s.writer.Flush()
+}
+
+func (s *JournalSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,119 @@
+package sinks
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+)
+
+// The line formats of a lineSink.
+const (
+	lineFormatCSV   = "csv"
+	lineFormatJSONL = "jsonl"
+)
+
+// The JSON encoding of a single update, one per line in the jsonl format.
+// Timestamps are unix nanoseconds, to match the RPC API.
+type jsonlUpdate struct {
+	Name      string  `json:"name"`
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// A sink that writes each update as a single line of text, either to a file or to stdout.
+// Used by the csv, jsonl, and stdout sink types.
+type lineSink struct {
+	// The file to write to. If empty, writes to stdout instead.
+	filePath string
+
+	// If true, append to an existing file rather than truncating it.
+	appendToFile bool
+
+	// Either lineFormatCSV or lineFormatJSONL.
+	format string
+
+	file        *os.File
+	writer      *bufio.Writer
+	csvWriter   *csv.Writer
+	jsonEncoder *json.Encoder
+}
+
+func (s *lineSink) Open(ctx context.Context) error {
+	writeHeader := true
+	if s.filePath == "" {
+		s.file = os.Stdout
+	} else {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if s.appendToFile {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		file, err := os.OpenFile(s.filePath, flags, 0644)
+		if err != nil {
+			return err
+		}
+		// When appending to an existing file, the header is already there.
+		if fileInfo, err := file.Stat(); err == nil && fileInfo.Size() > 0 {
+			writeHeader = false
+		}
+		s.file = file
+	}
+	s.writer = bufio.NewWriter(s.file)
+
+	switch s.format {
+	case lineFormatCSV:
+		s.csvWriter = csv.NewWriter(s.writer)
+		if writeHeader {
+			return s.csvWriter.Write([]string{"name", "timestamp", "value"})
+		}
+	case lineFormatJSONL:
+		s.jsonEncoder = json.NewEncoder(s.writer)
+	}
+	return nil
+}
+
+func (s *lineSink) Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error) {
+	for i, update := range batch {
+		var err error
+		switch s.format {
+		case lineFormatCSV:
+			err = s.csvWriter.Write([]string{
+				update.Name,
+				strconv.FormatInt(update.Timestamp.UnixNano(), 10),
+				strconv.FormatFloat(update.Value, 'g', -1, 64),
+			})
+		case lineFormatJSONL:
+			err = s.jsonEncoder.Encode(jsonlUpdate{
+				Name:      update.Name,
+				Timestamp: update.Timestamp.UnixNano(),
+				Value:     update.Value,
+			})
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(batch), nil
+}
+
+func (s *lineSink) Flush(ctx context.Context) error {
+	if s.csvWriter != nil {
+		s.csvWriter.Flush()
+		if err := s.csvWriter.Error(); err != nil {
+			return err
+		}
+	}
+	return s.writer.Flush()
+}
+
+// Close the file. Stdout is left open, since it is shared with the rest of the program.
+func (s *lineSink) Close() error {
+	if s.file == os.Stdout {
+		return nil
+	}
+	return s.file.Close()
+}
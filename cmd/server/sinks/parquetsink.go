@@ -0,0 +1,92 @@
+package sinks
+
+import (
+	"context"
+	"os"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/parquet-go/parquet-go"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterSinkType(SinkType{
+		Name:        "parquet",
+		Description: "Write ticker updates to a zstd compressed Parquet file with columns name, timestamp (nanosecond), and value. The file is only readable once the sink is closed.",
+		Config:      ParquetSinkConfig{},
+		New: func(sinkConfig *viper.Viper) (Sink, error) {
+			var cfg ParquetSinkConfig
+			if err := config.Decode(sinkConfig, &cfg); err != nil {
+				return nil, err
+			}
+			return &ParquetSink{cfg: cfg}, nil
+		},
+	})
+}
+
+type ParquetSinkConfig struct {
+	BaseSinkConfig `mapstructure:",squash"`
+
+	File         string `mapstructure:"file" validate:"required" description:"The file to write ticker updates to. The file is truncated if it exists."`
+	RowGroupSize int64  `mapstructure:"rowgroupsize" default:"1000000" validate:"gt=0" description:"The maximum number of rows in each row group of the file."`
+}
+
+// A single row of the Parquet file.
+type parquetUpdate struct {
+	Name      string  `parquet:"name,dict"`
+	Timestamp int64   `parquet:"timestamp,timestamp(nanosecond)"`
+	Value     float64 `parquet:"value"`
+}
+
+// Writes ticker updates to a Parquet file.
+//
+// Parquet files are only readable once the footer is written, which happens on Close.
+// Row groups are cut every RowGroupSize rows, rather than on every Flush, so that they are not tiny.
+type ParquetSink struct {
+	cfg    ParquetSinkConfig
+	file   *os.File
+	writer *parquet.GenericWriter[parquetUpdate]
+
+	// The rows of the current batch, reused between writes.
+	rows []parquetUpdate
+}
+
+func (s *ParquetSink) Open(ctx context.Context) error {
+	file, err := os.Create(s.cfg.File)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.writer = parquet.NewGenericWriter[parquetUpdate](file,
+		parquet.Compression(&parquet.Zstd),
+		parquet.MaxRowsPerRowGroup(s.cfg.RowGroupSize),
+	)
+	return nil
+}
+
+func (s *ParquetSink) Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error) {
+	s.rows = s.rows[:0]
+	for _, update := range batch {
+		s.rows = append(s.rows, parquetUpdate{
+			Name:      update.Name,
+			Timestamp: update.Timestamp.UnixNano(),
+			Value:     update.Value,
+		})
+	}
+	return s.writer.Write(s.rows)
+}
+
+// Row groups are cut by size (see ParquetSink), so there is nothing to flush.
+func (s *ParquetSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+// Write the remaining row group and the footer, then close the file.
+func (s *ParquetSink) Close() error {
+	if err := s.writer.Close(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,131 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterSinkType(SinkType{
+		Name:        "postgres",
+		Description: "Copy ticker updates into a PostgreSQL (or TimescaleDB hypertable) table using COPY batches.",
+		Config:      PostgresSinkConfig{},
+		New: func(sinkConfig *viper.Viper) (Sink, error) {
+			var cfg PostgresSinkConfig
+			if err := config.Decode(sinkConfig, &cfg); err != nil {
+				return nil, err
+			}
+			return &PostgresSink{
+				cfg:             cfg,
+				tableIdentifier: pgx.Identifier{cfg.Table},
+				columns:         []string{"time", "name", "value"},
+			}, nil
+		},
+	})
+}
+
+type PostgresSinkConfig struct {
+	BaseSinkConfig `mapstructure:",squash"`
+
+	ConnString   string        `mapstructure:"connstring" validate:"required" description:"The PostgreSQL connection string (URL or key=value format) of the database to copy ticker updates into."`
+	Table        string        `mapstructure:"table" default:"ticks" description:"The table to copy ticker updates into. Created if it does not exist."`
+	Timescale    bool          `mapstructure:"timescale" default:"false" description:"Make the table a TimescaleDB hypertable partitioned on time. Requires the timescaledb extension to be available."`
+	WriteTimeout time.Duration `mapstructure:"writetimeout" default:"10s" validate:"gt=0" description:"The timeout of a single COPY."`
+}
+
+// Copies ticker updates into a PostgreSQL table, one COPY per batch.
+// The table has a `time` column first, so it can be made a TimescaleDB hypertable (see PostgresSinkConfig.Timescale).
+//
+// COPY is done synchronously, so when the database is slow batches wait in the bounded pending batch queue of the dispatcher
+// (see BaseSinkConfig.MaxPendingBatches). Once that is full the dispatcher stops taking updates from the sink buffer,
+// and once that is full further updates are dropped, so memory use stays bounded and the tickers are never slowed.
+type PostgresSink struct {
+	cfg  PostgresSinkConfig
+	pool *pgxpool.Pool
+
+	// The table identifier and columns used in COPY.
+	tableIdentifier pgx.Identifier
+	columns         []string
+
+	// The rows of the current batch, reused between writes.
+	rows [][]any
+}
+
+// Connect to the database, and create the table (and hypertable) if needed.
+func (s *PostgresSink) Open(ctx context.Context) error {
+	pool, err := pgxpool.New(ctx, s.cfg.ConnString)
+	if err != nil {
+		return err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return err
+	}
+
+	s.pool = pool
+	if err := s.createSchema(ctx); err != nil {
+		pool.Close()
+		return err
+	}
+	return nil
+}
+
+func (s *PostgresSink) createSchema(ctx context.Context) error {
+	table := s.tableIdentifier.Sanitize()
+	index := pgx.Identifier{s.cfg.Table + "_name_time"}.Sanitize()
+
+	if _, err := s.pool.Exec(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			time  TIMESTAMPTZ      NOT NULL,
+			name  TEXT             NOT NULL,
+			value DOUBLE PRECISION NOT NULL
+		)`, table)); err != nil {
+		return err
+	}
+	if _, err := s.pool.Exec(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s (name, time DESC)`, index, table)); err != nil {
+		return err
+	}
+
+	if s.cfg.Timescale {
+		if _, err := s.pool.Exec(ctx, `CREATE EXTENSION IF NOT EXISTS timescaledb`); err != nil {
+			return err
+		}
+		// The table name is passed as a regclass, so is given as the sanitized identifier.
+		if _, err := s.pool.Exec(ctx, `SELECT create_hypertable($1::regclass, 'time', if_not_exists => TRUE)`, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A COPY is a single statement, so the batch is either written in full, or not at all.
+func (s *PostgresSink) Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error) {
+	s.rows = s.rows[:0]
+	for _, update := range batch {
+		s.rows = append(s.rows, []any{update.Timestamp, update.Name, update.Value})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.WriteTimeout)
+	defer cancel()
+	if _, err := s.pool.CopyFrom(ctx, s.tableIdentifier, s.columns, pgx.CopyFromRows(s.rows)); err != nil {
+		return 0, err
+	}
+	return len(batch), nil
+}
+
+// Every batch is committed by Write, so there is nothing to flush.
+func (s *PostgresSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (s *PostgresSink) Close() error {
+	s.pool.Close()
+	return nil
+}
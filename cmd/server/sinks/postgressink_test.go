@@ -0,0 +1,141 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/viper"
+)
+
+// The connection string of the database used by the Postgres sink integration test.
+// The test is skipped unless this is set, e.g. to a local TimescaleDB started as described in the README.
+const postgresTestDSNEnv = "GENRON_TEST_POSTGRES_DSN"
+
+func TestPostgresSink(t *testing.T) {
+	dsn := os.Getenv(postgresTestDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set, skipping postgres integration test", postgresTestDSNEnv)
+	}
+
+	tests := []struct {
+		name      string
+		timescale bool
+	}{
+		{name: "postgres", timescale: false},
+		{name: "timescale", timescale: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			conn, err := pgx.Connect(ctx, dsn)
+			if err != nil {
+				t.Fatalf("error connecting to postgres: %v", err)
+			}
+			defer conn.Close(ctx)
+
+			if tt.timescale {
+				var available bool
+				err := conn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'timescaledb')`).Scan(&available)
+				if err != nil {
+					t.Fatalf("error checking for timescaledb: %v", err)
+				}
+				if !available {
+					t.Skip("timescaledb extension not available, skipping timescale mode")
+				}
+			}
+
+			table := fmt.Sprintf("genron_test_%s_%d", tt.name, time.Now().UnixNano())
+			t.Cleanup(func() {
+				conn, err := pgx.Connect(context.Background(), dsn)
+				if err != nil {
+					return
+				}
+				defer conn.Close(context.Background())
+				conn.Exec(context.Background(), fmt.Sprintf(`DROP TABLE IF EXISTS %s`, pgx.Identifier{table}.Sanitize()))
+			})
+
+			sinkConfig := viper.New()
+			sinkConfig.Set("name", "test")
+			sinkConfig.Set("type", "postgres")
+			sinkConfig.Set("connstring", dsn)
+			sinkConfig.Set("table", table)
+			sinkConfig.Set("timescale", tt.timescale)
+			sinkType, ok := getSinkType("postgres")
+			if !ok {
+				t.Fatal("postgres sink type not registered")
+			}
+			sink, err := sinkType.New(sinkConfig)
+			if err != nil {
+				t.Fatalf("error creating postgres sink: %v", err)
+			}
+			if err := sink.Open(ctx); err != nil {
+				t.Fatalf("error opening postgres sink: %v", err)
+			}
+
+			// TIMESTAMPTZ has microsecond precision, so the timestamps are truncated to compare them after the round trip.
+			start := time.Now().Truncate(time.Microsecond)
+			batch := make([]ticker.TickerUpdate, 0, 100)
+			for i := range cap(batch) {
+				batch = append(batch, ticker.TickerUpdate{
+					Name:      fmt.Sprintf("ticker%02d", i%3),
+					Timestamp: start.Add(time.Duration(i) * time.Millisecond),
+					Value:     100 + float64(i)/8,
+				})
+			}
+			written, err := sink.Write(ctx, batch)
+			if err != nil {
+				t.Fatalf("error writing batch: %v", err)
+			}
+			if written != len(batch) {
+				t.Fatalf("wrote %d updates, expected %d", written, len(batch))
+			}
+			if err := sink.Flush(ctx); err != nil {
+				t.Fatalf("error flushing postgres sink: %v", err)
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatalf("error closing postgres sink: %v", err)
+			}
+
+			rows, err := conn.Query(ctx, fmt.Sprintf(`SELECT time, name, value FROM %s ORDER BY time`, pgx.Identifier{table}.Sanitize()))
+			if err != nil {
+				t.Fatalf("error querying rows: %v", err)
+			}
+			var got []ticker.TickerUpdate
+			for rows.Next() {
+				var update ticker.TickerUpdate
+				if err := rows.Scan(&update.Timestamp, &update.Name, &update.Value); err != nil {
+					t.Fatalf("error scanning row: %v", err)
+				}
+				got = append(got, update)
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatalf("error reading rows: %v", err)
+			}
+
+			if len(got) != len(batch) {
+				t.Fatalf("got %d rows, expected %d", len(got), len(batch))
+			}
+			for i, want := range batch {
+				if got[i].Name != want.Name || !got[i].Timestamp.Equal(want.Timestamp) || got[i].Value != want.Value {
+					t.Errorf("row %d is %+v, expected %+v", i, got[i], want)
+				}
+			}
+
+			if tt.timescale {
+				var isHypertable bool
+				err := conn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM timescaledb_information.hypertables WHERE hypertable_name = $1)`, table).Scan(&isHypertable)
+				if err != nil {
+					t.Fatalf("error checking for hypertable: %v", err)
+				}
+				if !isHypertable {
+					t.Errorf("table %q is not a hypertable", table)
+				}
+			}
+		})
+	}
+}
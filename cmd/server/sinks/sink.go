@@ -0,0 +1,204 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/spf13/viper"
+)
+
+var (
+	ErrorUnknownSinkType = errors.New("sink type is not known")
+)
+
+// A persistence target for ticker updates.
+// Sinks are driven by a Dispatcher, which handles buffering, batching, sampling, and errors,
+// so a sink need only move batches of updates to its target.
+//
+// To create a new Sink implementation, make a new file (aptly named), define a typed config struct
+// (embedding BaseSinkConfig) and a struct implementing the below methods.
+// Then, register the type with RegisterSinkType in an init function of the same file.
+// Don't forget to update documentation in the README.
+//
+// The methods of a sink are only ever called from a single goroutine, so need no locking.
+type Sink interface {
+	// Open the sink, e.g. create the file or connect to the database.
+	// Called once, before any other method.
+	Open(ctx context.Context) error
+
+	// Write a batch of updates to the sink. The sink may buffer updates until Flush is called.
+	// The batch must not be retained after Write returns.
+	//
+	// Returns the number of updates of the batch written, which is the whole batch unless there is an error.
+	// On error, the updates before that number must have been written, and the rest not, since only the rest are retried.
+	// Sinks that write a batch atomically (e.g. in a transaction) return zero on error.
+	Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error)
+
+	// Flush any buffered updates to the target, e.g. sync the file.
+	// Called periodically (at the flush interval), and before Close.
+	Flush(ctx context.Context) error
+
+	// Close the sink, releasing any resources. Called once, after the final Flush.
+	Close() error
+}
+
+// The error policies of a sink, determining how the Dispatcher handles an error from Write or Flush.
+const (
+	// Log the error and discard the batch.
+	SinkErrorPolicyDrop = "drop"
+
+	// Retry (with exponential backoff) up to the maximum number of retries, then log the error and discard the batch.
+	// While retrying, batches queue up as pending batches, then updates queue up in the sink buffer (and are eventually dropped).
+	SinkErrorPolicyRetry = "retry"
+
+	// Log the error, then close the sink and stop sending it updates.
+	SinkErrorPolicyStop = "stop"
+)
+
+// The config shared by all sink types, used by the Dispatcher.
+// Embed this in the config struct of each sink type with `mapstructure:",squash"`.
+type BaseSinkConfig struct {
+	// Set from the sink key by ParseSinks, rather than in the sink config itself.
+	Name string `mapstructure:"name" schema:"-"`
+
+	Type              string        `mapstructure:"type" validate:"required" description:"The sink type."`
+	BufferSize        int           `mapstructure:"buffersize" default:"65536" validate:"gt=0" description:"The number of updates buffered while waiting to be written. Updates are dropped (rather than slowing the tickers) while the buffer is full."`
+	BatchSize         int           `mapstructure:"batchsize" default:"1000" validate:"gt=0" description:"The maximum number of updates written in a single batch."`
+	FlushInterval     time.Duration `mapstructure:"flushinterval" default:"1s" validate:"gt=0" description:"The maximum time an update waits before its batch is written and the sink is flushed."`
+	SamplingInterval  time.Duration `mapstructure:"samplinginterval" default:"0s" validate:"min=0" description:"The minimum time between written updates of each ticker. Updates sooner than this after the last written update are skipped. Zero writes every update."`
+	ErrorPolicy       string        `mapstructure:"errorpolicy" default:"retry" validate:"oneof=drop retry stop" description:"How to handle an error when writing or flushing. One of drop (discard the batch), retry (retry with backoff, then discard), or stop (close the sink)."`
+	MaxRetries        int           `mapstructure:"maxretries" default:"3" validate:"min=0" description:"The number of times a failed write is retried under the retry error policy."`
+	MaxPendingBatches int           `mapstructure:"maxpendingbatches" default:"16" validate:"gt=0" description:"The number of batches that may wait for a slow sink before backpressure is applied. Once this many batches are waiting, no more updates are taken from the buffer."`
+}
+
+// A registered sink type.
+type SinkType struct {
+	// The name of the type, as given in the `type` field of a sink config.
+	Name string
+
+	Description string
+
+	// The zero value of the typed config struct of this sink type, e.g. SQLiteSinkConfig{}.
+	// The struct must embed BaseSinkConfig, and is used to generate the config schema.
+	Config any
+
+	// Create a new, unopened sink of this type by decoding the sink config.
+	// Use config.Decode to decode the typed config struct.
+	New func(sinkConfig *viper.Viper) (Sink, error)
+}
+
+var (
+	sinkTypeRegistryMutex sync.RWMutex
+	sinkTypeRegistry      = make(map[string]SinkType)
+)
+
+// Register a sink type, making it available in the `sinks` config.
+// Built-in sink types register themselves in an init function, and packages embedding GEnron
+// may register their own types in the same way, before any sinks are parsed.
+//
+// Panics if the type is missing a name, config, or constructor, or if a type with the same name is already registered.
+func RegisterSinkType(sinkType SinkType) {
+	sinkTypeRegistryMutex.Lock()
+	defer sinkTypeRegistryMutex.Unlock()
+
+	if sinkType.Name == "" {
+		panic("sink type registered without a name")
+	}
+	if sinkType.New == nil || sinkType.Config == nil {
+		panic(fmt.Sprintf("sink type %q registered without a constructor or config", sinkType.Name))
+	}
+	if _, ok := sinkTypeRegistry[sinkType.Name]; ok {
+		panic(fmt.Sprintf("sink type %q registered twice", sinkType.Name))
+	}
+	sinkTypeRegistry[sinkType.Name] = sinkType
+}
+
+// Get all registered sink types, sorted by name.
+func ListSinkTypes() []SinkType {
+	sinkTypeRegistryMutex.RLock()
+	defer sinkTypeRegistryMutex.RUnlock()
+
+	sinkTypes := make([]SinkType, 0, len(sinkTypeRegistry))
+	for _, sinkType := range sinkTypeRegistry {
+		sinkTypes = append(sinkTypes, sinkType)
+	}
+	slices.SortFunc(sinkTypes, func(a, b SinkType) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sinkTypes
+}
+
+// Generate the JSON Schema of the `sinks` config key,
+// a map from sink name to a sink config of any registered type.
+func SinksSchema() *config.JSONSchema {
+	sinkSchemas := make([]*config.JSONSchema, 0)
+	for _, sinkType := range ListSinkTypes() {
+		schema := config.Schema(sinkType.Config)
+		schema.Title = sinkType.Name
+		schema.Description = sinkType.Description
+		schema.Properties["type"].Const = sinkType.Name
+		sinkSchemas = append(sinkSchemas, schema)
+	}
+	return &config.JSONSchema{
+		Type:                 "object",
+		Description:          "The sinks to write ticker updates to, keyed by sink name.",
+		AdditionalProperties: &config.JSONSchema{OneOf: sinkSchemas},
+	}
+}
+
+// Parse sinks from the viper config, looking into the `sinks` map to find definitions.
+// Each sink is opened, then started on the dispatcher.
+//
+// Unlike tickers, a sink that cannot be parsed or opened is an error, since silently losing persisted data is worse than not starting.
+func ParseSinks(ctx context.Context, dispatcher *Dispatcher) error {
+	for sinkName := range viper.GetStringMap("sinks") {
+		sinkConfig := viper.Sub("sinks." + sinkName)
+		sinkConfig.Set("name", sinkName) // Add the sink name to the config as a way to easily pass this along to constructors
+
+		sinkType, ok := getSinkType(sinkConfig.GetString("type"))
+		if !ok {
+			return fmt.Errorf("error parsing sink %q: %w", sinkName, ErrorUnknownSinkType)
+		}
+
+		// The base config is decoded separately (ignoring the type specific keys) for the dispatcher.
+		var baseConfig BaseSinkConfig
+		config.SetDefaults(sinkConfig, BaseSinkConfig{})
+		if err := sinkConfig.Unmarshal(&baseConfig); err != nil {
+			return fmt.Errorf("error parsing sink %q: %w", sinkName, err)
+		}
+
+		sink, err := sinkType.New(sinkConfig)
+		if err != nil {
+			return fmt.Errorf("error parsing sink %q: %w", sinkName, err)
+		}
+		if err := sink.Open(ctx); err != nil {
+			return fmt.Errorf("error opening sink %q: %w", sinkName, err)
+		}
+
+		dispatcher.Start(sink, baseConfig)
+		slog.Debug("started new sink",
+			slog.Group(
+				"sink",
+				"name", sinkName,
+				"type", sinkType.Name,
+			),
+		)
+	}
+	return nil
+}
+
+func getSinkType(name string) (SinkType, bool) {
+	sinkTypeRegistryMutex.RLock()
+	defer sinkTypeRegistryMutex.RUnlock()
+
+	sinkType, ok := sinkTypeRegistry[name]
+	return sinkType, ok
+}
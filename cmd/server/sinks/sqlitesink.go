@@ -0,0 +1,143 @@
+package sinks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/spf13/viper"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// How often rows older than the retention period are deleted.
+	// Retention is also checked at most this often, even if the retention period is shorter.
+	sqliteRetentionCheckInterval = time.Minute
+)
+
+// The table is keyed by (name, timestamp), with timestamps stored as unix nanoseconds to match the RPC API.
+// For example, to get the history of a single ticker:
+// ```
+// SELECT timestamp, value FROM ticks WHERE name = 'ticker01' ORDER BY timestamp;
+// ```
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS ticks (
+	name      TEXT    NOT NULL,
+	timestamp INTEGER NOT NULL,
+	value     REAL    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS ticks_name_timestamp ON ticks (name, timestamp);
+CREATE INDEX IF NOT EXISTS ticks_timestamp ON ticks (timestamp);
+`
+
+func init() {
+	RegisterSinkType(SinkType{
+		Name:        "sqlite",
+		Description: "Insert ticker updates into the ticks table of a local SQLite database, so history survives restarts and can be queried with SQL.",
+		Config:      SQLiteSinkConfig{},
+		New: func(sinkConfig *viper.Viper) (Sink, error) {
+			var cfg SQLiteSinkConfig
+			if err := config.Decode(sinkConfig, &cfg); err != nil {
+				return nil, err
+			}
+			return &SQLiteSink{cfg: cfg}, nil
+		},
+	})
+}
+
+type SQLiteSinkConfig struct {
+	BaseSinkConfig `mapstructure:",squash"`
+
+	File      string        `mapstructure:"file" validate:"required" description:"The SQLite database file to insert ticker updates into. The file (and schema) is created if it does not exist."`
+	Retention time.Duration `mapstructure:"retention" default:"0s" validate:"min=0" description:"Rows older than this are periodically deleted. Zero keeps all rows."`
+}
+
+// Inserts ticker updates into a local SQLite database.
+// Each batch is inserted in a single transaction.
+type SQLiteSink struct {
+	cfg SQLiteSinkConfig
+	db  *sql.DB
+
+	lastRetentionTimestamp time.Time
+}
+
+// Open (or create) the SQLite database file, and create the schema if needed.
+func (s *SQLiteSink) Open(ctx context.Context) error {
+	db, err := sql.Open("sqlite", s.cfg.File)
+	if err != nil {
+		return err
+	}
+	// SQLite allows only one writer at a time, and the sink only ever writes from one goroutine.
+	db.SetMaxOpenConns(1)
+
+	// WAL mode allows other processes to query the database while the sink is writing.
+	if _, err := db.ExecContext(ctx, "PRAGMA journal_mode=WAL; PRAGMA synchronous=NORMAL;"); err != nil {
+		db.Close()
+		return err
+	}
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return err
+	}
+
+	s.db = db
+	return nil
+}
+
+// The batch is inserted in a single transaction, so is either written in full, or not at all.
+func (s *SQLiteSink) Write(ctx context.Context, batch []ticker.TickerUpdate) (int, error) {
+	if err := s.insertBatch(ctx, batch); err != nil {
+		return 0, err
+	}
+	return len(batch), nil
+}
+
+func (s *SQLiteSink) insertBatch(ctx context.Context, batch []ticker.TickerUpdate) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	insertStatement, err := tx.PrepareContext(ctx, "INSERT INTO ticks (name, timestamp, value) VALUES (?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insertStatement.Close()
+
+	for _, update := range batch {
+		if _, err = insertStatement.ExecContext(ctx, update.Name, update.Timestamp.UnixNano(), update.Value); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Every batch is committed by Write, so flushing only deletes expired rows (if due).
+func (s *SQLiteSink) Flush(ctx context.Context) error {
+	if s.cfg.Retention <= 0 || time.Since(s.lastRetentionTimestamp) < sqliteRetentionCheckInterval {
+		return nil
+	}
+	s.lastRetentionTimestamp = time.Now()
+
+	cutoffTimestamp := time.Now().Add(-s.cfg.Retention).UnixNano()
+	result, err := s.db.ExecContext(ctx, "DELETE FROM ticks WHERE timestamp < ?", cutoffTimestamp)
+	if err != nil {
+		return err
+	}
+	deletedRows, _ := result.RowsAffected()
+	slog.Debug("deleted expired rows from sqlite database", "file", s.cfg.File, "deletedRows", deletedRows)
+	return nil
+}
+
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
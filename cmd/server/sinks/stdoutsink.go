@@ -0,0 +1,29 @@
+package sinks
+
+import (
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterSinkType(SinkType{
+		Name:        "stdout",
+		Description: "Write ticker updates to stdout, as CSV or JSON Lines. Mostly useful for debugging, or piping into other programs.",
+		Config:      StdoutSinkConfig{},
+		New: func(sinkConfig *viper.Viper) (Sink, error) {
+			var cfg StdoutSinkConfig
+			if err := config.Decode(sinkConfig, &cfg); err != nil {
+				return nil, err
+			}
+			return &lineSink{
+				format: cfg.Format,
+			}, nil
+		},
+	})
+}
+
+type StdoutSinkConfig struct {
+	BaseSinkConfig `mapstructure:",squash"`
+
+	Format string `mapstructure:"format" default:"jsonl" validate:"oneof=csv jsonl" description:"The line format to write, either csv or jsonl."`
+}
@@ -1,55 +1,154 @@
 package ticker
 
 import (
-	"errors"
-	"math/rand"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
 	"sync"
 	"time"
 
-	"github.com/spf13/viper"
+	"github.com/hmcalister/genron/cmd/server/calendar"
 )
 
+// The config shared by all ticker types.
+// Embed this in the config struct of each ticker type with `mapstructure:",squash"`.
+type BaseTickerConfig struct {
+	// Set from the ticker key by ParseTickers, rather than in the ticker config itself.
+	Name string `mapstructure:"name" schema:"-"`
+
+	Type         string  `mapstructure:"type" validate:"required" description:"The ticker type."`
+	Value        float64 `mapstructure:"value" validate:"required,min=0" description:"The initial value for the ticker. Must be non-negative."`
+	UpdatePeriod int64   `mapstructure:"updateperiod" validate:"required,gt=0" description:"The amount of time (in nanoseconds) between updates of the ticker. Must be greater than 0."`
+	RandomSeed   *int64  `mapstructure:"randomseed" description:"The random seed to use for the generator. If left unset, a random seed is generated and logged."`
+	Calendar     string  `mapstructure:"calendar" description:"The name of the calendar (under the calendars key) whose sessions the ticker trades in. The ticker is frozen while its market is closed. If left unset, the ticker trades continuously."`
+
+	Quote       QuoteConfig       `mapstructure:"quote"`
+	Trades      TradesConfig      `mapstructure:"trades"`
+	Impact      ImpactConfig      `mapstructure:"impact"`
+	Overnight   OvernightConfig   `mapstructure:"overnight"`
+	Seasonality SeasonalityConfig `mapstructure:"seasonality"`
+}
+
+// A consistent view of a ticker, as of the end of its last update.
+type TickerInfo struct {
+	Name                 string
+	Value                float64
+	LastUpdatedTimestamp time.Time
+	UpdatePeriod         time.Duration
+	Quote                Quote
+}
+
 type BaseTicker struct {
 	name                string
+	tickerType          string
 	value               float64
 	updatePeriod        time.Duration
 	lastUpdateTimestamp time.Time
+	randomSeed          int64
+	randSource          *rand.PCG
 	randGen             *rand.Rand
-	mu                  sync.RWMutex
+
+	// The quote is set around the value after every update, see quote.go.
+	quoteConfig    QuoteConfig
+	quote          Quote
+	quotedValue    float64
+	returnVariance float64
+
+	// Trades are generated at the quote after every update, see trades.go.
+	tradesConfig TradesConfig
+
+	// Executions of simulated orders move the value after every update, see impact.go.
+	impactConfig ImpactConfig
+	impact       ImpactState
+
+	// The ticker only updates during the sessions of its calendar, if it has one, and gaps at the open, see overnight.go.
+	calendar        *calendar.Calendar
+	overnightConfig OvernightConfig
+
+	// The value, timestamp, and quote as of the end of the last update, served by GetSnapshot, GetInfo, and GetQuote.
+	// An update changes the value and quote in separate steps, so readers are served the last committed update instead,
+	// and never see the value of one update with the quote of the previous update.
+	committed TickerInfo
+
+	// The volatility of stochastic tickers is scaled over the time of day, see seasonality.go.
+	seasonalityConfig SeasonalityConfig
+	seasonalityTable  []seasonalityPoint
+
+	mu sync.RWMutex
 }
 
-// Initialize only the base ticker attributes using the given viper config.
+// Initialize only the base ticker attributes using the given (already decoded and validated) config.
 // This initialization is shared between all tickers, so it is extracted here.
-// Note an error is returned if initialization is malformed, be sure to check and return!
 //
 // Does not lock the mutex, since this method will be called from the parent Initalize method, which already locks.
-func (t *BaseTicker) initializeBase(tickerConfig *viper.Viper) error {
-	if !tickerConfig.IsSet("name") {
-		return errors.New("error initializing ticker, name field not specified")
+func (t *BaseTicker) initializeBase(tickerConfig BaseTickerConfig) error {
+	if err := tickerConfig.Quote.validate(); err != nil {
+		return err
 	}
-	t.name = tickerConfig.GetString("name")
 
-	t.value = tickerConfig.GetFloat64("value")
-	if t.value < 0.0 {
-		return errors.New("error initializing ticker, specified initial value is negative")
-	}
+	t.name = tickerConfig.Name
+	t.tickerType = tickerConfig.Type
+	t.value = tickerConfig.Value
+	t.updatePeriod = time.Duration(tickerConfig.UpdatePeriod) * time.Nanosecond
 
-	t.updatePeriod = time.Duration(tickerConfig.GetInt64("updateperiod")) * time.Nanosecond
-	if t.updatePeriod <= 0.0 {
-		return errors.New("error initializing ticker, specified update period is negative")
+	if tickerConfig.RandomSeed != nil {
+		t.randomSeed = *tickerConfig.RandomSeed
+	} else {
+		// Seeds are drawn from the (randomly seeded) global generator rather than the current timestamp,
+		// so tickers initialized in the same instant do not share a seed.
+		// Log the generated seed so that the run can be reproduced later by setting it in the config.
+		t.randomSeed = rand.Int64()
+		slog.Info("no random seed specified, using generated random seed",
+			"tickerName", t.name,
+			"randomSeed", t.randomSeed,
+		)
 	}
 
-	var randomSeed int64
-	if tickerConfig.IsSet("randomseed") {
-		randomSeed = tickerConfig.GetInt64("randomseed")
-	} else {
-		randomSeed = time.Now().UnixNano()
+	// PCG is used over the math/rand sources since its output sequence is fixed for a given seed,
+	// and its state can be marshalled for snapshots.
+	t.randSource = rand.NewPCG(uint64(t.randomSeed), uint64(t.randomSeed))
+	t.randGen = rand.New(t.randSource)
+
+	// The initial quote has no size jitter, so the generator is left untouched until the first update.
+	t.quoteConfig = tickerConfig.Quote
+	t.quotedValue = t.value
+	t.quote = t.quoteAround(t.value, false)
+	t.tradesConfig = tickerConfig.Trades
+	t.impactConfig = tickerConfig.Impact
+	t.overnightConfig = tickerConfig.Overnight
+
+	seasonalityTable, err := tickerConfig.Seasonality.parseTable()
+	if err != nil {
+		return err
 	}
-	t.randGen = rand.New(rand.NewSource(randomSeed))
+	t.seasonalityConfig = tickerConfig.Seasonality
+	t.seasonalityTable = seasonalityTable
 
+	if tickerConfig.Calendar != "" {
+		c, ok := calendar.GetCalendar(tickerConfig.Calendar)
+		if !ok {
+			return fmt.Errorf("ticker %q has calendar %q: %w", t.name, tickerConfig.Calendar, calendar.ErrorUnknownCalendar)
+		}
+		t.calendar = c
+	}
+	t.commit()
 	return nil
 }
 
+// Initialize the base ticker attributes using the given (already decoded and validated) config.
+// Ticker types defined outside this package call this from their Initialize method, after decoding their config.
+func (t *BaseTicker) InitializeBase(tickerConfig BaseTickerConfig) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.initializeBase(tickerConfig)
+}
+
+// A single step of the model of a ticker, returning the next value of the ticker from the current value.
+// The volatility multiplier is the seasonality multiplier at the time of the update, see seasonality.go,
+// and the generator is the seeded generator of the ticker, so that runs with the same seed are reproducible.
+type ModelStep func(value float64, volatilityMultiplier float64, randGen *rand.Rand) float64
+
 func (t *BaseTicker) String() string {
 	return t.name
 }
@@ -58,13 +157,58 @@ func (t *BaseTicker) GetValue() float64 {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	return t.value
+	return t.committed.Value
 }
 
 func (t *BaseTicker) GetInfo() (string, float64, time.Time, time.Duration) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	return t.name, t.value, t.lastUpdateTimestamp, t.updatePeriod
+	return t.name, t.committed.Value, t.committed.LastUpdatedTimestamp, t.updatePeriod
+}
+
+func (t *BaseTicker) GetSnapshot() TickerInfo {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	info := t.committed
+	info.Name = t.name
+	info.UpdatePeriod = t.updatePeriod
+	return info
+}
+
+func (t *BaseTicker) CommitUpdate() TickerInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.commit()
+	info := t.committed
+	info.Name = t.name
+	info.UpdatePeriod = t.updatePeriod
+	return info
+}
+
+// Commit the current value, timestamp, and quote, to be served to readers.
+//
+// Does not lock the mutex, since this method is called from methods that already lock.
+func (t *BaseTicker) commit() {
+	t.committed = TickerInfo{
+		Value:                t.value,
+		LastUpdatedTimestamp: t.lastUpdateTimestamp,
+		Quote:                t.quote,
+	}
+}
+
+// Update the value of the ticker by a single step of the model, under the ticker lock.
+// Ticker types defined outside this package call this from their Update method.
+// Note that no ticker value may be below zero, so a negative result is clamped to zero.
+func (t *BaseTicker) UpdateValue(step ModelStep) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.value = step(t.value, t.volatilityMultiplier(), t.randGen)
+	if t.value < 0 {
+		t.value = 0
+	}
 }
 
 func (t *BaseTicker) SetLastUpdatedTimestamp(timestamp time.Time) {
@@ -72,3 +216,75 @@ func (t *BaseTicker) SetLastUpdatedTimestamp(timestamp time.Time) {
 	defer t.mu.Unlock()
 	t.lastUpdateTimestamp = timestamp
 }
+
+// Get the state shared by all tickers: the name, type, value, last-updated timestamp, and generator state.
+// Tickers with model-internal state should override GetState, call this method, then fill in ModelState.
+//
+// Does not lock the mutex, since this method will be called from GetState, which already locks.
+func (t *BaseTicker) getBaseState() (TickerState, error) {
+	generatorState, err := t.randSource.MarshalBinary()
+	if err != nil {
+		return TickerState{}, err
+	}
+
+	return TickerState{
+		Name:                t.name,
+		Type:                t.tickerType,
+		Value:               t.value,
+		LastUpdateTimestamp: t.lastUpdateTimestamp,
+		RandomState: RandomState{
+			Seed:      t.randomSeed,
+			Generator: generatorState,
+		},
+		Quote: QuoteState{
+			Quote:          t.quote,
+			ReturnVariance: t.returnVariance,
+		},
+		Impact: t.impact,
+	}, nil
+}
+
+// Set the state shared by all tickers from a previously taken TickerState.
+// Note an error is returned if the state does not belong to this ticker, be sure to check and return!
+//
+// Does not lock the mutex, since this method will be called from SetState, which already locks.
+func (t *BaseTicker) setBaseState(state TickerState) error {
+	if state.Name != t.name {
+		return fmt.Errorf("error restoring ticker %q, state belongs to ticker %q", t.name, state.Name)
+	}
+	if state.Type != t.tickerType {
+		return fmt.Errorf("error restoring ticker %q, state has type %q but ticker has type %q", t.name, state.Type, t.tickerType)
+	}
+	if state.Value < 0.0 {
+		return fmt.Errorf("error restoring ticker %q, state value is negative", t.name)
+	}
+
+	if err := t.randSource.UnmarshalBinary(state.RandomState.Generator); err != nil {
+		return fmt.Errorf("error restoring ticker %q, invalid generator state: %w", t.name, err)
+	}
+	t.randomSeed = state.RandomState.Seed
+	t.value = state.Value
+	t.lastUpdateTimestamp = state.LastUpdateTimestamp
+
+	// The quote was set around the value at the end of the last update, so the restored value is also the quoted value.
+	t.quotedValue = t.value
+	t.returnVariance = state.Quote.ReturnVariance
+	t.quote = state.Quote.Quote
+	t.impact = state.Impact
+	t.commit()
+	return nil
+}
+
+func (t *BaseTicker) GetState() (TickerState, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.getBaseState()
+}
+
+func (t *BaseTicker) SetState(state TickerState) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.setBaseState(state)
+}
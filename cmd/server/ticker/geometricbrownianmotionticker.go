@@ -1,12 +1,30 @@
 package ticker
 
 import (
-	"errors"
 	"math"
+	"math/rand/v2"
 
+	"github.com/hmcalister/genron/cmd/server/config"
 	"github.com/spf13/viper"
 )
 
+func init() {
+	RegisterTickerType(TickerType{
+		Name:        "GeometricBrownianMotion",
+		Description: "Update the ticker value by geometric Brownian motion, X_{t+dt} = X_t * exp((drift - 0.5 * volatility**2)dt + volatility*sqrt(dt)*Z), with dt=1.",
+		Config:      GeometricBrownianMotionConfig{},
+		New:         func() Ticker { return &GeometricBrownianMotionTicker{} },
+	})
+}
+
+type GeometricBrownianMotionConfig struct {
+	BaseTickerConfig `mapstructure:",squash"`
+
+	// Drift is allowed to be negative.
+	Drift      float64 `mapstructure:"drift" validate:"required" description:"The general trend of the stock price over time. Zero drift implies a martingale."`
+	Volatility float64 `mapstructure:"volatility" validate:"required,min=0" description:"The \"randomness\" of the stock price. Must be non-negative."`
+}
+
 type GeometricBrownianMotionTicker struct {
 	BaseTicker
 	drift      float64
@@ -14,38 +32,32 @@ type GeometricBrownianMotionTicker struct {
 }
 
 func (t *GeometricBrownianMotionTicker) Initialize(tickerConfig *viper.Viper) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
-	if err := t.initializeBase(tickerConfig); err != nil {
+	var cfg GeometricBrownianMotionConfig
+	if err := config.Decode(tickerConfig, &cfg); err != nil {
 		return err
 	}
 
-	// Drift is allowed to be negative.
-	t.drift = tickerConfig.GetFloat64("drift")
-
-	t.volatility = tickerConfig.GetFloat64("volatility")
-	if t.volatility < 0.0 {
-		return errors.New("error initializing geometric brownian motion ticker, volatility term is negative")
+	if err := t.InitializeBase(cfg.BaseTickerConfig); err != nil {
+		return err
 	}
+	t.drift = cfg.Drift
+	t.volatility = cfg.Volatility
 
 	return nil
 }
 
 func (t *GeometricBrownianMotionTicker) Update() {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
-	// Geometric Brownian Motion update looks like
-	// X_{t+dt} = X_t * exp((drift - 0.5 * volatility**2)dt + volatility*sqrt(dt)*Z)
-	// For a random gaussian number Z (simulating the random walk)
-	//
-	// We will discretize this to assume dt=1, and have the user set drift and volatility accordingly
-
-	dt := 1.0
-	exponent := (t.drift-0.5*math.Pow(t.volatility, 2))*dt + t.volatility*math.Sqrt(dt)*t.randGen.NormFloat64()
-	t.value *= math.Exp(exponent)
-	if t.value < 0 {
-		t.value = 0
-	}
+	t.UpdateValue(func(value float64, volatilityMultiplier float64, randGen *rand.Rand) float64 {
+		// Geometric Brownian Motion update looks like
+		// X_{t+dt} = X_t * exp((drift - 0.5 * volatility**2)dt + volatility*sqrt(dt)*Z)
+		// For a random gaussian number Z (simulating the random walk)
+		//
+		// We will discretize this to assume dt=1, and have the user set drift and volatility accordingly.
+		// The volatility is scaled by the seasonality profile at the time of the update.
+
+		dt := 1.0
+		volatility := t.volatility * volatilityMultiplier
+		exponent := (t.drift-0.5*math.Pow(volatility, 2))*dt + volatility*math.Sqrt(dt)*randGen.NormFloat64()
+		return value * math.Exp(exponent)
+	})
 }
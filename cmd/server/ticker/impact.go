@@ -0,0 +1,73 @@
+package ticker
+
+import (
+	"math"
+)
+
+// The models of the price impact of simulated orders.
+const (
+	// Executions do not move the value.
+	ImpactModelNone = "none"
+
+	// The value moves in proportion to the net size executed, impact.coefficient * size / impact.referencesize.
+	ImpactModelLinear = "linear"
+
+	// The value moves in proportion to the square root of the net size executed, impact.coefficient * sqrt(size / impact.referencesize),
+	// so that doubling the size of an order less than doubles its impact, as observed in real markets.
+	ImpactModelSquareRoot = "sqrt"
+)
+
+// The config of the price impact of simulated orders (see the orders package) on a ticker,
+// shared by all ticker types under the `impact` key. The defaults have no impact.
+type ImpactConfig struct {
+	Model             string  `mapstructure:"model" default:"none" validate:"oneof=none linear sqrt" description:"How executions of simulated orders move the ticker value. One of none, linear (in proportion to the net size executed), or sqrt (in proportion to the square root of the net size executed)."`
+	Coefficient       float64 `mapstructure:"coefficient" default:"0.001" validate:"min=0" description:"The log return of the value for a net execution of the reference size. Buys move the value up, and sells move it down."`
+	ReferenceSize     float64 `mapstructure:"referencesize" default:"10000" validate:"gt=0" description:"The net size executed that moves the value by the coefficient."`
+	PermanentFraction float64 `mapstructure:"permanentfraction" default:"0" validate:"min=0,max=1" description:"The fraction of each impact that is permanent. The rest is transient, and decays away."`
+	HalfLife          float64 `mapstructure:"halflife" default:"20" validate:"gt=0" description:"The number of updates after which the transient impact has decayed to half."`
+}
+
+// The state of the impact model of a ticker, kept in snapshots so transient impact survives a restart.
+type ImpactState struct {
+	// The net size (positive for buys) executed since the last update.
+	PendingSize int64 `json:"pendingSize,omitempty"`
+
+	// The log return by which the transient impact currently displaces the value.
+	Transient float64 `json:"transient,omitempty"`
+}
+
+// Record an execution of a simulated order, to be applied to the value at the next update.
+func (t *BaseTicker) RecordExecution(side Side, size int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if side == SideSell {
+		size = -size
+	}
+	t.impact.PendingSize += size
+}
+
+// Apply the impact of the executions since the last update to the value, after an update.
+// The transient impact of earlier executions decays, returning the value towards where it would be without them.
+func (t *BaseTicker) ApplyImpact() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cfg := t.impactConfig
+	if cfg.Model != ImpactModelLinear && cfg.Model != ImpactModelSquareRoot {
+		t.impact.PendingSize = 0
+		return
+	}
+
+	netSize := float64(t.impact.PendingSize) / cfg.ReferenceSize
+	t.impact.PendingSize = 0
+	impact := cfg.Coefficient * netSize
+	if cfg.Model == ImpactModelSquareRoot {
+		impact = cfg.Coefficient * math.Copysign(math.Sqrt(math.Abs(netSize)), netSize)
+	}
+
+	decay := math.Pow(0.5, 1/cfg.HalfLife)
+	transient := decay*t.impact.Transient + (1-cfg.PermanentFraction)*impact
+	t.value *= math.Exp(transient - t.impact.Transient + cfg.PermanentFraction*impact)
+	t.impact.Transient = transient
+}
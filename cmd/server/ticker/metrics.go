@@ -0,0 +1,118 @@
+package ticker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics of the ticker update loop, recorded by StartTicker and registered with the default registry.
+// The current value of each ticker is exported separately, by a TickerCollector.
+var (
+	metricUpdatesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "updates_total",
+		Help:      "The number of updates of each ticker.",
+	}, []string{"ticker"})
+
+	metricUpdateDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "update_duration_seconds",
+		Help:      "The time taken by each call to Update of each ticker.",
+		Buckets:   prometheus.ExponentialBuckets(1e-7, 4, 12),
+	}, []string{"ticker"})
+
+	metricUpdateLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "update_lag_seconds",
+		Help:      "The time between an update being due and the update being published, for each ticker.",
+		Buckets:   prometheus.ExponentialBuckets(1e-6, 4, 12),
+	}, []string{"ticker"})
+
+	metricUpdatesLaggingTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "updates_lagging_total",
+		Help:      "The number of updates of each ticker that took longer than the update period.",
+	}, []string{"ticker"})
+
+	metricTradesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "trades_total",
+		Help:      "The number of simulated trades of each ticker.",
+	}, []string{"ticker"})
+
+	metricVolumeTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "volume_total",
+		Help:      "The total size of the simulated trades of each ticker.",
+	}, []string{"ticker"})
+
+	metricMarketOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "market_open",
+		Help:      "Whether the market of each ticker is open (1) or closed (0), by the calendar of the ticker.",
+	}, []string{"ticker"})
+
+	metricUpdatePeriod = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "genron",
+		Subsystem: "ticker",
+		Name:      "update_period_seconds",
+		Help:      "The expected time between updates of each ticker.",
+	}, []string{"ticker"})
+)
+
+var (
+	tickerValueDesc = prometheus.NewDesc(
+		"genron_ticker_value",
+		"The current value of each ticker.",
+		[]string{"ticker"}, nil,
+	)
+	tickerSpreadDesc = prometheus.NewDesc(
+		"genron_ticker_spread",
+		"The current spread between the ask and bid of each ticker.",
+		[]string{"ticker"}, nil,
+	)
+	tickerLastUpdatedDesc = prometheus.NewDesc(
+		"genron_ticker_last_updated_timestamp_seconds",
+		"The unix timestamp of the last update of each ticker.",
+		[]string{"ticker"}, nil,
+	)
+)
+
+// A prometheus.Collector exporting the current value and spread of every ticker.
+// Values are read when scraped, rather than on every update, so exporting values costs nothing between scrapes.
+type TickerCollector struct {
+	tickers map[string]Ticker
+}
+
+func NewTickerCollector(tickers map[string]Ticker) *TickerCollector {
+	return &TickerCollector{
+		tickers: tickers,
+	}
+}
+
+func (c *TickerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tickerValueDesc
+	ch <- tickerSpreadDesc
+	ch <- tickerLastUpdatedDesc
+}
+
+func (c *TickerCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, t := range c.tickers {
+		info := t.GetSnapshot()
+		ch <- prometheus.MustNewConstMetric(tickerValueDesc, prometheus.GaugeValue, info.Value, info.Name)
+		// A quote missing a side has no spread.
+		if info.Quote.HasBid && info.Quote.HasAsk {
+			ch <- prometheus.MustNewConstMetric(tickerSpreadDesc, prometheus.GaugeValue, info.Quote.Ask-info.Quote.Bid, info.Name)
+		}
+		if !info.LastUpdatedTimestamp.IsZero() {
+			ch <- prometheus.MustNewConstMetric(tickerLastUpdatedDesc, prometheus.GaugeValue, float64(info.LastUpdatedTimestamp.UnixNano())/1e9, info.Name)
+		}
+	}
+}
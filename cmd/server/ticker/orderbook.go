@@ -0,0 +1,194 @@
+package ticker
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// A single aggregated price level of an order book.
+type PriceLevel struct {
+	Price float64
+	Size  int64
+
+	// The number of orders resting at the price.
+	Orders int
+}
+
+// The aggregated depth (L2) of an order book at one point in time.
+type OrderBookDepth struct {
+	Timestamp time.Time
+
+	// The best levels of each side, best first.
+	Bids []PriceLevel
+	Asks []PriceLevel
+}
+
+// Implemented by tickers whose value emerges from a limit order book, such as the OrderBookTicker.
+type OrderBookSource interface {
+	// Get the depth of the book, at most the given number of levels on each side.
+	GetOrderBook(levels int) OrderBookDepth
+}
+
+// A limit order resting in an order book. Prices are whole numbers of ticks.
+type bookOrder struct {
+	ID    uint64 `json:"id"`
+	Owner string `json:"owner"`
+	Side  Side   `json:"side"`
+	Price int64  `json:"price"`
+	Size  int64  `json:"size"`
+}
+
+// A fill of a resting (maker) order by an incoming (taker) order, at the price of the maker.
+type bookFill struct {
+	MakerOrderID uint64
+	MakerOwner   string
+	TakerOwner   string
+	Aggressor    Side
+	Price        int64
+	Size         int64
+}
+
+// The orders resting at a single price, in time priority.
+type bookLevel struct {
+	price  int64
+	orders []*bookOrder
+}
+
+// A price-time priority limit order book, matching incoming orders against resting orders.
+// Not safe for concurrent use, the order book ticker guards it with the ticker mutex.
+type orderBook struct {
+	// The levels of each side, best first: bids by descending price, asks by ascending price.
+	bids []*bookLevel
+	asks []*bookLevel
+
+	orders      map[uint64]*bookOrder
+	nextOrderID uint64
+}
+
+func newOrderBook() *orderBook {
+	return &orderBook{
+		orders:      make(map[uint64]*bookOrder),
+		nextOrderID: 1,
+	}
+}
+
+// Submit an order, matching it against the resting orders of the other side.
+// A price of zero is a market order, which matches at any price and never rests.
+// Any unmatched size of a limit order rests in the book.
+//
+// Returns the ID of the order (whether or not it rests), and the fills of the order.
+func (b *orderBook) submit(owner string, side Side, price int64, size int64) (uint64, []bookFill) {
+	orderID := b.nextOrderID
+	b.nextOrderID++
+
+	var fills []bookFill
+	opposite := &b.asks
+	if side == SideSell {
+		opposite = &b.bids
+	}
+	for size > 0 && len(*opposite) > 0 {
+		level := (*opposite)[0]
+		if price != 0 && ((side == SideBuy && level.price > price) || (side == SideSell && level.price < price)) {
+			break
+		}
+		for size > 0 && len(level.orders) > 0 {
+			maker := level.orders[0]
+			fillSize := min(size, maker.Size)
+			fills = append(fills, bookFill{
+				MakerOrderID: maker.ID,
+				MakerOwner:   maker.Owner,
+				TakerOwner:   owner,
+				Aggressor:    side,
+				Price:        level.price,
+				Size:         fillSize,
+			})
+			size -= fillSize
+			maker.Size -= fillSize
+			if maker.Size == 0 {
+				level.orders = level.orders[1:]
+				delete(b.orders, maker.ID)
+			}
+		}
+		if len(level.orders) == 0 {
+			*opposite = (*opposite)[1:]
+		}
+	}
+
+	if size > 0 && price != 0 {
+		b.rest(&bookOrder{ID: orderID, Owner: owner, Side: side, Price: price, Size: size})
+	}
+	return orderID, fills
+}
+
+// Add an order to the back of the queue at its price, without matching.
+func (b *orderBook) rest(order *bookOrder) {
+	levels := &b.bids
+	better := func(level *bookLevel) bool { return level.price > order.Price }
+	if order.Side == SideSell {
+		levels = &b.asks
+		better = func(level *bookLevel) bool { return level.price < order.Price }
+	}
+
+	i := sort.Search(len(*levels), func(i int) bool { return !better((*levels)[i]) })
+	if i == len(*levels) || (*levels)[i].price != order.Price {
+		*levels = slices.Insert(*levels, i, &bookLevel{price: order.Price})
+	}
+	(*levels)[i].orders = append((*levels)[i].orders, order)
+	b.orders[order.ID] = order
+}
+
+// Cancel a resting order. Returns false if the order is not resting (e.g. already filled).
+func (b *orderBook) cancel(orderID uint64) bool {
+	order, ok := b.orders[orderID]
+	if !ok {
+		return false
+	}
+	delete(b.orders, orderID)
+
+	levels := &b.bids
+	if order.Side == SideSell {
+		levels = &b.asks
+	}
+	levelIndex := slices.IndexFunc(*levels, func(level *bookLevel) bool { return level.price == order.Price })
+	level := (*levels)[levelIndex]
+	level.orders = slices.DeleteFunc(level.orders, func(o *bookOrder) bool { return o.ID == orderID })
+	if len(level.orders) == 0 {
+		*levels = slices.Delete(*levels, levelIndex, levelIndex+1)
+	}
+	return true
+}
+
+// Get the price and total size of the best level of a side. Returns false if the side is empty.
+func (b *orderBook) best(side Side) (int64, int64, bool) {
+	levels := b.bids
+	if side == SideSell {
+		levels = b.asks
+	}
+	if len(levels) == 0 {
+		return 0, 0, false
+	}
+	return levels[0].price, levels[0].size(), true
+}
+
+func (level *bookLevel) size() int64 {
+	var size int64
+	for _, order := range level.orders {
+		size += order.Size
+	}
+	return size
+}
+
+// Get every resting order, bids then asks, each side best first and in time priority.
+// Resting the orders in the same order into an empty book recreates the book.
+func (b *orderBook) allOrders() []bookOrder {
+	orders := make([]bookOrder, 0, len(b.orders))
+	for _, levels := range [][]*bookLevel{b.bids, b.asks} {
+		for _, level := range levels {
+			for _, order := range level.orders {
+				orders = append(orders, *order)
+			}
+		}
+	}
+	return orders
+}
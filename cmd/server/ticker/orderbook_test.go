@@ -0,0 +1,339 @@
+package ticker
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// An order submitted to the book by a test.
+type testBookOrder struct {
+	owner string
+	side  Side
+	price int64
+	size  int64
+}
+
+// Create a book by submitting the given orders in order, checking that none of them match.
+// The orders are given IDs from 1, in the order submitted.
+func newTestOrderBook(t *testing.T, orders []testBookOrder) *orderBook {
+	t.Helper()
+
+	b := newOrderBook()
+	for _, order := range orders {
+		if _, fills := b.submit(order.owner, order.side, order.price, order.size); len(fills) > 0 {
+			t.Fatalf("setup order %+v matched with fills %+v", order, fills)
+		}
+	}
+	return b
+}
+
+func checkBookOrders(t *testing.T, b *orderBook, want []bookOrder) {
+	t.Helper()
+
+	if got := b.allOrders(); !slices.Equal(got, want) {
+		t.Errorf("resting orders are %+v, expected %+v", got, want)
+	}
+	if len(b.orders) != len(want) {
+		t.Errorf("%d orders are indexed, expected %d", len(b.orders), len(want))
+	}
+}
+
+func TestOrderBookSubmit(t *testing.T) {
+	// Asks at 101, 102, and 103, with two orders at 101.
+	asks := []testBookOrder{
+		{owner: "a", side: SideSell, price: 102, size: 100},
+		{owner: "b", side: SideSell, price: 101, size: 100},
+		{owner: "c", side: SideSell, price: 101, size: 100},
+		{owner: "d", side: SideSell, price: 103, size: 100},
+	}
+	// Bids at 99 and 100.
+	bids := []testBookOrder{
+		{owner: "a", side: SideBuy, price: 99, size: 100},
+		{owner: "b", side: SideBuy, price: 100, size: 100},
+	}
+
+	tests := []struct {
+		name      string
+		setup     []testBookOrder
+		order     testBookOrder
+		wantFills []bookFill
+		// The resting orders after the order, see orderBook.allOrders.
+		wantOrders []bookOrder
+	}{
+		{
+			name:  "limit order rests without crossing",
+			setup: asks,
+			order: testBookOrder{owner: "x", side: SideBuy, price: 100, size: 50},
+			wantOrders: []bookOrder{
+				{ID: 5, Owner: "x", Side: SideBuy, Price: 100, Size: 50},
+				{ID: 2, Owner: "b", Side: SideSell, Price: 101, Size: 100},
+				{ID: 3, Owner: "c", Side: SideSell, Price: 101, Size: 100},
+				{ID: 1, Owner: "a", Side: SideSell, Price: 102, Size: 100},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 103, Size: 100},
+			},
+		},
+		{
+			name:  "time priority within a level",
+			setup: asks,
+			order: testBookOrder{owner: "x", side: SideBuy, price: 101, size: 150},
+			wantFills: []bookFill{
+				{MakerOrderID: 2, MakerOwner: "b", TakerOwner: "x", Aggressor: SideBuy, Price: 101, Size: 100},
+				{MakerOrderID: 3, MakerOwner: "c", TakerOwner: "x", Aggressor: SideBuy, Price: 101, Size: 50},
+			},
+			wantOrders: []bookOrder{
+				{ID: 3, Owner: "c", Side: SideSell, Price: 101, Size: 50},
+				{ID: 1, Owner: "a", Side: SideSell, Price: 102, Size: 100},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 103, Size: 100},
+			},
+		},
+		{
+			name:  "partial fills across levels up to the limit, then rests",
+			setup: asks,
+			order: testBookOrder{owner: "x", side: SideBuy, price: 102, size: 350},
+			wantFills: []bookFill{
+				{MakerOrderID: 2, MakerOwner: "b", TakerOwner: "x", Aggressor: SideBuy, Price: 101, Size: 100},
+				{MakerOrderID: 3, MakerOwner: "c", TakerOwner: "x", Aggressor: SideBuy, Price: 101, Size: 100},
+				{MakerOrderID: 1, MakerOwner: "a", TakerOwner: "x", Aggressor: SideBuy, Price: 102, Size: 100},
+			},
+			wantOrders: []bookOrder{
+				{ID: 5, Owner: "x", Side: SideBuy, Price: 102, Size: 50},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 103, Size: 100},
+			},
+		},
+		{
+			name:  "sell limit order matches the best bid first",
+			setup: bids,
+			order: testBookOrder{owner: "x", side: SideSell, price: 99, size: 150},
+			wantFills: []bookFill{
+				{MakerOrderID: 2, MakerOwner: "b", TakerOwner: "x", Aggressor: SideSell, Price: 100, Size: 100},
+				{MakerOrderID: 1, MakerOwner: "a", TakerOwner: "x", Aggressor: SideSell, Price: 99, Size: 50},
+			},
+			wantOrders: []bookOrder{
+				{ID: 1, Owner: "a", Side: SideBuy, Price: 99, Size: 50},
+			},
+		},
+		{
+			name:  "market order walks the book",
+			setup: asks,
+			order: testBookOrder{owner: "x", side: SideBuy, price: 0, size: 350},
+			wantFills: []bookFill{
+				{MakerOrderID: 2, MakerOwner: "b", TakerOwner: "x", Aggressor: SideBuy, Price: 101, Size: 100},
+				{MakerOrderID: 3, MakerOwner: "c", TakerOwner: "x", Aggressor: SideBuy, Price: 101, Size: 100},
+				{MakerOrderID: 1, MakerOwner: "a", TakerOwner: "x", Aggressor: SideBuy, Price: 102, Size: 100},
+				{MakerOrderID: 4, MakerOwner: "d", TakerOwner: "x", Aggressor: SideBuy, Price: 103, Size: 50},
+			},
+			wantOrders: []bookOrder{
+				{ID: 4, Owner: "d", Side: SideSell, Price: 103, Size: 50},
+			},
+		},
+		{
+			name:  "market order larger than the book never rests",
+			setup: bids,
+			order: testBookOrder{owner: "x", side: SideSell, price: 0, size: 500},
+			wantFills: []bookFill{
+				{MakerOrderID: 2, MakerOwner: "b", TakerOwner: "x", Aggressor: SideSell, Price: 100, Size: 100},
+				{MakerOrderID: 1, MakerOwner: "a", TakerOwner: "x", Aggressor: SideSell, Price: 99, Size: 100},
+			},
+			wantOrders: []bookOrder{},
+		},
+		{
+			name:  "market order against an empty side",
+			setup: bids,
+			order: testBookOrder{owner: "x", side: SideBuy, price: 0, size: 100},
+			wantOrders: []bookOrder{
+				{ID: 2, Owner: "b", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 1, Owner: "a", Side: SideBuy, Price: 99, Size: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestOrderBook(t, tt.setup)
+			orderID, fills := b.submit(tt.order.owner, tt.order.side, tt.order.price, tt.order.size)
+			if wantID := uint64(len(tt.setup) + 1); orderID != wantID {
+				t.Errorf("order ID is %d, expected %d", orderID, wantID)
+			}
+			if !slices.Equal(fills, tt.wantFills) {
+				t.Errorf("fills are %+v, expected %+v", fills, tt.wantFills)
+			}
+			checkBookOrders(t, b, tt.wantOrders)
+		})
+	}
+}
+
+func TestOrderBookCancel(t *testing.T) {
+	setup := []testBookOrder{
+		{owner: "a", side: SideBuy, price: 100, size: 100},
+		{owner: "b", side: SideBuy, price: 100, size: 100},
+		{owner: "c", side: SideBuy, price: 99, size: 100},
+		{owner: "d", side: SideSell, price: 101, size: 100},
+	}
+
+	tests := []struct {
+		name    string
+		cancel  uint64
+		wantOk  bool
+		wantBid int64
+		// The resting orders after the cancel, see orderBook.allOrders.
+		wantOrders []bookOrder
+	}{
+		{
+			name:    "first in the queue",
+			cancel:  1,
+			wantOk:  true,
+			wantBid: 100,
+			wantOrders: []bookOrder{
+				{ID: 2, Owner: "b", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 3, Owner: "c", Side: SideBuy, Price: 99, Size: 100},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 101, Size: 100},
+			},
+		},
+		{
+			name:    "last in the queue",
+			cancel:  2,
+			wantOk:  true,
+			wantBid: 100,
+			wantOrders: []bookOrder{
+				{ID: 1, Owner: "a", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 3, Owner: "c", Side: SideBuy, Price: 99, Size: 100},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 101, Size: 100},
+			},
+		},
+		{
+			name:    "only order of a level removes the level",
+			cancel:  3,
+			wantOk:  true,
+			wantBid: 100,
+			wantOrders: []bookOrder{
+				{ID: 1, Owner: "a", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 2, Owner: "b", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 101, Size: 100},
+			},
+		},
+		{
+			name:    "unknown order",
+			cancel:  99,
+			wantOk:  false,
+			wantBid: 100,
+			wantOrders: []bookOrder{
+				{ID: 1, Owner: "a", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 2, Owner: "b", Side: SideBuy, Price: 100, Size: 100},
+				{ID: 3, Owner: "c", Side: SideBuy, Price: 99, Size: 100},
+				{ID: 4, Owner: "d", Side: SideSell, Price: 101, Size: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestOrderBook(t, setup)
+			if ok := b.cancel(tt.cancel); ok != tt.wantOk {
+				t.Errorf("cancel returned %v, expected %v", ok, tt.wantOk)
+			}
+			if bid, _, _ := b.best(SideBuy); bid != tt.wantBid {
+				t.Errorf("best bid is %d, expected %d", bid, tt.wantBid)
+			}
+			checkBookOrders(t, b, tt.wantOrders)
+		})
+	}
+}
+
+func TestOrderBookCancelFilledOrder(t *testing.T) {
+	b := newTestOrderBook(t, []testBookOrder{
+		{owner: "a", side: SideSell, price: 101, size: 100},
+		{owner: "b", side: SideSell, price: 101, size: 100},
+	})
+	b.submit("x", SideBuy, 0, 150)
+	if b.cancel(1) {
+		t.Error("cancelled a filled order")
+	}
+	if !b.cancel(2) {
+		t.Error("could not cancel a partially filled order")
+	}
+	if _, _, ok := b.best(SideSell); ok {
+		t.Error("asks are not empty after cancelling the last order")
+	}
+
+	// An order resting after a cancel joins the back of the queue.
+	b = newTestOrderBook(t, []testBookOrder{
+		{owner: "a", side: SideBuy, price: 100, size: 100},
+		{owner: "b", side: SideBuy, price: 100, size: 100},
+	})
+	b.cancel(1)
+	b.submit("a", SideBuy, 100, 100)
+	checkBookOrders(t, b, []bookOrder{
+		{ID: 2, Owner: "b", Side: SideBuy, Price: 100, Size: 100},
+		{ID: 3, Owner: "a", Side: SideBuy, Price: 100, Size: 100},
+	})
+}
+
+func newTestOrderBookTicker(t *testing.T) *OrderBookTicker {
+	t.Helper()
+
+	tickerConfig := viper.New()
+	tickerConfig.Set("name", "BOOK")
+	tickerConfig.Set("type", "OrderBook")
+	tickerConfig.Set("value", 100)
+	tickerConfig.Set("updateperiod", 1000)
+	tickerConfig.Set("randomseed", 1)
+	tk, err := NewTickerFromConfig("BOOK", tickerConfig)
+	if err != nil {
+		t.Fatalf("error creating order book ticker: %v", err)
+	}
+	return tk.(*OrderBookTicker)
+}
+
+func TestOrderBookTickerStateRoundTrip(t *testing.T) {
+	original := newTestOrderBookTicker(t)
+	for range 200 {
+		original.Update()
+		original.UpdateQuote()
+		original.GenerateTrades()
+		original.CommitUpdate()
+	}
+	state, err := original.GetState()
+	if err != nil {
+		t.Fatalf("error getting state: %v", err)
+	}
+
+	restored := newTestOrderBookTicker(t)
+	if err := restored.SetState(state); err != nil {
+		t.Fatalf("error setting state: %v", err)
+	}
+
+	// The restored book has the same resting orders, in the same priority, and the agents know their own orders.
+	checkBookOrders(t, restored.book, original.book.allOrders())
+	if restored.book.nextOrderID != original.book.nextOrderID {
+		t.Errorf("next order ID is %d, expected %d", restored.book.nextOrderID, original.book.nextOrderID)
+	}
+	for i := range original.marketMakerOrders {
+		if !slices.Equal(restored.marketMakerOrders[i], original.marketMakerOrders[i]) {
+			t.Errorf("market maker %d has orders %v, expected %v", i, restored.marketMakerOrders[i], original.marketMakerOrders[i])
+		}
+	}
+	// The noise traders of the original may still hold IDs of filled orders, which are not restored.
+	wantNoiseOrders := slices.DeleteFunc(slices.Clone(original.noiseOrders), func(orderID uint64) bool {
+		_, ok := original.book.orders[orderID]
+		return !ok
+	})
+	if !slices.Equal(restored.noiseOrders, wantNoiseOrders) {
+		t.Errorf("noise traders have orders %v, expected %v", restored.noiseOrders, wantNoiseOrders)
+	}
+	if got, want := restored.GetOrderBook(1000), original.GetOrderBook(1000); !reflect.DeepEqual(got, want) {
+		t.Errorf("depth is %+v, expected %+v", got, want)
+	}
+	if got, want := restored.GetSnapshot(), original.GetSnapshot(); got != want {
+		t.Errorf("snapshot is %+v, expected %+v", got, want)
+	}
+
+	// Both tickers continue identically.
+	for i := range 50 {
+		original.Update()
+		restored.Update()
+		if got, want := restored.book.allOrders(), original.book.allOrders(); !slices.Equal(got, want) {
+			t.Fatalf("update %d after restoring has resting orders %+v, expected %+v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package ticker
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterTickerType(TickerType{
+		Name:        "OrderBook",
+		Description: "The ticker value is the mid-price of a simulated limit order book, fed at every update by a population of noise traders, market makers, and momentum traders. The quote and trades come from the book.",
+		Config:      OrderBookConfig{},
+		New:         func() Ticker { return &OrderBookTicker{} },
+	})
+}
+
+// The keys of the base ticker config rejected by order book tickers, since they would have no effect.
+// The price impact of simulated orders emerges from the book, the volatility emerges from the agents,
+// and the book is left as it was at the close, so does not gap at the open.
+var orderBookUnsupportedKeys = []string{"impact", "overnight", "seasonality"}
+
+// The owners of the orders of each agent population. Market makers are numbered, since each has its own inventory.
+const (
+	ownerNoiseTrader    = "noise"
+	ownerMomentumTrader = "momentum"
+	ownerMarketMaker    = "marketmaker"
+
+	// The executions of simulated orders (see RecordExecution) are submitted to the book as orders of this owner.
+	ownerClient = "client"
+)
+
+type OrderBookConfig struct {
+	BaseTickerConfig `mapstructure:",squash"`
+
+	TickSize float64 `mapstructure:"ticksize" default:"0.01" validate:"gt=0" description:"The price increment of the book. Every order is priced in whole ticks."`
+
+	NoiseTraders    NoiseTraderConfig    `mapstructure:"noisetraders"`
+	MarketMakers    MarketMakerConfig    `mapstructure:"marketmakers"`
+	MomentumTraders MomentumTraderConfig `mapstructure:"momentumtraders"`
+}
+
+// Noise traders trade at random, providing most of the liquidity away from the touch, and most of the volume.
+type NoiseTraderConfig struct {
+	Count                  int     `mapstructure:"count" default:"50" validate:"min=0" description:"The number of noise traders."`
+	Activity               float64 `mapstructure:"activity" default:"0.1" validate:"min=0,max=1" description:"The probability that each noise trader submits an order at each update."`
+	MarketOrderProbability float64 `mapstructure:"marketorderprobability" default:"0.2" validate:"min=0,max=1" description:"The probability that an order of a noise trader is a market order, rather than a limit order."`
+	MeanOffsetTicks        float64 `mapstructure:"meanoffsetticks" default:"5" validate:"min=0" description:"The mean distance of the limit orders of noise traders from the mid-price, in ticks. Offsets are exponentially distributed."`
+	MeanSize               float64 `mapstructure:"meansize" default:"100" validate:"gt=0" description:"The mean size of the orders of noise traders. Sizes are exponentially distributed."`
+	CancelProbability      float64 `mapstructure:"cancelprobability" default:"0.05" validate:"min=0,max=1" description:"The probability that each resting order of a noise trader is cancelled at each update."`
+}
+
+// Market makers quote both sides around the mid-price every update, skewing their quotes to shed inventory.
+type MarketMakerConfig struct {
+	Count          int     `mapstructure:"count" default:"2" validate:"min=0" description:"The number of market makers."`
+	SpreadTicks    int64   `mapstructure:"spreadticks" default:"2" validate:"min=1" description:"The spread of the quotes of each market maker, in ticks."`
+	Levels         int     `mapstructure:"levels" default:"3" validate:"min=1" description:"The number of price levels quoted on each side, one tick apart."`
+	Size           int64   `mapstructure:"size" default:"500" validate:"gt=0" description:"The size quoted at each level."`
+	InventoryLimit int64   `mapstructure:"inventorylimit" default:"5000" validate:"gt=0" description:"The largest position a market maker holds. A market maker at its limit stops quoting the side that would increase its position."`
+	SkewTicks      float64 `mapstructure:"skewticks" default:"2" validate:"min=0" description:"How far quotes are shifted against the inventory of a market maker, in ticks, when at the inventory limit. The shift is proportional to the inventory."`
+}
+
+// Momentum traders buy after the price rises, and sell after it falls, with market orders.
+type MomentumTraderConfig struct {
+	Count     int     `mapstructure:"count" default:"5" validate:"min=0" description:"The number of momentum traders."`
+	Activity  float64 `mapstructure:"activity" default:"0.05" validate:"min=0,max=1" description:"The probability that each momentum trader checks the trend at each update."`
+	HalfLife  float64 `mapstructure:"halflife" default:"50" validate:"gt=0" description:"The number of updates after which a return has half the weight in the trend."`
+	Threshold float64 `mapstructure:"threshold" default:"0.0001" validate:"min=0" description:"The trend (an exponentially weighted mean of the log returns of the mid-price) beyond which momentum traders trade."`
+	MeanSize  float64 `mapstructure:"meansize" default:"200" validate:"gt=0" description:"The mean size of the orders of momentum traders. Sizes are exponentially distributed."`
+}
+
+type OrderBookTicker struct {
+	BaseTicker
+	cfg OrderBookConfig
+
+	book *orderBook
+
+	// The resting orders of the noise traders, and of each market maker, in the order submitted.
+	// May include orders that have since been filled.
+	noiseOrders       []uint64
+	marketMakerOrders [][]uint64
+
+	// The owner of the orders of each market maker, and the index of each owner.
+	marketMakerOwners       []string
+	marketMakerOwnerIndices map[string]int
+
+	marketMakerInventories []int64
+	lastTradePrice         int64
+	trend                  float64
+	pendingTrades          []Trade
+}
+
+// The model state of an OrderBookTicker.
+type orderBookState struct {
+	Orders                 []bookOrder `json:"orders"`
+	NextOrderID            uint64      `json:"nextOrderID"`
+	MarketMakerInventories []int64     `json:"marketMakerInventories"`
+	LastTradePrice         int64       `json:"lastTradePrice"`
+	Trend                  float64     `json:"trend"`
+}
+
+func (t *OrderBookTicker) Initialize(tickerConfig *viper.Viper) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The value of the book emerges from its agents, so the keys that shape the value of other ticker types
+	// are rejected, rather than silently ignored.
+	for _, key := range orderBookUnsupportedKeys {
+		if tickerConfig.IsSet(key) {
+			return fmt.Errorf("order book ticker %q does not support the %s key", tickerConfig.GetString("name"), key)
+		}
+	}
+
+	var cfg OrderBookConfig
+	if err := config.Decode(tickerConfig, &cfg); err != nil {
+		return err
+	}
+
+	if err := t.initializeBase(cfg.BaseTickerConfig); err != nil {
+		return err
+	}
+	if cfg.Value < cfg.TickSize {
+		return fmt.Errorf("order book ticker %q must have an initial value of at least one tick", cfg.Name)
+	}
+	t.cfg = cfg
+	t.book = newOrderBook()
+	t.marketMakerOrders = make([][]uint64, cfg.MarketMakers.Count)
+	t.marketMakerOwners = make([]string, cfg.MarketMakers.Count)
+	t.marketMakerOwnerIndices = make(map[string]int, cfg.MarketMakers.Count)
+	for i := range cfg.MarketMakers.Count {
+		t.marketMakerOwners[i] = fmt.Sprintf("%s%d", ownerMarketMaker, i)
+		t.marketMakerOwnerIndices[t.marketMakerOwners[i]] = i
+	}
+	t.marketMakerInventories = make([]int64, cfg.MarketMakers.Count)
+	t.lastTradePrice = t.toTicks(cfg.Value)
+
+	// The market makers seed the book, so it has a quote before the first update.
+	t.requoteMarketMakers()
+	t.updateValue()
+	t.setQuoteFromBook()
+	t.commit()
+	return nil
+}
+
+// Step the agents of the book once: the market makers requote, then any executions of simulated orders trade,
+// then the noise and momentum traders trade, then the noise traders cancel. The value is then set to the mid-price.
+func (t *OrderBookTicker) Update() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requoteMarketMakers()
+	t.submitClientExecutions()
+	t.stepNoiseTraders()
+	t.stepMomentumTraders()
+	t.cancelNoiseOrders()
+
+	previousValue := t.value
+	t.updateValue()
+	if previousValue > 0 && t.value > 0 {
+		decay := math.Pow(0.5, 1/t.cfg.MomentumTraders.HalfLife)
+		t.trend = decay*t.trend + (1-decay)*math.Log(t.value/previousValue)
+	}
+}
+
+// The quote of an order book ticker is its best bid and ask, rather than set by the quote config.
+func (t *OrderBookTicker) UpdateQuote() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.setQuoteFromBook()
+}
+
+// The trades of an order book ticker are the fills in the book since the last update, rather than set by the trades config.
+func (t *OrderBookTicker) GenerateTrades() []Trade {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trades := t.pendingTrades
+	t.pendingTrades = nil
+	return trades
+}
+
+func (t *OrderBookTicker) GetOrderBook(levels int) OrderBookDepth {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	depth := OrderBookDepth{
+		Timestamp: t.lastUpdateTimestamp,
+		Bids:      make([]PriceLevel, 0, min(levels, len(t.book.bids))),
+		Asks:      make([]PriceLevel, 0, min(levels, len(t.book.asks))),
+	}
+	for _, level := range t.book.bids[:min(levels, len(t.book.bids))] {
+		depth.Bids = append(depth.Bids, PriceLevel{Price: t.fromTicks(level.price), Size: level.size(), Orders: len(level.orders)})
+	}
+	for _, level := range t.book.asks[:min(levels, len(t.book.asks))] {
+		depth.Asks = append(depth.Asks, PriceLevel{Price: t.fromTicks(level.price), Size: level.size(), Orders: len(level.orders)})
+	}
+	return depth
+}
+
+func (t *OrderBookTicker) GetState() (TickerState, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	state, err := t.getBaseState()
+	if err != nil {
+		return TickerState{}, err
+	}
+	state.ModelState, err = json.Marshal(orderBookState{
+		Orders:                 t.book.allOrders(),
+		NextOrderID:            t.book.nextOrderID,
+		MarketMakerInventories: t.marketMakerInventories,
+		LastTradePrice:         t.lastTradePrice,
+		Trend:                  t.trend,
+	})
+	return state, err
+}
+
+func (t *OrderBookTicker) SetState(state TickerState) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var modelState orderBookState
+	if err := json.Unmarshal(state.ModelState, &modelState); err != nil {
+		return fmt.Errorf("error restoring ticker %q, invalid model state: %w", t.name, err)
+	}
+	if len(modelState.MarketMakerInventories) != t.cfg.MarketMakers.Count {
+		return fmt.Errorf("error restoring ticker %q, state has %d market makers but ticker has %d", t.name, len(modelState.MarketMakerInventories), t.cfg.MarketMakers.Count)
+	}
+	if err := t.setBaseState(state); err != nil {
+		return err
+	}
+
+	t.book = newOrderBook()
+	t.book.nextOrderID = modelState.NextOrderID
+	t.noiseOrders = nil
+	t.marketMakerOrders = make([][]uint64, t.cfg.MarketMakers.Count)
+	for _, order := range modelState.Orders {
+		t.book.rest(&order)
+		if order.Owner == ownerNoiseTrader {
+			t.noiseOrders = append(t.noiseOrders, order.ID)
+		} else if i, ok := t.marketMakerOwnerIndices[order.Owner]; ok {
+			t.marketMakerOrders[i] = append(t.marketMakerOrders[i], order.ID)
+		}
+	}
+	// Order IDs increase with time, so sorting restores the order each agent submitted its orders in.
+	slices.Sort(t.noiseOrders)
+	for _, orderIDs := range t.marketMakerOrders {
+		slices.Sort(orderIDs)
+	}
+	t.marketMakerInventories = modelState.MarketMakerInventories
+	t.lastTradePrice = modelState.LastTradePrice
+	t.trend = modelState.Trend
+	t.pendingTrades = nil
+	t.setQuoteFromBook()
+	t.commit()
+	return nil
+}
+
+// --------------------------------------------------------------------------------
+// Agents. None of these lock the mutex, since they are called from Update, which already locks.
+
+// Cancel the quotes of every market maker, and quote again around the current mid-price, skewed against inventory.
+func (t *OrderBookTicker) requoteMarketMakers() {
+	cfg := t.cfg.MarketMakers
+	for i := range cfg.Count {
+		for _, orderID := range t.marketMakerOrders[i] {
+			t.book.cancel(orderID)
+		}
+		t.marketMakerOrders[i] = t.marketMakerOrders[i][:0]
+
+		// A long market maker lowers its quotes to sell more and buy less, and a short one raises them.
+		inventory := t.marketMakerInventories[i]
+		skew := int64(math.Round(-float64(inventory) / float64(cfg.InventoryLimit) * cfg.SkewTicks))
+		// Rounding the bid down and the ask up keeps the quotes symmetric about a mid-price between ticks.
+		mid := t.midTicks()
+		halfSpread := float64(cfg.SpreadTicks) / 2
+		bidPrice := int64(math.Floor(mid-halfSpread)) + skew
+		askPrice := int64(math.Ceil(mid+halfSpread)) + skew
+
+		owner := t.marketMakerOwners[i]
+		for level := range int64(cfg.Levels) {
+			if inventory < cfg.InventoryLimit && bidPrice-level >= 1 {
+				orderID := t.submit(owner, SideBuy, bidPrice-level, cfg.Size)
+				t.marketMakerOrders[i] = append(t.marketMakerOrders[i], orderID)
+			}
+			if inventory > -cfg.InventoryLimit {
+				orderID := t.submit(owner, SideSell, askPrice+level, cfg.Size)
+				t.marketMakerOrders[i] = append(t.marketMakerOrders[i], orderID)
+			}
+		}
+	}
+}
+
+func (t *OrderBookTicker) stepNoiseTraders() {
+	cfg := t.cfg.NoiseTraders
+	for range cfg.Count {
+		if t.randGen.Float64() >= cfg.Activity {
+			continue
+		}
+		side := t.randomSide()
+		size := t.exponentialSize(cfg.MeanSize)
+		if t.randGen.Float64() < cfg.MarketOrderProbability {
+			t.submit(ownerNoiseTrader, side, 0, size)
+			continue
+		}
+
+		offset := int64(math.Floor(cfg.MeanOffsetTicks * t.randGen.ExpFloat64()))
+		price := int64(math.Floor(t.midTicks())) - offset
+		if side == SideSell {
+			price = int64(math.Ceil(t.midTicks())) + offset
+		}
+		t.noiseOrders = append(t.noiseOrders, t.submit(ownerNoiseTrader, side, max(price, 1), size))
+	}
+}
+
+// Submit the net size of the executions of simulated orders since the last update as a market order,
+// so that their price impact emerges from the book. Nothing is left for ApplyImpact, and the impact config is rejected by Initialize.
+func (t *OrderBookTicker) submitClientExecutions() {
+	netSize := t.impact.PendingSize
+	t.impact.PendingSize = 0
+	if netSize > 0 {
+		t.submit(ownerClient, SideBuy, 0, netSize)
+	} else if netSize < 0 {
+		t.submit(ownerClient, SideSell, 0, -netSize)
+	}
+}
+
+func (t *OrderBookTicker) stepMomentumTraders() {
+	cfg := t.cfg.MomentumTraders
+	for range cfg.Count {
+		if t.randGen.Float64() >= cfg.Activity || math.Abs(t.trend) <= cfg.Threshold {
+			continue
+		}
+		side := SideBuy
+		if t.trend < 0 {
+			side = SideSell
+		}
+		t.submit(ownerMomentumTrader, side, 0, t.exponentialSize(cfg.MeanSize))
+	}
+}
+
+// Cancel each resting order of the noise traders with the cancel probability, and forget filled orders.
+func (t *OrderBookTicker) cancelNoiseOrders() {
+	cancelProbability := t.cfg.NoiseTraders.CancelProbability
+	t.noiseOrders = slices.DeleteFunc(t.noiseOrders, func(orderID uint64) bool {
+		if _, ok := t.book.orders[orderID]; !ok {
+			return true
+		}
+		if t.randGen.Float64() < cancelProbability {
+			t.book.cancel(orderID)
+			return true
+		}
+		return false
+	})
+}
+
+// Submit an order to the book, recording its fills as trades and in the inventories of any market makers.
+func (t *OrderBookTicker) submit(owner string, side Side, price int64, size int64) uint64 {
+	orderID, fills := t.book.submit(owner, side, price, size)
+	for _, fill := range fills {
+		t.pendingTrades = append(t.pendingTrades, Trade{
+			Timestamp: t.lastUpdateTimestamp,
+			Price:     t.fromTicks(fill.Price),
+			Size:      fill.Size,
+			Aggressor: fill.Aggressor,
+		})
+		t.lastTradePrice = fill.Price
+
+		// The maker is on the other side of the aggressor.
+		if i, ok := t.marketMakerOwnerIndices[fill.MakerOwner]; ok {
+			if fill.Aggressor == SideBuy {
+				t.marketMakerInventories[i] -= fill.Size
+			} else {
+				t.marketMakerInventories[i] += fill.Size
+			}
+		}
+		if i, ok := t.marketMakerOwnerIndices[fill.TakerOwner]; ok {
+			if fill.Aggressor == SideBuy {
+				t.marketMakerInventories[i] += fill.Size
+			} else {
+				t.marketMakerInventories[i] -= fill.Size
+			}
+		}
+	}
+	return orderID
+}
+
+// Set the value to the mid-price of the book. If either side is empty, the value is the last trade price.
+func (t *OrderBookTicker) updateValue() {
+	t.value = t.fromTicks(t.lastTradePrice)
+	bidPrice, _, bidOk := t.book.best(SideBuy)
+	askPrice, _, askOk := t.book.best(SideSell)
+	if bidOk && askOk {
+		t.value = t.fromTicks(bidPrice+askPrice) / 2
+	}
+}
+
+// Set the quote to the best bid and ask of the book. An empty side of the book has no price.
+func (t *OrderBookTicker) setQuoteFromBook() {
+	bidPrice, bidSize, bidOk := t.book.best(SideBuy)
+	askPrice, askSize, askOk := t.book.best(SideSell)
+	t.quote = Quote{
+		Bid:     t.fromTicks(bidPrice),
+		Ask:     t.fromTicks(askPrice),
+		BidSize: bidSize,
+		AskSize: askSize,
+		HasBid:  bidOk,
+		HasAsk:  askOk,
+	}
+}
+
+// The mid-price of the book in ticks (which may be half way between ticks), or the last trade price if either side is empty.
+func (t *OrderBookTicker) midTicks() float64 {
+	bidPrice, _, bidOk := t.book.best(SideBuy)
+	askPrice, _, askOk := t.book.best(SideSell)
+	if bidOk && askOk {
+		return float64(bidPrice+askPrice) / 2
+	}
+	return float64(t.lastTradePrice)
+}
+
+func (t *OrderBookTicker) randomSide() Side {
+	if t.randGen.Float64() < 0.5 {
+		return SideBuy
+	}
+	return SideSell
+}
+
+func (t *OrderBookTicker) exponentialSize(meanSize float64) int64 {
+	return max(1, int64(math.Round(meanSize*t.randGen.ExpFloat64())))
+}
+
+func (t *OrderBookTicker) toTicks(price float64) int64 {
+	return int64(math.Round(price / t.cfg.TickSize))
+}
+
+func (t *OrderBookTicker) fromTicks(price int64) float64 {
+	// Dividing by a whole number of ticks per unit (e.g. 100 for a tick size of 0.01) gives the closest float to the price,
+	// where multiplying by the tick size would not, e.g. 10032 * 0.01 = 100.32000000000001.
+	if ticksPerUnit := math.Round(1 / t.cfg.TickSize); math.Abs(ticksPerUnit-1/t.cfg.TickSize) < 1e-9 {
+		return float64(price) / ticksPerUnit
+	}
+	return float64(price) * t.cfg.TickSize
+}
@@ -0,0 +1,48 @@
+package ticker
+
+import (
+	"math"
+	"time"
+
+	"github.com/hmcalister/genron/cmd/server/calendar"
+)
+
+// The models of the return of a ticker over the time its market is closed, applied as a gap at the open.
+const (
+	// The ticker opens at the value it closed at.
+	OvernightModelNone = "none"
+
+	// The log return over the close is normally distributed, with mean overnight.mean * days and
+	// standard deviation overnight.volatility * sqrt(days), for the number of days the market was closed.
+	OvernightModelLognormal = "lognormal"
+)
+
+// The config of the gap of a ticker at the open of each session of its calendar,
+// shared by all ticker types under the `overnight` key. The defaults have no gap.
+type OvernightConfig struct {
+	Model      string  `mapstructure:"model" default:"none" validate:"oneof=none lognormal" description:"How the ticker value gaps at the open, after its market was closed. One of none or lognormal."`
+	Mean       float64 `mapstructure:"mean" default:"0" description:"The mean log return of the value per day the market is closed."`
+	Volatility float64 `mapstructure:"volatility" default:"0.01" validate:"min=0" description:"The standard deviation of the log return of the value per square root day the market is closed."`
+}
+
+// Get the status of the market of the ticker at the given time.
+// A ticker with no calendar trades continuously, so its market is always open.
+func (t *BaseTicker) GetMarketStatus(at time.Time) calendar.Status {
+	if t.calendar == nil {
+		return calendar.Status{Open: true}
+	}
+	return t.calendar.Status(at)
+}
+
+// Gap the value by a draw of the overnight model, for a market closed for the given duration.
+func (t *BaseTicker) ApplyOvernightGap(closed time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.overnightConfig.Model != OvernightModelLognormal || closed <= 0 {
+		return
+	}
+	days := closed.Hours() / 24
+	logReturn := t.overnightConfig.Mean*days + t.overnightConfig.Volatility*math.Sqrt(days)*t.randGen.NormFloat64()
+	t.value *= math.Exp(logReturn)
+}
@@ -0,0 +1,152 @@
+package ticker
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrorQuoteTickSizeRequired = errors.New("the ticks spread model requires a tick size greater than zero")
+)
+
+const (
+	tickTolerance = 1e-9
+)
+
+// The spread models of a quote.
+const (
+	// A constant spread, quote.spread.
+	SpreadModelFixed = "fixed"
+
+	// A constant number of ticks, quote.spreadticks * quote.ticksize.
+	SpreadModelTicks = "ticks"
+
+	// A spread of at least quote.spread, widening with the recent volatility of the ticker value:
+	// quote.spread + quote.volatilitymultiplier * sigma * value,
+	// where sigma is an exponentially weighted estimate of the standard deviation of the log returns of each update.
+	SpreadModelVolatility = "volatility"
+)
+
+// The config of the two-sided quote of a ticker, shared by all ticker types under the `quote` key.
+// The defaults quote the ticker value on both sides, with no spread.
+type QuoteConfig struct {
+	SpreadModel          string  `mapstructure:"spreadmodel" default:"fixed" validate:"oneof=fixed ticks volatility" description:"How the spread between the bid and ask is set. One of fixed (a constant spread), ticks (a constant number of ticks), or volatility (widening with the recent volatility of the ticker)."`
+	Spread               float64 `mapstructure:"spread" default:"0" validate:"min=0" description:"The spread of the fixed model, and the minimum spread of the volatility model."`
+	TickSize             float64 `mapstructure:"ticksize" default:"0" validate:"min=0" description:"The price increment of the quote. If greater than zero, the bid is rounded down and the ask up to a whole number of ticks. Required by the ticks model."`
+	SpreadTicks          int     `mapstructure:"spreadticks" default:"1" validate:"min=0" description:"The spread of the ticks model, in ticks."`
+	VolatilityMultiplier float64 `mapstructure:"volatilitymultiplier" default:"2" validate:"min=0" description:"The spread of the volatility model added per unit of volatility, as a multiple of the ticker value."`
+	VolatilityHalfLife   float64 `mapstructure:"volatilityhalflife" default:"20" validate:"gt=0" description:"The number of updates after which a return has half the weight in the volatility estimate of the volatility model."`
+	Size                 int64   `mapstructure:"size" default:"100" validate:"min=0" description:"The size quoted on each side."`
+	SizeJitter           float64 `mapstructure:"sizejitter" default:"0" validate:"min=0,max=1" description:"The fraction by which each quoted size varies randomly around the size at every update. Zero quotes the same size at every update."`
+}
+
+// A two-sided quote around the value (mid-price) of a ticker.
+// A side with no liquidity (e.g. an empty side of an order book) has no price, and its price and size are zero.
+// A side with a price but a size of zero (as replay tickers quote) has no limit on its size.
+type Quote struct {
+	Bid     float64 `json:"bid"`
+	Ask     float64 `json:"ask"`
+	BidSize int64   `json:"bidSize"`
+	AskSize int64   `json:"askSize"`
+	HasBid  bool    `json:"hasBid"`
+	HasAsk  bool    `json:"hasAsk"`
+}
+
+// The state of the quote model of a ticker, kept in snapshots so the volatility estimate survives a restart.
+type QuoteState struct {
+	Quote Quote `json:"quote"`
+
+	// The exponentially weighted variance of the log returns, used by the volatility spread model.
+	ReturnVariance float64 `json:"returnVariance,omitempty"`
+}
+
+// Check the parts of the quote config that cannot be expressed in struct tags.
+func (cfg QuoteConfig) validate() error {
+	if cfg.SpreadModel == SpreadModelTicks && cfg.TickSize <= 0 {
+		return ErrorQuoteTickSizeRequired
+	}
+	return nil
+}
+
+// Get the quote of the ticker, as of the end of the last update.
+func (t *BaseTicker) GetQuote() Quote {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.committed.Quote
+}
+
+// Requote the ticker around its current value, after an update.
+func (t *BaseTicker) UpdateQuote() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.updateQuote()
+}
+
+// Requote the ticker around its current value, updating the volatility estimate with the return since the last quote.
+//
+// Does not lock the mutex, since this method is called from methods that already lock.
+func (t *BaseTicker) updateQuote() {
+	if t.quoteConfig.SpreadModel == SpreadModelVolatility && t.quotedValue > 0 && t.value > 0 {
+		logReturn := math.Log(t.value / t.quotedValue)
+		decay := math.Pow(0.5, 1/t.quoteConfig.VolatilityHalfLife)
+		t.returnVariance = decay*t.returnVariance + (1-decay)*logReturn*logReturn
+	}
+	t.quotedValue = t.value
+	t.quote = t.quoteAround(t.value, true)
+}
+
+// Quote around the given value, drawing random sizes from the generator of the ticker if jitter is enabled.
+//
+// Does not lock the mutex, since this method is called from methods that already lock.
+func (t *BaseTicker) quoteAround(value float64, jitter bool) Quote {
+	cfg := t.quoteConfig
+
+	var spread float64
+	switch cfg.SpreadModel {
+	case SpreadModelTicks:
+		spread = float64(cfg.SpreadTicks) * cfg.TickSize
+	case SpreadModelVolatility:
+		spread = cfg.Spread + cfg.VolatilityMultiplier*math.Sqrt(t.returnVariance)*value
+	default:
+		spread = cfg.Spread
+	}
+
+	bid := value - spread/2
+	ask := value + spread/2
+	if cfg.TickSize > 0 {
+		// The tolerance stops prices already on a tick being rounded to the next tick by floating point error.
+		bid = math.Floor(bid/cfg.TickSize+tickTolerance) * cfg.TickSize
+		if cfg.SpreadModel == SpreadModelTicks {
+			// Rounding the ask separately could widen the spread by a tick.
+			ask = bid + spread
+		} else {
+			ask = math.Ceil(ask/cfg.TickSize-tickTolerance) * cfg.TickSize
+		}
+	}
+	// As with the value, no price may be below zero.
+	bid = max(bid, 0)
+	ask = max(ask, 0)
+
+	quote := Quote{
+		Bid:     bid,
+		Ask:     ask,
+		BidSize: cfg.Size,
+		AskSize: cfg.Size,
+		HasBid:  true,
+		HasAsk:  true,
+	}
+	if jitter && cfg.SizeJitter > 0 && cfg.Size > 0 {
+		quote.BidSize = t.jitterSize()
+		quote.AskSize = t.jitterSize()
+	}
+	return quote
+}
+
+// Draw a size uniformly within the jitter of the configured size, of at least one.
+func (t *BaseTicker) jitterSize() int64 {
+	cfg := t.quoteConfig
+	scale := 1 + cfg.SizeJitter*(2*t.randGen.Float64()-1)
+	return max(1, int64(math.Round(float64(cfg.Size)*scale)))
+}
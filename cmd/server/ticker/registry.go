@@ -0,0 +1,166 @@
+package ticker
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+)
+
+// A description of a single ticker config parameter.
+// Used to document ticker types, e.g. through the ListTickerTypes RPC.
+type TickerParameter struct {
+	// The config key of the parameter, as written in the config file.
+	Name string
+
+	// The datatype of the parameter, e.g. "float64".
+	Datatype string
+
+	// The default value of the parameter, if it is not required.
+	Default string
+
+	// If true, the parameter must be set in the config.
+	Required bool
+
+	Description string
+}
+
+// A registered ticker type.
+type TickerType struct {
+	// The name of the type, as given in the `type` field of a ticker config.
+	Name string
+
+	Description string
+
+	// The zero value of the typed config struct of this ticker type, e.g. GeometricBrownianMotionConfig{}.
+	// The struct must embed BaseTickerConfig, and is used to document the parameters and generate the config schema.
+	// See the config package for the struct tags used.
+	Config any
+
+	// Create a new, uninitialized ticker of this type.
+	// Initialize is called on the result with the ticker config.
+	New func() Ticker
+}
+
+// Get the parameters of the ticker type, including those shared by all ticker types,
+// from the typed config struct.
+func (tickerType TickerType) AllParameters() []TickerParameter {
+	fields := config.Fields(tickerType.Config)
+	parameters := make([]TickerParameter, 0, len(fields))
+	for _, field := range fields {
+		if field.Hidden {
+			continue
+		}
+		parameters = append(parameters, TickerParameter{
+			Name:        field.Key,
+			Datatype:    field.Datatype,
+			Default:     field.Default,
+			Required:    field.Required,
+			Description: field.Description,
+		})
+	}
+	return parameters
+}
+
+// Generate the JSON Schema of a single ticker config of this type.
+func (tickerType TickerType) Schema() *config.JSONSchema {
+	schema := config.Schema(tickerType.Config)
+	schema.Title = tickerType.Name
+	schema.Description = tickerType.Description
+	schema.Properties["type"].Const = tickerType.Name
+	return schema
+}
+
+// Generate the JSON Schema of the `tickers` config key,
+// a map from ticker name to a ticker config of any registered type.
+func TickersSchema() *config.JSONSchema {
+	tickerSchemas := make([]*config.JSONSchema, 0)
+	for _, tickerType := range ListTickerTypes() {
+		tickerSchemas = append(tickerSchemas, tickerType.Schema())
+	}
+	return &config.JSONSchema{
+		Type:                 "object",
+		Description:          "The tickers to create and manage, keyed by ticker name.",
+		AdditionalProperties: &config.JSONSchema{OneOf: tickerSchemas},
+	}
+}
+
+var (
+	tickerTypeRegistryMutex sync.RWMutex
+	tickerTypeRegistry      = make(map[string]TickerType)
+)
+
+// Register a ticker type, making it available to NewTickerFromConfig.
+// Built-in ticker types register themselves in an init function, and packages embedding GEnron
+// may register their own types in the same way, before any tickers are parsed.
+//
+// Panics if the type is missing a name, config, or constructor, or if a type with the same name is already registered.
+func RegisterTickerType(tickerType TickerType) {
+	tickerTypeRegistryMutex.Lock()
+	defer tickerTypeRegistryMutex.Unlock()
+
+	if tickerType.Name == "" {
+		panic("ticker type registered without a name")
+	}
+	if tickerType.New == nil || tickerType.Config == nil {
+		panic(fmt.Sprintf("ticker type %q registered without a constructor or config", tickerType.Name))
+	}
+	if _, ok := tickerTypeRegistry[tickerType.Name]; ok {
+		panic(fmt.Sprintf("ticker type %q registered twice", tickerType.Name))
+	}
+	tickerTypeRegistry[tickerType.Name] = tickerType
+}
+
+// Get the registered ticker type with the given name.
+func GetTickerType(name string) (TickerType, bool) {
+	tickerTypeRegistryMutex.RLock()
+	defer tickerTypeRegistryMutex.RUnlock()
+
+	tickerType, ok := tickerTypeRegistry[name]
+	return tickerType, ok
+}
+
+// Get all registered ticker types, sorted by name.
+func ListTickerTypes() []TickerType {
+	tickerTypeRegistryMutex.RLock()
+	defer tickerTypeRegistryMutex.RUnlock()
+
+	tickerTypes := make([]TickerType, 0, len(tickerTypeRegistry))
+	for _, tickerType := range tickerTypeRegistry {
+		tickerTypes = append(tickerTypes, tickerType)
+	}
+	slices.SortFunc(tickerTypes, func(a, b TickerType) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return tickerTypes
+}
+
+// Write a human readable description of every registered ticker type and its parameters.
+func PrintTickerTypes(w io.Writer) error {
+	for _, tickerType := range ListTickerTypes() {
+		if _, err := fmt.Fprintf(w, "%s\n\t%s\n\n", tickerType.Name, tickerType.Description); err != nil {
+			return err
+		}
+
+		tabWriter := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tabWriter, "\tKEY\tDATATYPE\tDEFAULT\tDESCRIPTION")
+		for _, parameter := range tickerType.AllParameters() {
+			defaultValue := parameter.Default
+			if parameter.Required {
+				defaultValue = "(required)"
+			}
+			fmt.Fprintf(tabWriter, "\t%s\t%s\t%s\t%s\n", parameter.Name, parameter.Datatype, defaultValue, parameter.Description)
+		}
+		if err := tabWriter.Flush(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package ticker_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/hmcalister/genron/cmd/server/config"
+	"github.com/hmcalister/genron/cmd/server/ticker"
+	"github.com/spf13/viper"
+)
+
+// A ticker type defined outside the ticker package, as a program embedding GEnron would define one.
+// The value moves by a fixed step, either up or down at random.
+type coinFlipConfig struct {
+	ticker.BaseTickerConfig `mapstructure:",squash"`
+
+	Step float64 `mapstructure:"step" validate:"required,gt=0" description:"The amount the value moves by at every update."`
+}
+
+type coinFlipTicker struct {
+	ticker.BaseTicker
+	step float64
+}
+
+func (t *coinFlipTicker) Initialize(tickerConfig *viper.Viper) error {
+	var cfg coinFlipConfig
+	if err := config.Decode(tickerConfig, &cfg); err != nil {
+		return err
+	}
+
+	if err := t.InitializeBase(cfg.BaseTickerConfig); err != nil {
+		return err
+	}
+	t.step = cfg.Step
+
+	return nil
+}
+
+func (t *coinFlipTicker) Update() {
+	t.UpdateValue(func(value float64, volatilityMultiplier float64, randGen *rand.Rand) float64 {
+		if randGen.IntN(2) == 0 {
+			return value - t.step*volatilityMultiplier
+		}
+		return value + t.step*volatilityMultiplier
+	})
+}
+
+func init() {
+	ticker.RegisterTickerType(ticker.TickerType{
+		Name:        "TestCoinFlip",
+		Description: "Move the value up or down by a fixed step at random.",
+		Config:      coinFlipConfig{},
+		New:         func() ticker.Ticker { return &coinFlipTicker{} },
+	})
+}
+
+func newCoinFlipTicker(t *testing.T, value float64, step float64, seed int64) ticker.Ticker {
+	t.Helper()
+
+	tickerConfig := viper.New()
+	tickerConfig.Set("name", "COIN")
+	tickerConfig.Set("type", "TestCoinFlip")
+	tickerConfig.Set("value", value)
+	tickerConfig.Set("updateperiod", 1000)
+	tickerConfig.Set("randomseed", seed)
+	tickerConfig.Set("step", step)
+	tk, err := ticker.NewTickerFromConfig("COIN", tickerConfig)
+	if err != nil {
+		t.Fatalf("error creating ticker: %v", err)
+	}
+	return tk
+}
+
+// Update the ticker the given number of times and return the committed values.
+func updateCoinFlipTicker(tk ticker.Ticker, updates int) []float64 {
+	values := make([]float64, 0, updates)
+	for range updates {
+		tk.Update()
+		values = append(values, tk.CommitUpdate().Value)
+	}
+	return values
+}
+
+func TestRegisterTickerTypeOutsidePackage(t *testing.T) {
+	tickerType, ok := ticker.GetTickerType("TestCoinFlip")
+	if !ok {
+		t.Fatal("ticker type not registered")
+	}
+	foundStep := false
+	for _, parameter := range tickerType.AllParameters() {
+		if parameter.Name == "step" {
+			foundStep = parameter.Required
+		}
+	}
+	if !foundStep {
+		t.Error("parameters do not include the required step parameter")
+	}
+
+	const step = 0.5
+	tk := newCoinFlipTicker(t, 100, step, 1)
+	if name := tk.String(); name != "COIN" {
+		t.Errorf("name is %q, expected %q", name, "COIN")
+	}
+	quote := tk.GetQuote()
+	if !quote.HasBid || !quote.HasAsk {
+		t.Errorf("initial quote %+v is not two-sided", quote)
+	}
+
+	previous := 100.0
+	for i, value := range updateCoinFlipTicker(tk, 20) {
+		if diff := value - previous; diff != step && diff != -step {
+			t.Fatalf("update %d moved the value from %v to %v, expected a move of %v", i, previous, value, step)
+		}
+		previous = value
+	}
+}
+
+func TestRegisteredTickerOutsidePackageReproducible(t *testing.T) {
+	first := updateCoinFlipTicker(newCoinFlipTicker(t, 100, 1, 42), 20)
+	second := updateCoinFlipTicker(newCoinFlipTicker(t, 100, 1, 42), 20)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("update %d is %v with the first ticker but %v with the second, expected the same seed to give the same values", i, first[i], second[i])
+		}
+	}
+
+	// Restoring the state of a ticker continues the same sequence.
+	tk := newCoinFlipTicker(t, 100, 1, 7)
+	updateCoinFlipTicker(tk, 5)
+	state, err := tk.GetState()
+	if err != nil {
+		t.Fatalf("error getting state: %v", err)
+	}
+	want := updateCoinFlipTicker(tk, 10)
+
+	restored := newCoinFlipTicker(t, 100, 1, 7)
+	if err := restored.SetState(state); err != nil {
+		t.Fatalf("error setting state: %v", err)
+	}
+	got := updateCoinFlipTicker(restored, 10)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("update %d after restoring is %v, expected %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRegisteredTickerOutsidePackageClampsValue(t *testing.T) {
+	tk := newCoinFlipTicker(t, 0.5, 1, 3)
+	for i, value := range updateCoinFlipTicker(tk, 50) {
+		if value < 0 {
+			t.Fatalf("update %d has negative value %v", i, value)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ticker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	ErrorReplayTickerNotConfigurable = errors.New("replay tickers are created from a journal, not from config")
+	ErrorReplayTickerNotRestorable   = errors.New("replay tickers cannot be restored from a snapshot")
+)
+
+// A ticker whose values are replayed from a journal, rather than simulated.
+// Replay tickers serve the recorded quote of each update, and publish its recorded trades (see the journal package).
+// Replay tickers are not registered as a ticker type, since they are created by the replayer
+// (see the journal package) for each ticker name found in the journal, and are never started with StartTicker.
+type ReplayTicker struct {
+	BaseTicker
+}
+
+func NewReplayTicker(name string, updatePeriod time.Duration) *ReplayTicker {
+	t := &ReplayTicker{}
+	t.name = name
+	t.tickerType = "Replay"
+	t.updatePeriod = updatePeriod
+	return t
+}
+
+func (t *ReplayTicker) Initialize(tickerConfig *viper.Viper) error {
+	return ErrorReplayTickerNotConfigurable
+}
+
+// Replay tickers are updated by Replay, not by StartTicker, so Update does nothing.
+func (t *ReplayTicker) Update() {}
+
+// Set the value, quote, and last updated timestamp of the ticker to a replayed update.
+func (t *ReplayTicker) Replay(update TickerUpdate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.value = update.Value
+	t.quote = update.Quote
+	t.lastUpdateTimestamp = update.Timestamp
+	t.commit()
+}
+
+// Replay tickers have no generator, so only the value and timestamp are included.
+func (t *ReplayTicker) GetState() (TickerState, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return TickerState{
+		Name:                t.name,
+		Type:                t.tickerType,
+		Value:               t.value,
+		LastUpdateTimestamp: t.lastUpdateTimestamp,
+		Quote:               QuoteState{Quote: t.quote},
+	}, nil
+}
+
+// The state of a replay ticker is determined by the journal, so cannot be restored.
+func (t *ReplayTicker) SetState(state TickerState) error {
+	return ErrorReplayTickerNotRestorable
+}
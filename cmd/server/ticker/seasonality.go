@@ -0,0 +1,143 @@
+package ticker
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// The profiles of the volatility of a ticker over the time of day.
+const (
+	// The volatility is the same at every time of day.
+	SeasonalityProfileNone = "none"
+
+	// The volatility is high at the open, falls to a low at midday, and rises again into the close,
+	// as observed in real equity markets. The multiplier is quadratic in the fraction of the session elapsed,
+	// from seasonality.openmultiplier at the open, to seasonality.middaymultiplier halfway through, to seasonality.closemultiplier at the close.
+	SeasonalityProfileUShape = "ushape"
+
+	// The multiplier is interpolated linearly between the times of day of seasonality.table.
+	SeasonalityProfileTable = "table"
+)
+
+// The config of the intraday seasonality of the volatility of a ticker, shared by all ticker types under the `seasonality` key.
+// The defaults have no seasonality.
+type SeasonalityConfig struct {
+	Profile          string             `mapstructure:"profile" default:"none" validate:"oneof=none ushape table" description:"How the volatility of the ticker varies over the time of day. One of none, ushape (high at the open and close, low at midday), or table (interpolated from seasonality.table)."`
+	OpenMultiplier   float64            `mapstructure:"openmultiplier" default:"2" validate:"min=0" description:"The volatility multiplier at the open, for the ushape profile."`
+	MiddayMultiplier float64            `mapstructure:"middaymultiplier" default:"0.6" validate:"min=0" description:"The volatility multiplier halfway through the session, for the ushape profile."`
+	CloseMultiplier  float64            `mapstructure:"closemultiplier" default:"1.5" validate:"min=0" description:"The volatility multiplier at the close, for the ushape profile."`
+	Table            map[string]float64 `mapstructure:"table" description:"The volatility multiplier at each time of day, keyed by time as HH:MM, for the table profile. Multipliers are interpolated linearly between times, and held constant before the first time and after the last."`
+}
+
+// A single time of day of a seasonality table, and its volatility multiplier.
+type seasonalityPoint struct {
+	timeOfDay  time.Duration
+	multiplier float64
+}
+
+// Parse the table of a seasonality config, sorted by time of day.
+// Returns an error if the table profile is used with an empty table, or the table has an invalid time or a negative multiplier.
+func (cfg SeasonalityConfig) parseTable() ([]seasonalityPoint, error) {
+	if cfg.Profile != SeasonalityProfileTable {
+		return nil, nil
+	}
+	if len(cfg.Table) == 0 {
+		return nil, fmt.Errorf("seasonality profile %q requires a non-empty seasonality.table", SeasonalityProfileTable)
+	}
+
+	points := make([]seasonalityPoint, 0, len(cfg.Table))
+	for clock, multiplier := range cfg.Table {
+		parsed, err := time.Parse("15:04", clock)
+		if err != nil {
+			return nil, fmt.Errorf("seasonality.table has invalid time %q, expected HH:MM", clock)
+		}
+		if multiplier < 0 {
+			return nil, fmt.Errorf("seasonality.table has negative multiplier %g at %s", multiplier, clock)
+		}
+		points = append(points, seasonalityPoint{
+			timeOfDay:  time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute,
+			multiplier: multiplier,
+		})
+	}
+	slices.SortFunc(points, func(a, b seasonalityPoint) int {
+		return int(a.timeOfDay - b.timeOfDay)
+	})
+	return points, nil
+}
+
+// Get the multiplier of the volatility of the ticker at the last update timestamp, i.e. the time of the current update.
+// The time of day is taken in the time zone of the calendar of the ticker, and the session is the session of the calendar.
+// A ticker with no calendar uses UTC, and treats the whole day as its session.
+//
+// Does not lock the mutex, since this method will be called from Update, which already locks.
+func (t *BaseTicker) volatilityMultiplier() float64 {
+	timestamp := t.lastUpdateTimestamp
+	if timestamp.IsZero() {
+		return 1
+	}
+
+	switch t.seasonalityConfig.Profile {
+	case SeasonalityProfileUShape:
+		sessionFraction, ok := t.sessionFraction(timestamp)
+		if !ok {
+			return 1
+		}
+		// The U-shape is two parabolas meeting at their minimum halfway through the session.
+		cfg := t.seasonalityConfig
+		distanceFromMidday := 2*sessionFraction - 1
+		edgeMultiplier := cfg.CloseMultiplier
+		if sessionFraction < 0.5 {
+			edgeMultiplier = cfg.OpenMultiplier
+		}
+		return cfg.MiddayMultiplier + (edgeMultiplier-cfg.MiddayMultiplier)*distanceFromMidday*distanceFromMidday
+	case SeasonalityProfileTable:
+		return interpolateSeasonality(t.seasonalityTable, t.timeOfDay(timestamp))
+	default:
+		return 1
+	}
+}
+
+// Get the fraction (between 0 and 1) of the session elapsed at the given time.
+// Returns false if the market of the ticker is closed at the given time.
+//
+// Does not lock the mutex, since this method will be called from Update, which already locks.
+func (t *BaseTicker) sessionFraction(timestamp time.Time) (float64, bool) {
+	if t.calendar == nil {
+		return float64(t.timeOfDay(timestamp)) / float64(24*time.Hour), true
+	}
+	status := t.calendar.Status(timestamp)
+	if !status.Open {
+		return 0, false
+	}
+	return float64(timestamp.Sub(status.SessionOpen)) / float64(status.NextClose.Sub(status.SessionOpen)), true
+}
+
+// Get the time since midnight of the given time, in the time zone of the calendar of the ticker (or UTC with no calendar).
+//
+// Does not lock the mutex, since this method will be called from Update, which already locks.
+func (t *BaseTicker) timeOfDay(timestamp time.Time) time.Duration {
+	location := time.UTC
+	if t.calendar != nil {
+		location = t.calendar.Location()
+	}
+	// The clock is read directly, rather than subtracting midnight, so that the time of day is kept across a daylight saving change.
+	hour, minute, second := timestamp.In(location).Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second +
+		time.Duration(timestamp.Nanosecond())
+}
+
+// Interpolate the multiplier of a (sorted, non-empty) seasonality table linearly at the given time of day.
+func interpolateSeasonality(points []seasonalityPoint, timeOfDay time.Duration) float64 {
+	if timeOfDay <= points[0].timeOfDay {
+		return points[0].multiplier
+	}
+	for i := 1; i < len(points); i++ {
+		if timeOfDay <= points[i].timeOfDay {
+			previous := points[i-1]
+			fraction := float64(timeOfDay-previous.timeOfDay) / float64(points[i].timeOfDay-previous.timeOfDay)
+			return previous.multiplier + fraction*(points[i].multiplier-previous.multiplier)
+		}
+	}
+	return points[len(points)-1].multiplier
+}
@@ -0,0 +1,138 @@
+package ticker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The state of a ticker's random number generator.
+type RandomState struct {
+	// The seed the generator was created with, either from the config or generated at startup.
+	// Not needed to restore the generator, but recorded so the run can be reproduced from the start.
+	Seed int64 `json:"seed"`
+
+	// The marshalled state of the rand.PCG generator.
+	Generator []byte `json:"generator"`
+}
+
+// The full serialisable state of a single ticker.
+// Restoring this state onto a ticker with the same config resumes the simulation
+// exactly where it was left off.
+type TickerState struct {
+	Name                string      `json:"name"`
+	Type                string      `json:"type"`
+	Value               float64     `json:"value"`
+	LastUpdateTimestamp time.Time   `json:"lastUpdateTimestamp"`
+	RandomState         RandomState `json:"randomState"`
+	Quote               QuoteState  `json:"quote"`
+	Impact              ImpactState `json:"impact"`
+
+	// Any model-internal state, such as variance estimates or lag buffers.
+	// Left empty for models that have no state beyond the value.
+	// Each ticker type decides the layout of its own model state.
+	ModelState json.RawMessage `json:"modelState,omitempty"`
+}
+
+// A snapshot of the state of every ticker at (approximately) one point in time.
+// Each ticker is locked separately, so tickers may be up to one update apart.
+type Snapshot struct {
+	CreatedTimestamp time.Time     `json:"createdTimestamp"`
+	Tickers          []TickerState `json:"tickers"`
+}
+
+// Take a snapshot of the given tickers and write it to the given file.
+// The snapshot is written to a temporary file first, then renamed over the target,
+// so an existing snapshot is never left half-written.
+func SaveSnapshot(snapshotFilePath string, tickers map[string]Ticker) (Snapshot, error) {
+	snapshot := Snapshot{
+		CreatedTimestamp: time.Now(),
+		Tickers:          make([]TickerState, 0, len(tickers)),
+	}
+	for _, t := range tickers {
+		state, err := t.GetState()
+		if err != nil {
+			return Snapshot{}, err
+		}
+		snapshot.Tickers = append(snapshot.Tickers, state)
+	}
+	slices.SortFunc(snapshot.Tickers, func(a, b TickerState) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	tempFile, err := os.CreateTemp(filepath.Dir(snapshotFilePath), filepath.Base(snapshotFilePath)+".*.tmp")
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer os.Remove(tempFile.Name())
+
+	encoder := json.NewEncoder(tempFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		tempFile.Close()
+		return Snapshot{}, err
+	}
+	if err := tempFile.Close(); err != nil {
+		return Snapshot{}, err
+	}
+	if err := os.Rename(tempFile.Name(), snapshotFilePath); err != nil {
+		return Snapshot{}, err
+	}
+
+	slog.Info("saved snapshot",
+		"snapshotFilePath", snapshotFilePath,
+		"createdTimestamp", snapshot.CreatedTimestamp,
+		"numTickers", len(snapshot.Tickers),
+	)
+	return snapshot, nil
+}
+
+// Read a snapshot from the given file.
+// Returns an error wrapping os.ErrNotExist if the file does not exist.
+func LoadSnapshot(snapshotFilePath string) (Snapshot, error) {
+	snapshotFile, err := os.Open(snapshotFilePath)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer snapshotFile.Close()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(snapshotFile).Decode(&snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("error decoding snapshot file %q: %w", snapshotFilePath, err)
+	}
+	return snapshot, nil
+}
+
+// Restore the state of each ticker from the snapshot.
+// Tickers in the snapshot that are not in the given map (e.g. removed from the config) are skipped,
+// and tickers in the map that are not in the snapshot keep their current state.
+//
+// Returns the names of the restored tickers, and the joined errors of any tickers that could not be restored.
+func RestoreSnapshot(snapshot Snapshot, tickers map[string]Ticker) ([]string, error) {
+	restoredTickerNames := make([]string, 0, len(snapshot.Tickers))
+	var errs []error
+	for _, state := range snapshot.Tickers {
+		t, ok := tickers[state.Name]
+		if !ok {
+			slog.Warn("snapshot contains ticker that does not exist, skipping", "tickerName", state.Name)
+			continue
+		}
+		if err := t.SetState(state); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		restoredTickerNames = append(restoredTickerNames, state.Name)
+	}
+
+	slog.Info("restored snapshot",
+		"createdTimestamp", snapshot.CreatedTimestamp,
+		"restoredTickerNames", restoredTickerNames,
+	)
+	return restoredTickerNames, errors.Join(errs...)
+}
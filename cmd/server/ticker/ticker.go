@@ -1,10 +1,12 @@
 package ticker
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"time"
 
+	"github.com/hmcalister/genron/cmd/server/calendar"
 	"github.com/spf13/viper"
 )
 
@@ -18,7 +20,11 @@ var (
 //
 // To create a new Ticker implementation, make a new file (aptly named), define a struct,
 // include a BaseTicker as an embedded component, then define the Initialize and Update methods.
-// Then, in the NewTickerFromConfig method below, add the relevant case to the switch statement.
+// Define a typed config struct (embedding BaseTickerConfig) for the parameters of the ticker,
+// and decode it in Initialize using config.Decode, passing the embedded BaseTickerConfig to InitializeBase.
+// Update moves the value by a single step of the model with UpdateValue. BaseTicker implements every other method.
+// If the ticker has any model-internal state (beyond the value), also override GetState and SetState.
+// Then, register the type with RegisterTickerType in an init function of the same file.
 // Don't forget to update documentation in the README.
 type Ticker interface {
 	// Return the name of the ticker.
@@ -27,7 +33,7 @@ type Ticker interface {
 	// Does not require a lock since name should never change.
 	String() string
 
-	// Get the name, current value, last-updated timestamp, and update period of a ticker.
+	// Get the name, value, last-updated timestamp, and update period of a ticker, as of the end of the last update.
 	// Requires a read lock of the ticker mutex.
 	// Implemented by the BaseTicker struct.
 	//
@@ -36,12 +42,79 @@ type Ticker interface {
 	// Note that no ticker value may be below zero, as a rule of business logic.
 	GetInfo() (string, float64, time.Time, time.Duration)
 
+	// Get the two-sided quote of the ticker around the value, as of the end of the last update.
+	// Requires a read lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	GetQuote() Quote
+
+	// Get the name, value, last-updated timestamp, update period, and quote of a ticker, as of the end of the last update,
+	// under a single lock. Use this, rather than GetInfo and GetQuote, wherever the value and quote are combined,
+	// so that the quote is always the quote of the value.
+	// Requires a read lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	GetSnapshot() TickerInfo
+
+	// Commit the value, timestamp, and quote of the ticker at the end of an update, to be served by GetSnapshot,
+	// GetInfo, and GetQuote, and return them.
+	// Called in the StartTicker method after every update, once the ticker is requoted and has generated its trades.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	CommitUpdate() TickerInfo
+
+	// Requote the ticker around its current value, using the quote config of the ticker.
+	// Called in the StartTicker method after every update.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	UpdateQuote()
+
+	// Generate the simulated trades since the last update, using the trades config of the ticker.
+	// Called in the StartTicker method after every update, once the ticker is requoted.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	GenerateTrades() []Trade
+
+	// Record an execution of a simulated order against the ticker, to move the value at the next update
+	// using the impact config of the ticker.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	RecordExecution(side Side, size int64)
+
+	// Apply the impact of the executions recorded since the last update to the value.
+	// Called in the StartTicker method after every update, before the ticker is requoted.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	ApplyImpact()
+
+	// Get the status of the market of the ticker at the given time, from the calendar of the ticker.
+	// A ticker with no calendar is always open.
+	// Does not require a lock since the calendar should never change.
+	// Implemented by the BaseTicker struct.
+	GetMarketStatus(at time.Time) calendar.Status
+
+	// Gap the value at the open, using the overnight config of the ticker, after the market was closed for the given duration.
+	// Called in the StartTicker method before the first update of each session.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct.
+	ApplyOvernightGap(closed time.Duration)
+
 	// Set the last update timestamp of the ticker.
 	// Called in the StartTicker method.
 	// Requires a read lock of the ticker mutex.
 	// Implemented by the BaseTicker struct.
 	SetLastUpdatedTimestamp(time.Time)
 
+	// Get the full serialisable state of the ticker, including any model-internal state.
+	// Requires a read lock of the ticker mutex.
+	// Implemented by the BaseTicker struct, but tickers with model-internal state must override this
+	// to also fill in the TickerState.ModelState field.
+	GetState() (TickerState, error)
+
+	// Restore the ticker to a state previously returned by GetState.
+	// Requires a write lock of the ticker mutex.
+	// Implemented by the BaseTicker struct, but tickers with model-internal state must override this
+	// to also restore from the TickerState.ModelState field.
+	SetState(TickerState) error
+
 	// Initialize the ticker using the passed viper config map.
 	// Requires a write lock of the ticker mutex.
 	//
@@ -57,25 +130,19 @@ type Ticker interface {
 	Update()
 }
 
-// Factory pattern to initialize the new ticker.
+// Factory pattern to initialize the new ticker, using the type registered under the config `type` field.
 // Returns:
 //   - (Ticker, nil) if all checks pass and the ticker is initialized
 //   - (nil, ErrorUnknownTickerType) if the ticker type in tickerConfig is unknown
 //   - (nil, error) if the ticker is initialized with invalid parameters (e.g. a negative initial value)
 //     The specifics of the error are determined by the initialization method of the selected ticker
 func NewTickerFromConfig(name string, tickerConfig *viper.Viper) (Ticker, error) {
-	tickerType := tickerConfig.GetString("type")
-
-	var t Ticker
-	switch tickerType {
-	case "UniformRandom":
-		t = &UniformRandomTicker{}
-	case "GeometricBrownianMotion":
-		t = &GeometricBrownianMotionTicker{}
-	default:
+	tickerType, ok := GetTickerType(tickerConfig.GetString("type"))
+	if !ok {
 		return nil, ErrorUnknownTickerType
 	}
 
+	t := tickerType.New()
 	if err := t.Initialize(tickerConfig); err != nil {
 		return nil, err
 	}
@@ -124,32 +191,101 @@ func ParseTickers() map[string]Ticker {
 
 // Start the given ticker updating at the given update period. This function blocks, so call inside a goroutine.
 // If the ticker Update method takes too long, a warning is logged with level Warn.
+// Update counts, durations, and lag are recorded as Prometheus metrics (see metrics.go).
+// Every update is published to the given broadcaster.
+// If the ticker has a calendar, the ticker is frozen (neither updated nor published) while its market is closed,
+// and gaps by its overnight model at the first update of each session.
+// The ticker stops updating, and this function returns, once the given context is cancelled.
 //
 // Example:
 // ```
-// updatePeriod := time.Duration(viper.GetInt64("UpdatePeriod")) * time.Nanosecond
+// ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+// defer stop()
 //
+//	updates := ticker.NewUpdateBroadcaster()
 //	var tickerWaitGroup sync.WaitGroup
 //	for _, t := range tickers {
 //		tickerWaitGroup.Go(func() {
-//			ticker.StartTicker(t, updatePeriod)
+//			ticker.StartTicker(ctx, t, updates)
 //		})
 //	}
 //	tickerWaitGroup.Wait()
 //
 // ```
-func StartTicker(t Ticker) {
+func StartTicker(ctx context.Context, t Ticker, updates *UpdateBroadcaster) {
 	tickerName, _, _, updatePeriod := t.GetInfo()
 	// An unfortunate name, time.Ticker is a timing device to count a certain time before updating.
 	// We will refer to this as the timer throughout to avoid confusion with a stock ticker.
 	timer := time.NewTicker(updatePeriod)
+	defer timer.Stop()
+
+	metricUpdatePeriod.WithLabelValues(tickerName).Set(updatePeriod.Seconds())
+	updatesTotal := metricUpdatesTotal.WithLabelValues(tickerName)
+	updateDurationObserver := metricUpdateDuration.WithLabelValues(tickerName)
+	updateLagObserver := metricUpdateLag.WithLabelValues(tickerName)
+	updatesLaggingTotal := metricUpdatesLaggingTotal.WithLabelValues(tickerName)
+	tradesTotal := metricTradesTotal.WithLabelValues(tickerName)
+	volumeTotal := metricVolumeTotal.WithLabelValues(tickerName)
+	marketOpen := metricMarketOpen.WithLabelValues(tickerName)
+	if t.GetMarketStatus(time.Now()).Open {
+		marketOpen.Set(1)
+	}
+
+	for {
+		var updateTimerTimestamp time.Time
+		select {
+		case <-ctx.Done():
+			slog.Debug("ticker stopped", "tickerName", tickerName)
+			return
+		case updateTimerTimestamp = <-timer.C:
+		}
+
+		marketStatus := t.GetMarketStatus(updateTimerTimestamp)
+		if !marketStatus.Open {
+			marketOpen.Set(0)
+			if !waitForOpen(ctx, tickerName, marketStatus) {
+				slog.Debug("ticker stopped", "tickerName", tickerName)
+				return
+			}
+			// Restart the timer from the open, so the first update of the session is one update period after it.
+			timer.Reset(updatePeriod)
+			continue
+		}
+		marketOpen.Set(1)
+
+		// The last update before the open was in an earlier session, so the market has been closed since.
+		// A ticker that has never updated has nothing to gap from.
+		_, _, previousTimestamp, _ := t.GetInfo()
+		if !previousTimestamp.IsZero() && previousTimestamp.Before(marketStatus.SessionOpen) {
+			t.ApplyOvernightGap(marketStatus.SessionOpen.Sub(previousTimestamp))
+		}
 
-	for updateTimerTimestamp := range timer.C {
 		t.SetLastUpdatedTimestamp(updateTimerTimestamp)
 		updateStartTime := time.Now()
 		t.Update()
+		t.ApplyImpact()
+		t.UpdateQuote()
+		trades := t.GenerateTrades()
+		info := t.CommitUpdate()
 		updateDuration := time.Since(updateStartTime)
-		_, newValue, lastUpdatedTimestamp, _ := t.GetInfo()
+		newValue, lastUpdatedTimestamp := info.Value, info.LastUpdatedTimestamp
+		updates.Publish(TickerUpdate{
+			Name:      tickerName,
+			Timestamp: lastUpdatedTimestamp,
+			Value:     newValue,
+			Quote:     info.Quote,
+			Trades:    trades,
+		})
+
+		updatesTotal.Inc()
+		if len(trades) > 0 {
+			tradesTotal.Add(float64(len(trades)))
+			for _, trade := range trades {
+				volumeTotal.Add(float64(trade.Size))
+			}
+		}
+		updateDurationObserver.Observe(updateDuration.Seconds())
+		updateLagObserver.Observe(time.Since(updateTimerTimestamp).Seconds())
 
 		slog.Debug("ticker updated",
 			slog.Group("ticker",
@@ -164,6 +300,7 @@ func StartTicker(t Ticker) {
 		)
 
 		if updateDuration > updatePeriod {
+			updatesLaggingTotal.Inc()
 			slog.Warn("timer update is lagging behind update period",
 				slog.Group("ticker",
 					"name", tickerName,
@@ -178,3 +315,29 @@ func StartTicker(t Ticker) {
 		}
 	}
 }
+
+// Wait until the next open of a closed market, or until the given context is cancelled.
+// Returns false if the context was cancelled first.
+// A calendar with no next open (e.g. every remaining day is a holiday) waits for the context alone.
+func waitForOpen(ctx context.Context, tickerName string, marketStatus calendar.Status) bool {
+	slog.Info("market closed, ticker frozen until the next open",
+		"tickerName", tickerName,
+		"calendar", marketStatus.Calendar,
+		"nextOpen", marketStatus.NextOpen,
+	)
+	if marketStatus.NextOpen.IsZero() {
+		slog.Warn("calendar has no next open, ticker frozen indefinitely", "tickerName", tickerName, "calendar", marketStatus.Calendar)
+		<-ctx.Done()
+		return false
+	}
+
+	openTimer := time.NewTimer(time.Until(marketStatus.NextOpen))
+	defer openTimer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-openTimer.C:
+		slog.Info("market open, ticker resumed", "tickerName", tickerName, "calendar", marketStatus.Calendar)
+		return true
+	}
+}
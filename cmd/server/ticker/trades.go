@@ -0,0 +1,119 @@
+package ticker
+
+import (
+	"math"
+	"time"
+)
+
+// The size distributions of trades.
+const (
+	// Every trade has the mean size.
+	SizeDistributionFixed = "fixed"
+
+	// Sizes are exponentially distributed, so most trades are small.
+	SizeDistributionExponential = "exponential"
+
+	// Sizes are log-normally distributed, with occasional very large trades, as in real markets.
+	SizeDistributionLogNormal = "lognormal"
+)
+
+// Above this mean number of trades per update, the Poisson distribution is approximated by a normal distribution.
+const poissonNormalApproximationThreshold = 30
+
+// The config of the simulated trades of a ticker, shared by all ticker types under the `trades` key.
+// The defaults generate no trades.
+type TradesConfig struct {
+	Rate             float64 `mapstructure:"rate" default:"0" validate:"min=0" description:"The mean number of trades per second. Trades arrive as a Poisson process, and are generated at each update. Zero disables trades."`
+	BuyProbability   float64 `mapstructure:"buyprobability" default:"0.5" validate:"min=0,max=1" description:"The probability that the buyer is the aggressor of each trade, trading at the ask. Otherwise the seller is the aggressor, trading at the bid."`
+	SizeDistribution string  `mapstructure:"sizedistribution" default:"lognormal" validate:"oneof=fixed exponential lognormal" description:"The distribution of trade sizes. One of fixed, exponential, or lognormal."`
+	MeanSize         float64 `mapstructure:"meansize" default:"100" validate:"gt=0" description:"The mean size of each trade."`
+	SizeSigma        float64 `mapstructure:"sizesigma" default:"1" validate:"min=0" description:"The standard deviation of the log of the size of each trade, for the lognormal distribution."`
+	LotSize          int64   `mapstructure:"lotsize" default:"1" validate:"min=1" description:"The size of a round lot. Trade sizes are rounded to a whole number of lots, of at least one lot."`
+}
+
+// The side of the aggressor of a trade, the party that crossed the spread.
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+// A single simulated trade of a ticker.
+type Trade struct {
+	Timestamp time.Time `json:"timestamp"`
+	Price     float64   `json:"price"`
+	Size      int64     `json:"size"`
+	Aggressor Side      `json:"aggressor"`
+}
+
+// Generate the trades since the last update, at the current quote, timestamped with the last update timestamp.
+func (t *BaseTicker) GenerateTrades() []Trade {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cfg := t.tradesConfig
+	if cfg.Rate == 0 {
+		return nil
+	}
+
+	numTrades := t.poisson(cfg.Rate * t.updatePeriod.Seconds())
+	if numTrades == 0 {
+		return nil
+	}
+	trades := make([]Trade, 0, numTrades)
+	for range numTrades {
+		trade := Trade{
+			Timestamp: t.lastUpdateTimestamp,
+			Size:      t.tradeSize(),
+		}
+		if t.randGen.Float64() < cfg.BuyProbability {
+			trade.Price = t.quote.Ask
+			trade.Aggressor = SideBuy
+		} else {
+			trade.Price = t.quote.Bid
+			trade.Aggressor = SideSell
+		}
+		trades = append(trades, trade)
+	}
+	return trades
+}
+
+// Draw a Poisson distributed count with the given mean.
+//
+// Does not lock the mutex, since this method is called from methods that already lock.
+func (t *BaseTicker) poisson(mean float64) int {
+	if mean > poissonNormalApproximationThreshold {
+		return max(0, int(math.Round(mean+math.Sqrt(mean)*t.randGen.NormFloat64())))
+	}
+
+	// Knuth's algorithm, multiplying uniform draws until the product falls below exp(-mean).
+	limit := math.Exp(-mean)
+	count := 0
+	product := t.randGen.Float64()
+	for product > limit {
+		count++
+		product *= t.randGen.Float64()
+	}
+	return count
+}
+
+// Draw the size of a trade from the size distribution, rounded to a whole number of lots.
+//
+// Does not lock the mutex, since this method is called from methods that already lock.
+func (t *BaseTicker) tradeSize() int64 {
+	cfg := t.tradesConfig
+
+	size := cfg.MeanSize
+	switch cfg.SizeDistribution {
+	case SizeDistributionExponential:
+		size = cfg.MeanSize * t.randGen.ExpFloat64()
+	case SizeDistributionLogNormal:
+		// Choose mu so that the mean of the distribution is the mean size.
+		mu := math.Log(cfg.MeanSize) - cfg.SizeSigma*cfg.SizeSigma/2
+		size = math.Exp(mu + cfg.SizeSigma*t.randGen.NormFloat64())
+	}
+
+	lots := max(1, int64(math.Round(size/float64(cfg.LotSize))))
+	return lots * cfg.LotSize
+}
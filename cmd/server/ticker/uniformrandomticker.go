@@ -1,38 +1,50 @@
 package ticker
 
 import (
-	"errors"
+	"math/rand/v2"
 
+	"github.com/hmcalister/genron/cmd/server/config"
 	"github.com/spf13/viper"
 )
 
+func init() {
+	RegisterTickerType(TickerType{
+		Name:        "UniformRandom",
+		Description: "Update the ticker value with a uniformly chosen random number at every step. This is a very simple, but very unrealistic model.",
+		Config:      UniformRandomConfig{},
+		New:         func() Ticker { return &UniformRandomTicker{} },
+	})
+}
+
+type UniformRandomConfig struct {
+	BaseTickerConfig `mapstructure:",squash"`
+
+	RandomRange float64 `mapstructure:"randomrange" validate:"required,min=0" description:"The upper and lower bound on the random number. Must be non-negative."`
+}
+
 type UniformRandomTicker struct {
 	BaseTicker
 	randomRange float64
 }
 
 func (t *UniformRandomTicker) Initialize(tickerConfig *viper.Viper) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
-	if err := t.initializeBase(tickerConfig); err != nil {
+	var cfg UniformRandomConfig
+	if err := config.Decode(tickerConfig, &cfg); err != nil {
 		return err
 	}
 
-	t.randomRange = tickerConfig.GetFloat64("randomrange")
-	if t.randomRange < 0.0 {
-		return errors.New("error initializing uniform random ticker, random range is negative")
+	if err := t.InitializeBase(cfg.BaseTickerConfig); err != nil {
+		return err
 	}
+	t.randomRange = cfg.RandomRange
 
 	return nil
 }
 
 func (t *UniformRandomTicker) Update() {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
-	t.value += -t.randomRange + 2*t.randomRange*t.randGen.Float64()
-	if t.value < 0 {
-		t.value = 0
-	}
+	t.UpdateValue(func(value float64, volatilityMultiplier float64, randGen *rand.Rand) float64 {
+		// The range is scaled by the seasonality profile at the time of the update.
+		randomRange := t.randomRange * volatilityMultiplier
+		return value - randomRange + 2*randomRange*randGen.Float64()
+	})
 }
@@ -0,0 +1,114 @@
+package ticker
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A single update of a ticker value, as published by StartTicker.
+type TickerUpdate struct {
+	Name      string
+	Timestamp time.Time
+	Value     float64
+	Quote     Quote
+
+	// The trades since the previous update of the ticker, if any.
+	Trades []Trade
+}
+
+// Fans out every ticker update to any number of subscribers (e.g. persistence sinks, or streaming RPCs).
+//
+// Publishing never blocks, so a slow subscriber can never slow down the tickers.
+// Instead, updates are dropped for any subscriber whose buffer is full, and counted in Subscription.Dropped.
+type UpdateBroadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+func NewUpdateBroadcaster() *UpdateBroadcaster {
+	return &UpdateBroadcaster{
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// A subscription to an UpdateBroadcaster.
+// Updates are received from the channel C, which is closed when the subscription is cancelled
+// or the broadcaster is closed.
+type Subscription struct {
+	C <-chan TickerUpdate
+
+	updates     chan TickerUpdate
+	broadcaster *UpdateBroadcaster
+	dropped     atomic.Uint64
+}
+
+// Subscribe to all future ticker updates, buffering at most bufferSize updates.
+// If the broadcaster is already closed, the returned subscription is already closed.
+func (b *UpdateBroadcaster) Subscribe(bufferSize int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	updates := make(chan TickerUpdate, bufferSize)
+	subscription := &Subscription{
+		C:           updates,
+		updates:     updates,
+		broadcaster: b,
+	}
+	if b.closed {
+		close(updates)
+		return subscription
+	}
+	b.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Send an update to every subscriber, dropping the update for any subscriber whose buffer is full.
+func (b *UpdateBroadcaster) Publish(update TickerUpdate) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscription := range b.subscribers {
+		select {
+		case subscription.updates <- update:
+		default:
+			subscription.dropped.Add(1)
+		}
+	}
+}
+
+// Close the broadcaster, closing the channel of every subscription.
+// Call this only once all tickers have stopped, so subscribers receive every published update
+// before their channel is closed.
+func (b *UpdateBroadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for subscription := range b.subscribers {
+		close(subscription.updates)
+	}
+	clear(b.subscribers)
+}
+
+// Cancel the subscription, closing the channel C. Updates already buffered may still be received.
+// Safe to call more than once, and after the broadcaster is closed.
+func (s *Subscription) Unsubscribe() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+
+	if _, ok := s.broadcaster.subscribers[s]; !ok {
+		return
+	}
+	delete(s.broadcaster.subscribers, s)
+	close(s.updates)
+}
+
+// Get the number of updates dropped because the subscription buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
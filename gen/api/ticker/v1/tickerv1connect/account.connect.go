@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/ticker/v1/account.proto
+
+package tickerv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AccountServiceName is the fully-qualified name of the AccountService service.
+	AccountServiceName = "api.ticker.v1.AccountService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AccountServiceGetPortfolioProcedure is the fully-qualified name of the AccountService's
+	// GetPortfolio RPC.
+	AccountServiceGetPortfolioProcedure = "/api.ticker.v1.AccountService/GetPortfolio"
+	// AccountServiceStreamPortfolioProcedure is the fully-qualified name of the AccountService's
+	// StreamPortfolio RPC.
+	AccountServiceStreamPortfolioProcedure = "/api.ticker.v1.AccountService/StreamPortfolio"
+)
+
+// AccountServiceClient is a client for the api.ticker.v1.AccountService service.
+type AccountServiceClient interface {
+	GetPortfolio(context.Context, *connect.Request[v1.GetPortfolioRequest]) (*connect.Response[v1.GetPortfolioResponse], error)
+	StreamPortfolio(context.Context, *connect.Request[v1.StreamPortfolioRequest]) (*connect.ServerStreamForClient[v1.StreamPortfolioResponse], error)
+}
+
+// NewAccountServiceClient constructs a client for the api.ticker.v1.AccountService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAccountServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AccountServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	accountServiceMethods := v1.File_api_ticker_v1_account_proto.Services().ByName("AccountService").Methods()
+	return &accountServiceClient{
+		getPortfolio: connect.NewClient[v1.GetPortfolioRequest, v1.GetPortfolioResponse](
+			httpClient,
+			baseURL+AccountServiceGetPortfolioProcedure,
+			connect.WithSchema(accountServiceMethods.ByName("GetPortfolio")),
+			connect.WithClientOptions(opts...),
+		),
+		streamPortfolio: connect.NewClient[v1.StreamPortfolioRequest, v1.StreamPortfolioResponse](
+			httpClient,
+			baseURL+AccountServiceStreamPortfolioProcedure,
+			connect.WithSchema(accountServiceMethods.ByName("StreamPortfolio")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// accountServiceClient implements AccountServiceClient.
+type accountServiceClient struct {
+	getPortfolio    *connect.Client[v1.GetPortfolioRequest, v1.GetPortfolioResponse]
+	streamPortfolio *connect.Client[v1.StreamPortfolioRequest, v1.StreamPortfolioResponse]
+}
+
+// GetPortfolio calls api.ticker.v1.AccountService.GetPortfolio.
+func (c *accountServiceClient) GetPortfolio(ctx context.Context, req *connect.Request[v1.GetPortfolioRequest]) (*connect.Response[v1.GetPortfolioResponse], error) {
+	return c.getPortfolio.CallUnary(ctx, req)
+}
+
+// StreamPortfolio calls api.ticker.v1.AccountService.StreamPortfolio.
+func (c *accountServiceClient) StreamPortfolio(ctx context.Context, req *connect.Request[v1.StreamPortfolioRequest]) (*connect.ServerStreamForClient[v1.StreamPortfolioResponse], error) {
+	return c.streamPortfolio.CallServerStream(ctx, req)
+}
+
+// AccountServiceHandler is an implementation of the api.ticker.v1.AccountService service.
+type AccountServiceHandler interface {
+	GetPortfolio(context.Context, *connect.Request[v1.GetPortfolioRequest]) (*connect.Response[v1.GetPortfolioResponse], error)
+	StreamPortfolio(context.Context, *connect.Request[v1.StreamPortfolioRequest], *connect.ServerStream[v1.StreamPortfolioResponse]) error
+}
+
+// NewAccountServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAccountServiceHandler(svc AccountServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	accountServiceMethods := v1.File_api_ticker_v1_account_proto.Services().ByName("AccountService").Methods()
+	accountServiceGetPortfolioHandler := connect.NewUnaryHandler(
+		AccountServiceGetPortfolioProcedure,
+		svc.GetPortfolio,
+		connect.WithSchema(accountServiceMethods.ByName("GetPortfolio")),
+		connect.WithHandlerOptions(opts...),
+	)
+	accountServiceStreamPortfolioHandler := connect.NewServerStreamHandler(
+		AccountServiceStreamPortfolioProcedure,
+		svc.StreamPortfolio,
+		connect.WithSchema(accountServiceMethods.ByName("StreamPortfolio")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.ticker.v1.AccountService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AccountServiceGetPortfolioProcedure:
+			accountServiceGetPortfolioHandler.ServeHTTP(w, r)
+		case AccountServiceStreamPortfolioProcedure:
+			accountServiceStreamPortfolioHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAccountServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAccountServiceHandler struct{}
+
+func (UnimplementedAccountServiceHandler) GetPortfolio(context.Context, *connect.Request[v1.GetPortfolioRequest]) (*connect.Response[v1.GetPortfolioResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.AccountService.GetPortfolio is not implemented"))
+}
+
+func (UnimplementedAccountServiceHandler) StreamPortfolio(context.Context, *connect.Request[v1.StreamPortfolioRequest], *connect.ServerStream[v1.StreamPortfolioResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.AccountService.StreamPortfolio is not implemented"))
+}
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/ticker/v1/order.proto
+
+package tickerv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// OrderServiceName is the fully-qualified name of the OrderService service.
+	OrderServiceName = "api.ticker.v1.OrderService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OrderServiceSubmitOrderProcedure is the fully-qualified name of the OrderService's SubmitOrder
+	// RPC.
+	OrderServiceSubmitOrderProcedure = "/api.ticker.v1.OrderService/SubmitOrder"
+	// OrderServiceCancelOrderProcedure is the fully-qualified name of the OrderService's CancelOrder
+	// RPC.
+	OrderServiceCancelOrderProcedure = "/api.ticker.v1.OrderService/CancelOrder"
+	// OrderServiceStreamExecutionsProcedure is the fully-qualified name of the OrderService's
+	// StreamExecutions RPC.
+	OrderServiceStreamExecutionsProcedure = "/api.ticker.v1.OrderService/StreamExecutions"
+)
+
+// OrderServiceClient is a client for the api.ticker.v1.OrderService service.
+type OrderServiceClient interface {
+	SubmitOrder(context.Context, *connect.Request[v1.SubmitOrderRequest]) (*connect.Response[v1.SubmitOrderResponse], error)
+	CancelOrder(context.Context, *connect.Request[v1.CancelOrderRequest]) (*connect.Response[v1.CancelOrderResponse], error)
+	StreamExecutions(context.Context, *connect.Request[v1.StreamExecutionsRequest]) (*connect.ServerStreamForClient[v1.StreamExecutionsResponse], error)
+}
+
+// NewOrderServiceClient constructs a client for the api.ticker.v1.OrderService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOrderServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) OrderServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	orderServiceMethods := v1.File_api_ticker_v1_order_proto.Services().ByName("OrderService").Methods()
+	return &orderServiceClient{
+		submitOrder: connect.NewClient[v1.SubmitOrderRequest, v1.SubmitOrderResponse](
+			httpClient,
+			baseURL+OrderServiceSubmitOrderProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("SubmitOrder")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelOrder: connect.NewClient[v1.CancelOrderRequest, v1.CancelOrderResponse](
+			httpClient,
+			baseURL+OrderServiceCancelOrderProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("CancelOrder")),
+			connect.WithClientOptions(opts...),
+		),
+		streamExecutions: connect.NewClient[v1.StreamExecutionsRequest, v1.StreamExecutionsResponse](
+			httpClient,
+			baseURL+OrderServiceStreamExecutionsProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("StreamExecutions")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// orderServiceClient implements OrderServiceClient.
+type orderServiceClient struct {
+	submitOrder      *connect.Client[v1.SubmitOrderRequest, v1.SubmitOrderResponse]
+	cancelOrder      *connect.Client[v1.CancelOrderRequest, v1.CancelOrderResponse]
+	streamExecutions *connect.Client[v1.StreamExecutionsRequest, v1.StreamExecutionsResponse]
+}
+
+// SubmitOrder calls api.ticker.v1.OrderService.SubmitOrder.
+func (c *orderServiceClient) SubmitOrder(ctx context.Context, req *connect.Request[v1.SubmitOrderRequest]) (*connect.Response[v1.SubmitOrderResponse], error) {
+	return c.submitOrder.CallUnary(ctx, req)
+}
+
+// CancelOrder calls api.ticker.v1.OrderService.CancelOrder.
+func (c *orderServiceClient) CancelOrder(ctx context.Context, req *connect.Request[v1.CancelOrderRequest]) (*connect.Response[v1.CancelOrderResponse], error) {
+	return c.cancelOrder.CallUnary(ctx, req)
+}
+
+// StreamExecutions calls api.ticker.v1.OrderService.StreamExecutions.
+func (c *orderServiceClient) StreamExecutions(ctx context.Context, req *connect.Request[v1.StreamExecutionsRequest]) (*connect.ServerStreamForClient[v1.StreamExecutionsResponse], error) {
+	return c.streamExecutions.CallServerStream(ctx, req)
+}
+
+// OrderServiceHandler is an implementation of the api.ticker.v1.OrderService service.
+type OrderServiceHandler interface {
+	SubmitOrder(context.Context, *connect.Request[v1.SubmitOrderRequest]) (*connect.Response[v1.SubmitOrderResponse], error)
+	CancelOrder(context.Context, *connect.Request[v1.CancelOrderRequest]) (*connect.Response[v1.CancelOrderResponse], error)
+	StreamExecutions(context.Context, *connect.Request[v1.StreamExecutionsRequest], *connect.ServerStream[v1.StreamExecutionsResponse]) error
+}
+
+// NewOrderServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOrderServiceHandler(svc OrderServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	orderServiceMethods := v1.File_api_ticker_v1_order_proto.Services().ByName("OrderService").Methods()
+	orderServiceSubmitOrderHandler := connect.NewUnaryHandler(
+		OrderServiceSubmitOrderProcedure,
+		svc.SubmitOrder,
+		connect.WithSchema(orderServiceMethods.ByName("SubmitOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	orderServiceCancelOrderHandler := connect.NewUnaryHandler(
+		OrderServiceCancelOrderProcedure,
+		svc.CancelOrder,
+		connect.WithSchema(orderServiceMethods.ByName("CancelOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	orderServiceStreamExecutionsHandler := connect.NewServerStreamHandler(
+		OrderServiceStreamExecutionsProcedure,
+		svc.StreamExecutions,
+		connect.WithSchema(orderServiceMethods.ByName("StreamExecutions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.ticker.v1.OrderService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OrderServiceSubmitOrderProcedure:
+			orderServiceSubmitOrderHandler.ServeHTTP(w, r)
+		case OrderServiceCancelOrderProcedure:
+			orderServiceCancelOrderHandler.ServeHTTP(w, r)
+		case OrderServiceStreamExecutionsProcedure:
+			orderServiceStreamExecutionsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOrderServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOrderServiceHandler struct{}
+
+func (UnimplementedOrderServiceHandler) SubmitOrder(context.Context, *connect.Request[v1.SubmitOrderRequest]) (*connect.Response[v1.SubmitOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.OrderService.SubmitOrder is not implemented"))
+}
+
+func (UnimplementedOrderServiceHandler) CancelOrder(context.Context, *connect.Request[v1.CancelOrderRequest]) (*connect.Response[v1.CancelOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.OrderService.CancelOrder is not implemented"))
+}
+
+func (UnimplementedOrderServiceHandler) StreamExecutions(context.Context, *connect.Request[v1.StreamExecutionsRequest], *connect.ServerStream[v1.StreamExecutionsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.OrderService.StreamExecutions is not implemented"))
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/ticker/v1/orderbook.proto
+
+package tickerv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// OrderBookServiceName is the fully-qualified name of the OrderBookService service.
+	OrderBookServiceName = "api.ticker.v1.OrderBookService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OrderBookServiceGetOrderBookProcedure is the fully-qualified name of the OrderBookService's
+	// GetOrderBook RPC.
+	OrderBookServiceGetOrderBookProcedure = "/api.ticker.v1.OrderBookService/GetOrderBook"
+	// OrderBookServiceStreamOrderBookProcedure is the fully-qualified name of the OrderBookService's
+	// StreamOrderBook RPC.
+	OrderBookServiceStreamOrderBookProcedure = "/api.ticker.v1.OrderBookService/StreamOrderBook"
+)
+
+// OrderBookServiceClient is a client for the api.ticker.v1.OrderBookService service.
+type OrderBookServiceClient interface {
+	GetOrderBook(context.Context, *connect.Request[v1.GetOrderBookRequest]) (*connect.Response[v1.GetOrderBookResponse], error)
+	StreamOrderBook(context.Context, *connect.Request[v1.StreamOrderBookRequest]) (*connect.ServerStreamForClient[v1.StreamOrderBookResponse], error)
+}
+
+// NewOrderBookServiceClient constructs a client for the api.ticker.v1.OrderBookService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOrderBookServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) OrderBookServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	orderBookServiceMethods := v1.File_api_ticker_v1_orderbook_proto.Services().ByName("OrderBookService").Methods()
+	return &orderBookServiceClient{
+		getOrderBook: connect.NewClient[v1.GetOrderBookRequest, v1.GetOrderBookResponse](
+			httpClient,
+			baseURL+OrderBookServiceGetOrderBookProcedure,
+			connect.WithSchema(orderBookServiceMethods.ByName("GetOrderBook")),
+			connect.WithClientOptions(opts...),
+		),
+		streamOrderBook: connect.NewClient[v1.StreamOrderBookRequest, v1.StreamOrderBookResponse](
+			httpClient,
+			baseURL+OrderBookServiceStreamOrderBookProcedure,
+			connect.WithSchema(orderBookServiceMethods.ByName("StreamOrderBook")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// orderBookServiceClient implements OrderBookServiceClient.
+type orderBookServiceClient struct {
+	getOrderBook    *connect.Client[v1.GetOrderBookRequest, v1.GetOrderBookResponse]
+	streamOrderBook *connect.Client[v1.StreamOrderBookRequest, v1.StreamOrderBookResponse]
+}
+
+// GetOrderBook calls api.ticker.v1.OrderBookService.GetOrderBook.
+func (c *orderBookServiceClient) GetOrderBook(ctx context.Context, req *connect.Request[v1.GetOrderBookRequest]) (*connect.Response[v1.GetOrderBookResponse], error) {
+	return c.getOrderBook.CallUnary(ctx, req)
+}
+
+// StreamOrderBook calls api.ticker.v1.OrderBookService.StreamOrderBook.
+func (c *orderBookServiceClient) StreamOrderBook(ctx context.Context, req *connect.Request[v1.StreamOrderBookRequest]) (*connect.ServerStreamForClient[v1.StreamOrderBookResponse], error) {
+	return c.streamOrderBook.CallServerStream(ctx, req)
+}
+
+// OrderBookServiceHandler is an implementation of the api.ticker.v1.OrderBookService service.
+type OrderBookServiceHandler interface {
+	GetOrderBook(context.Context, *connect.Request[v1.GetOrderBookRequest]) (*connect.Response[v1.GetOrderBookResponse], error)
+	StreamOrderBook(context.Context, *connect.Request[v1.StreamOrderBookRequest], *connect.ServerStream[v1.StreamOrderBookResponse]) error
+}
+
+// NewOrderBookServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOrderBookServiceHandler(svc OrderBookServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	orderBookServiceMethods := v1.File_api_ticker_v1_orderbook_proto.Services().ByName("OrderBookService").Methods()
+	orderBookServiceGetOrderBookHandler := connect.NewUnaryHandler(
+		OrderBookServiceGetOrderBookProcedure,
+		svc.GetOrderBook,
+		connect.WithSchema(orderBookServiceMethods.ByName("GetOrderBook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	orderBookServiceStreamOrderBookHandler := connect.NewServerStreamHandler(
+		OrderBookServiceStreamOrderBookProcedure,
+		svc.StreamOrderBook,
+		connect.WithSchema(orderBookServiceMethods.ByName("StreamOrderBook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.ticker.v1.OrderBookService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OrderBookServiceGetOrderBookProcedure:
+			orderBookServiceGetOrderBookHandler.ServeHTTP(w, r)
+		case OrderBookServiceStreamOrderBookProcedure:
+			orderBookServiceStreamOrderBookHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOrderBookServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOrderBookServiceHandler struct{}
+
+func (UnimplementedOrderBookServiceHandler) GetOrderBook(context.Context, *connect.Request[v1.GetOrderBookRequest]) (*connect.Response[v1.GetOrderBookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.OrderBookService.GetOrderBook is not implemented"))
+}
+
+func (UnimplementedOrderBookServiceHandler) StreamOrderBook(context.Context, *connect.Request[v1.StreamOrderBookRequest], *connect.ServerStream[v1.StreamOrderBookResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.OrderBookService.StreamOrderBook is not implemented"))
+}
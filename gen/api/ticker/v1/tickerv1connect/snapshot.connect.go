@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/ticker/v1/snapshot.proto
+
+package tickerv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/hmcalister/genron/gen/api/ticker/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SnapshotServiceName is the fully-qualified name of the SnapshotService service.
+	SnapshotServiceName = "api.ticker.v1.SnapshotService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SnapshotServiceSaveSnapshotProcedure is the fully-qualified name of the SnapshotService's
+	// SaveSnapshot RPC.
+	SnapshotServiceSaveSnapshotProcedure = "/api.ticker.v1.SnapshotService/SaveSnapshot"
+	// SnapshotServiceRestoreSnapshotProcedure is the fully-qualified name of the SnapshotService's
+	// RestoreSnapshot RPC.
+	SnapshotServiceRestoreSnapshotProcedure = "/api.ticker.v1.SnapshotService/RestoreSnapshot"
+)
+
+// SnapshotServiceClient is a client for the api.ticker.v1.SnapshotService service.
+type SnapshotServiceClient interface {
+	SaveSnapshot(context.Context, *connect.Request[v1.SaveSnapshotRequest]) (*connect.Response[v1.SaveSnapshotResponse], error)
+	RestoreSnapshot(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error)
+}
+
+// NewSnapshotServiceClient constructs a client for the api.ticker.v1.SnapshotService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSnapshotServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SnapshotServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	snapshotServiceMethods := v1.File_api_ticker_v1_snapshot_proto.Services().ByName("SnapshotService").Methods()
+	return &snapshotServiceClient{
+		saveSnapshot: connect.NewClient[v1.SaveSnapshotRequest, v1.SaveSnapshotResponse](
+			httpClient,
+			baseURL+SnapshotServiceSaveSnapshotProcedure,
+			connect.WithSchema(snapshotServiceMethods.ByName("SaveSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreSnapshot: connect.NewClient[v1.RestoreSnapshotRequest, v1.RestoreSnapshotResponse](
+			httpClient,
+			baseURL+SnapshotServiceRestoreSnapshotProcedure,
+			connect.WithSchema(snapshotServiceMethods.ByName("RestoreSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// snapshotServiceClient implements SnapshotServiceClient.
+type snapshotServiceClient struct {
+	saveSnapshot    *connect.Client[v1.SaveSnapshotRequest, v1.SaveSnapshotResponse]
+	restoreSnapshot *connect.Client[v1.RestoreSnapshotRequest, v1.RestoreSnapshotResponse]
+}
+
+// SaveSnapshot calls api.ticker.v1.SnapshotService.SaveSnapshot.
+func (c *snapshotServiceClient) SaveSnapshot(ctx context.Context, req *connect.Request[v1.SaveSnapshotRequest]) (*connect.Response[v1.SaveSnapshotResponse], error) {
+	return c.saveSnapshot.CallUnary(ctx, req)
+}
+
+// RestoreSnapshot calls api.ticker.v1.SnapshotService.RestoreSnapshot.
+func (c *snapshotServiceClient) RestoreSnapshot(ctx context.Context, req *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error) {
+	return c.restoreSnapshot.CallUnary(ctx, req)
+}
+
+// SnapshotServiceHandler is an implementation of the api.ticker.v1.SnapshotService service.
+type SnapshotServiceHandler interface {
+	SaveSnapshot(context.Context, *connect.Request[v1.SaveSnapshotRequest]) (*connect.Response[v1.SaveSnapshotResponse], error)
+	RestoreSnapshot(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error)
+}
+
+// NewSnapshotServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSnapshotServiceHandler(svc SnapshotServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	snapshotServiceMethods := v1.File_api_ticker_v1_snapshot_proto.Services().ByName("SnapshotService").Methods()
+	snapshotServiceSaveSnapshotHandler := connect.NewUnaryHandler(
+		SnapshotServiceSaveSnapshotProcedure,
+		svc.SaveSnapshot,
+		connect.WithSchema(snapshotServiceMethods.ByName("SaveSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	snapshotServiceRestoreSnapshotHandler := connect.NewUnaryHandler(
+		SnapshotServiceRestoreSnapshotProcedure,
+		svc.RestoreSnapshot,
+		connect.WithSchema(snapshotServiceMethods.ByName("RestoreSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.ticker.v1.SnapshotService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SnapshotServiceSaveSnapshotProcedure:
+			snapshotServiceSaveSnapshotHandler.ServeHTTP(w, r)
+		case SnapshotServiceRestoreSnapshotProcedure:
+			snapshotServiceRestoreSnapshotHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSnapshotServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSnapshotServiceHandler struct{}
+
+func (UnimplementedSnapshotServiceHandler) SaveSnapshot(context.Context, *connect.Request[v1.SaveSnapshotRequest]) (*connect.Response[v1.SaveSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.SnapshotService.SaveSnapshot is not implemented"))
+}
+
+func (UnimplementedSnapshotServiceHandler) RestoreSnapshot(context.Context, *connect.Request[v1.RestoreSnapshotRequest]) (*connect.Response[v1.RestoreSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.SnapshotService.RestoreSnapshot is not implemented"))
+}
@@ -40,12 +40,20 @@ const (
 	// TickerInfoServiceGetTickerValueProcedure is the fully-qualified name of the TickerInfoService's
 	// GetTickerValue RPC.
 	TickerInfoServiceGetTickerValueProcedure = "/api.ticker.v1.TickerInfoService/GetTickerValue"
+	// TickerInfoServiceListTickerTypesProcedure is the fully-qualified name of the TickerInfoService's
+	// ListTickerTypes RPC.
+	TickerInfoServiceListTickerTypesProcedure = "/api.ticker.v1.TickerInfoService/ListTickerTypes"
+	// TickerInfoServiceStreamTradesProcedure is the fully-qualified name of the TickerInfoService's
+	// StreamTrades RPC.
+	TickerInfoServiceStreamTradesProcedure = "/api.ticker.v1.TickerInfoService/StreamTrades"
 )
 
 // TickerInfoServiceClient is a client for the api.ticker.v1.TickerInfoService service.
 type TickerInfoServiceClient interface {
 	GetAllTickerNames(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetAllTickerNamesResponse], error)
 	GetTickerValue(context.Context, *connect.Request[v1.GetTickerValueRequest]) (*connect.Response[v1.GetTickerValueResponse], error)
+	ListTickerTypes(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTickerTypesResponse], error)
+	StreamTrades(context.Context, *connect.Request[v1.StreamTradesRequest]) (*connect.ServerStreamForClient[v1.StreamTradesResponse], error)
 }
 
 // NewTickerInfoServiceClient constructs a client for the api.ticker.v1.TickerInfoService service.
@@ -71,6 +79,18 @@ func NewTickerInfoServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(tickerInfoServiceMethods.ByName("GetTickerValue")),
 			connect.WithClientOptions(opts...),
 		),
+		listTickerTypes: connect.NewClient[emptypb.Empty, v1.ListTickerTypesResponse](
+			httpClient,
+			baseURL+TickerInfoServiceListTickerTypesProcedure,
+			connect.WithSchema(tickerInfoServiceMethods.ByName("ListTickerTypes")),
+			connect.WithClientOptions(opts...),
+		),
+		streamTrades: connect.NewClient[v1.StreamTradesRequest, v1.StreamTradesResponse](
+			httpClient,
+			baseURL+TickerInfoServiceStreamTradesProcedure,
+			connect.WithSchema(tickerInfoServiceMethods.ByName("StreamTrades")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -78,6 +98,8 @@ func NewTickerInfoServiceClient(httpClient connect.HTTPClient, baseURL string, o
 type tickerInfoServiceClient struct {
 	getAllTickerNames *connect.Client[emptypb.Empty, v1.GetAllTickerNamesResponse]
 	getTickerValue    *connect.Client[v1.GetTickerValueRequest, v1.GetTickerValueResponse]
+	listTickerTypes   *connect.Client[emptypb.Empty, v1.ListTickerTypesResponse]
+	streamTrades      *connect.Client[v1.StreamTradesRequest, v1.StreamTradesResponse]
 }
 
 // GetAllTickerNames calls api.ticker.v1.TickerInfoService.GetAllTickerNames.
@@ -90,10 +112,22 @@ func (c *tickerInfoServiceClient) GetTickerValue(ctx context.Context, req *conne
 	return c.getTickerValue.CallUnary(ctx, req)
 }
 
+// ListTickerTypes calls api.ticker.v1.TickerInfoService.ListTickerTypes.
+func (c *tickerInfoServiceClient) ListTickerTypes(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTickerTypesResponse], error) {
+	return c.listTickerTypes.CallUnary(ctx, req)
+}
+
+// StreamTrades calls api.ticker.v1.TickerInfoService.StreamTrades.
+func (c *tickerInfoServiceClient) StreamTrades(ctx context.Context, req *connect.Request[v1.StreamTradesRequest]) (*connect.ServerStreamForClient[v1.StreamTradesResponse], error) {
+	return c.streamTrades.CallServerStream(ctx, req)
+}
+
 // TickerInfoServiceHandler is an implementation of the api.ticker.v1.TickerInfoService service.
 type TickerInfoServiceHandler interface {
 	GetAllTickerNames(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetAllTickerNamesResponse], error)
 	GetTickerValue(context.Context, *connect.Request[v1.GetTickerValueRequest]) (*connect.Response[v1.GetTickerValueResponse], error)
+	ListTickerTypes(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTickerTypesResponse], error)
+	StreamTrades(context.Context, *connect.Request[v1.StreamTradesRequest], *connect.ServerStream[v1.StreamTradesResponse]) error
 }
 
 // NewTickerInfoServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -115,12 +149,28 @@ func NewTickerInfoServiceHandler(svc TickerInfoServiceHandler, opts ...connect.H
 		connect.WithSchema(tickerInfoServiceMethods.ByName("GetTickerValue")),
 		connect.WithHandlerOptions(opts...),
 	)
+	tickerInfoServiceListTickerTypesHandler := connect.NewUnaryHandler(
+		TickerInfoServiceListTickerTypesProcedure,
+		svc.ListTickerTypes,
+		connect.WithSchema(tickerInfoServiceMethods.ByName("ListTickerTypes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tickerInfoServiceStreamTradesHandler := connect.NewServerStreamHandler(
+		TickerInfoServiceStreamTradesProcedure,
+		svc.StreamTrades,
+		connect.WithSchema(tickerInfoServiceMethods.ByName("StreamTrades")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.ticker.v1.TickerInfoService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TickerInfoServiceGetAllTickerNamesProcedure:
 			tickerInfoServiceGetAllTickerNamesHandler.ServeHTTP(w, r)
 		case TickerInfoServiceGetTickerValueProcedure:
 			tickerInfoServiceGetTickerValueHandler.ServeHTTP(w, r)
+		case TickerInfoServiceListTickerTypesProcedure:
+			tickerInfoServiceListTickerTypesHandler.ServeHTTP(w, r)
+		case TickerInfoServiceStreamTradesProcedure:
+			tickerInfoServiceStreamTradesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -137,3 +187,11 @@ func (UnimplementedTickerInfoServiceHandler) GetAllTickerNames(context.Context,
 func (UnimplementedTickerInfoServiceHandler) GetTickerValue(context.Context, *connect.Request[v1.GetTickerValueRequest]) (*connect.Response[v1.GetTickerValueResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.TickerInfoService.GetTickerValue is not implemented"))
 }
+
+func (UnimplementedTickerInfoServiceHandler) ListTickerTypes(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTickerTypesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.TickerInfoService.ListTickerTypes is not implemented"))
+}
+
+func (UnimplementedTickerInfoServiceHandler) StreamTrades(context.Context, *connect.Request[v1.StreamTradesRequest], *connect.ServerStream[v1.StreamTradesResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("api.ticker.v1.TickerInfoService.StreamTrades is not implemented"))
+}